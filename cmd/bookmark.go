@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/yangsijun/bible-tui/internal/bible"
+	"github.com/yangsijun/bible-tui/internal/db"
 	"github.com/spf13/cobra"
 )
 
@@ -23,18 +24,36 @@ var highlightCmd = &cobra.Command{
 
 // Bookmark subcommands
 var bookmarkAddCmd = &cobra.Command{
-	Use:   "add <참조> [--note \"메모\"]",
+	Use:   "add <참조> [--note \"메모\"] [--tag 태그]",
 	Short: "책갈피 추가",
-	Long:  "성경 구절에 책갈피를 추가합니다. 예: bible bookmark add 창 1:1",
+	Long:  "성경 구절에 책갈피를 추가합니다. 범위는 책갈피 하나가 됩니다. 예: bible bookmark add 고전 13:4-7, bible bookmark add 요 3:16 --tag 암송 --tag 복음",
 	Args:  cobra.MinimumNArgs(1),
 	RunE:  runBookmarkAdd,
 }
 
 var bookmarkListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "책갈피 목록",
-	Long:  "저장된 책갈피 목록을 조회합니다.",
-	RunE:  runBookmarkList,
+	Use:         "list",
+	Short:       "책갈피 목록",
+	Long:        "저장된 책갈피 목록을 조회합니다. --tag로 태그가 붙은 책갈피만 봅니다. 예: bible bookmark list --tag 암송",
+	RunE:        runBookmarkList,
+	Annotations: allFormats,
+}
+
+var bookmarkTagsCmd = &cobra.Command{
+	Use:         "tags",
+	Short:       "책갈피 태그 목록",
+	Long:        "책갈피에 붙은 태그와 태그별 책갈피 수를 조회합니다.",
+	Args:        cobra.NoArgs,
+	RunE:        runBookmarkTags,
+	Annotations: allFormats,
+}
+
+var bookmarkEditCmd = &cobra.Command{
+	Use:   "edit <id> --note \"메모\"",
+	Short: "책갈피 메모 수정",
+	Long:  "책갈피 ID로 메모를 바꿉니다. --note \"\"는 메모를 지웁니다. 예: bible bookmark edit 3 --note \"다시 읽기\"",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBookmarkEdit,
 }
 
 var bookmarkRemoveCmd = &cobra.Command{
@@ -49,22 +68,23 @@ var bookmarkRemoveCmd = &cobra.Command{
 var highlightAddCmd = &cobra.Command{
 	Use:   "add <참조> [--color yellow]",
 	Short: "하이라이트 추가",
-	Long:  "성경 구절에 하이라이트를 추가합니다. 예: bible highlight add 창 1:1 --color green",
+	Long:  "성경 구절에 하이라이트를 추가합니다. 범위는 하이라이트 하나가 됩니다. 예: bible highlight add 고전 13:4-7 --color green",
 	Args:  cobra.MinimumNArgs(1),
 	RunE:  runHighlightAdd,
 }
 
 var highlightListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "하이라이트 목록",
-	Long:  "저장된 하이라이트 목록을 조회합니다.",
-	RunE:  runHighlightList,
+	Use:         "list",
+	Short:       "하이라이트 목록",
+	Long:        "저장된 하이라이트 목록을 조회합니다.",
+	RunE:        runHighlightList,
+	Annotations: allFormats,
 }
 
 var highlightRemoveCmd = &cobra.Command{
 	Use:   "remove <참조>",
 	Short: "하이라이트 삭제",
-	Long:  "성경 구절의 하이라이트를 삭제합니다. 예: bible highlight remove 창 1:1",
+	Long:  "성경 구절의 하이라이트를 삭제합니다. 구절을 포함하는 범위 하이라이트도 함께 삭제됩니다. 예: bible highlight remove 창 1:1",
 	Args:  cobra.MinimumNArgs(1),
 	RunE:  runHighlightRemove,
 }
@@ -74,6 +94,9 @@ var (
 	bookmarkNote      string
 	bookmarkVersion   string
 	bookmarkLimit     int
+	bookmarkEditNote  string
+	bookmarkTags      []string
+	bookmarkListTag   string
 	highlightColor    string
 	highlightVersion  string
 	highlightLimit    int
@@ -82,18 +105,21 @@ var (
 func init() {
 	// Bookmark flags
 	bookmarkAddCmd.Flags().StringVar(&bookmarkNote, "note", "", "책갈피 메모")
-	bookmarkAddCmd.Flags().StringVarP(&bookmarkVersion, "version", "v", "GAE", "성경 버전 코드")
+	bookmarkAddCmd.Flags().StringVarP(&bookmarkVersion, "version", "v", "", "성경 버전 코드 (기본: 설정된 역본)")
+	bookmarkAddCmd.Flags().StringSliceVar(&bookmarkTags, "tag", nil, "책갈피 태그 (여러 번 지정 가능)")
 	bookmarkListCmd.Flags().IntVar(&bookmarkLimit, "limit", 20, "조회할 책갈피 개수")
-	bookmarkRemoveCmd.Flags().StringVarP(&bookmarkVersion, "version", "v", "GAE", "성경 버전 코드")
+	bookmarkListCmd.Flags().StringVar(&bookmarkListTag, "tag", "", "이 태그가 붙은 책갈피만 조회")
+	bookmarkEditCmd.Flags().StringVar(&bookmarkEditNote, "note", "", "새 메모 (빈 문자열은 메모 삭제)")
+	bookmarkRemoveCmd.Flags().StringVarP(&bookmarkVersion, "version", "v", "", "성경 버전 코드 (기본: 설정된 역본)")
 
 	// Highlight flags
 	highlightAddCmd.Flags().StringVar(&highlightColor, "color", "yellow", "하이라이트 색상 (yellow, green, blue, pink, purple)")
-	highlightAddCmd.Flags().StringVarP(&highlightVersion, "version", "v", "GAE", "성경 버전 코드")
+	highlightAddCmd.Flags().StringVarP(&highlightVersion, "version", "v", "", "성경 버전 코드 (기본: 설정된 역본)")
 	highlightListCmd.Flags().IntVar(&highlightLimit, "limit", 20, "조회할 하이라이트 개수")
-	highlightRemoveCmd.Flags().StringVarP(&highlightVersion, "version", "v", "GAE", "성경 버전 코드")
+	highlightRemoveCmd.Flags().StringVarP(&highlightVersion, "version", "v", "", "성경 버전 코드 (기본: 설정된 역본)")
 
 	// Register subcommands
-	bookmarkCmd.AddCommand(bookmarkAddCmd, bookmarkListCmd, bookmarkRemoveCmd)
+	bookmarkCmd.AddCommand(bookmarkAddCmd, bookmarkListCmd, bookmarkTagsCmd, bookmarkEditCmd, bookmarkRemoveCmd)
 	highlightCmd.AddCommand(highlightAddCmd, highlightListCmd, highlightRemoveCmd)
 
 	// Register parent commands
@@ -101,40 +127,52 @@ func init() {
 	rootCmd.AddCommand(highlightCmd)
 }
 
-// resolveVerseID resolves a reference to a verse ID and label
-func resolveVerseID(versionCode string, args []string) (int64, string, error) {
-	ref, err := bible.ParseReference(strings.Join(args, " "))
+// resolveVerses resolves a reference list such as "요 3:16,18; 고전 13:4-7"
+// to the verses of each range and a canonical label. Annotations apply to
+// verses within one book, so whole-chapter references and ranges across
+// books are rejected.
+func resolveVerses(versionCode string, args []string) ([][]db.Verse, string, error) {
+	ranges, err := bible.ParseReferences(strings.Join(args, " "))
 	if err != nil {
-		return 0, "", err
+		return nil, "", err
 	}
-
-	if ref.VerseStart == 0 {
-		return 0, "", fmt.Errorf("specific verse required (e.g., 창 1:1)")
+	for _, r := range ranges {
+		if r.Start.Verse == 0 || r.End.Verse == 0 {
+			return nil, "", fmt.Errorf("specific verse required (e.g., 창 1:1)")
+		}
+		if r.Start.BookCode != r.End.BookCode {
+			return nil, "", fmt.Errorf("range must stay within one book: %s", r)
+		}
 	}
+	label := bible.FormatReferences(ranges)
 
 	database, err := getDB()
 	if err != nil {
-		return 0, "", fmt.Errorf("open database: %w", err)
+		return nil, "", fmt.Errorf("open database: %w", err)
 	}
 
-	verses, err := database.GetVerses(versionCode, ref.BookCode, ref.Chapter)
+	versionCode, err = resolveVersion(database, versionCode)
 	if err != nil {
-		return 0, "", err
+		return nil, "", err
 	}
 
-	for _, v := range verses {
-		if v.VerseNum == ref.VerseStart {
-			label := fmt.Sprintf("%s %d:%d", v.BookName, v.Chapter, v.VerseNum)
-			return v.ID, label, nil
+	spans := make([][]db.Verse, len(ranges))
+	for i, r := range ranges {
+		verses, err := database.GetPassage(versionCode, []bible.Range{r})
+		if err != nil {
+			return nil, "", err
 		}
+		if len(verses) == 0 {
+			return nil, "", fmt.Errorf("verse not found: %s", r)
+		}
+		spans[i] = verses
 	}
-
-	return 0, "", fmt.Errorf("verse not found: %s %d:%d", ref.BookCode, ref.Chapter, ref.VerseStart)
+	return spans, label, nil
 }
 
 // Bookmark command implementations
 func runBookmarkAdd(cmd *cobra.Command, args []string) error {
-	verseID, label, err := resolveVerseID(bookmarkVersion, args)
+	spans, label, err := resolveVerses(bookmarkVersion, args)
 	if err != nil {
 		return err
 	}
@@ -144,39 +182,152 @@ func runBookmarkAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("open database: %w", err)
 	}
 
-	_, err = database.AddBookmark(verseID, bookmarkNote)
-	if err != nil {
-		return err
+	// One bookmark per range, so 고전 13:4-7 is a single bookmark.
+	for _, verses := range spans {
+		id, err := database.AddBookmarkRange(verses[0].ID, verses[len(verses)-1].ID, bookmarkNote)
+		if err != nil {
+			return err
+		}
+		if len(bookmarkTags) > 0 {
+			if err := database.TagBookmark(id, bookmarkTags...); err != nil {
+				return err
+			}
+		}
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "책갈피 추가: %s\n", label)
+	if tags := formatTags(bookmarkTags); tags != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "책갈피 추가: %s %s\n", label, tags)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "책갈피 추가: %s\n", label)
+	}
 	return nil
 }
 
+// formatTags renders tag names as "#암송 #복음", skipping empty names.
+func formatTags(tags []string) string {
+	var parts []string
+	for _, tag := range tags {
+		if tag = db.NormalizeTag(tag); tag != "" {
+			parts = append(parts, "#"+tag)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
 func runBookmarkList(cmd *cobra.Command, args []string) error {
 	database, err := getDB()
 	if err != nil {
 		return fmt.Errorf("open database: %w", err)
 	}
 
-	bookmarks, err := database.ListBookmarks(bookmarkLimit, 0)
+	var bookmarks []db.BookmarkWithVerse
+	if bookmarkListTag != "" {
+		bookmarks, err = database.ListBookmarksByTag(bookmarkListTag, bookmarkLimit, 0)
+	} else {
+		bookmarks, err = database.ListBookmarks(bookmarkLimit, 0)
+	}
 	if err != nil {
 		return err
 	}
 
+	switch outputFormat {
+	case formatJSON:
+		if bookmarks == nil {
+			bookmarks = []db.BookmarkWithVerse{}
+		}
+		return writeJSON(cmd.OutOrStdout(), bookmarks)
+	case formatMarkdown:
+		for _, bm := range bookmarks {
+			fmt.Fprintf(cmd.OutOrStdout(), "- **%s** %s\n", bm.Range(), bm.VerseText)
+			if bm.Note != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "  - 메모: %s\n", bm.Note)
+			}
+			if len(bm.Tags) > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "  - 태그: %s\n", formatTags(bm.Tags))
+			}
+		}
+		return nil
+	}
+
 	if len(bookmarks) == 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "저장된 책갈피가 없습니다.")
+		if bookmarkListTag != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s 태그가 붙은 책갈피가 없습니다.\n", formatTags([]string{bookmarkListTag}))
+		} else {
+			fmt.Fprintln(cmd.OutOrStdout(), "저장된 책갈피가 없습니다.")
+		}
 		return nil
 	}
 
 	for _, bm := range bookmarks {
-		fmt.Fprintf(cmd.OutOrStdout(), "[ID:%d] %s %d:%d — %s\n",
-			bm.ID, bm.BookName, bm.Chapter, bm.VerseNum, bm.VerseText)
+		fmt.Fprintf(cmd.OutOrStdout(), "[ID:%d] %s — %s\n", bm.ID, bm.Range(), bm.VerseText)
 		if bm.Note != "" {
-			fmt.Fprintf(cmd.OutOrStdout(), "  메모: %s\n", bm.Note)
+			fmt.Fprintf(cmd.OutOrStdout(), "  메모: %s\n", strings.ReplaceAll(bm.Note, "\n", "\n        "))
+		}
+		if len(bm.Tags) > 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "  태그: %s\n", formatTags(bm.Tags))
+		}
+	}
+
+	return nil
+}
+
+func runBookmarkTags(cmd *cobra.Command, args []string) error {
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+
+	tags, err := database.ListTags()
+	if err != nil {
+		return err
+	}
+
+	switch outputFormat {
+	case formatJSON:
+		if tags == nil {
+			tags = []db.TagCount{}
+		}
+		return writeJSON(cmd.OutOrStdout(), tags)
+	case formatMarkdown:
+		for _, tc := range tags {
+			fmt.Fprintf(cmd.OutOrStdout(), "- #%s (%d)\n", tc.Name, tc.Count)
 		}
+		return nil
+	}
+
+	if len(tags) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "책갈피 태그가 없습니다.")
+		return nil
+	}
+	for _, tc := range tags {
+		fmt.Fprintf(cmd.OutOrStdout(), "#%s  %d개\n", tc.Name, tc.Count)
+	}
+	return nil
+}
+
+func runBookmarkEdit(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid bookmark ID: %w", err)
+	}
+	if !cmd.Flags().Changed("note") {
+		return fmt.Errorf("--note is required (--note \"\" clears the note)")
+	}
+
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+
+	if err := database.UpdateBookmarkNote(id, bookmarkEditNote); err != nil {
+		return err
 	}
 
+	if bookmarkEditNote == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "책갈피 메모 삭제: #%d\n", id)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "책갈피 메모 수정: #%d\n", id)
+	}
 	return nil
 }
 
@@ -214,7 +365,7 @@ func runHighlightAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid color: %s (valid: yellow, green, blue, pink, purple)", highlightColor)
 	}
 
-	verseID, label, err := resolveVerseID(highlightVersion, args)
+	spans, label, err := resolveVerses(highlightVersion, args)
 	if err != nil {
 		return err
 	}
@@ -224,9 +375,10 @@ func runHighlightAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("open database: %w", err)
 	}
 
-	err = database.AddHighlight(verseID, highlightColor)
-	if err != nil {
-		return err
+	for _, verses := range spans {
+		if err := database.AddHighlightRange(verses[0].ID, verses[len(verses)-1].ID, highlightColor); err != nil {
+			return err
+		}
 	}
 
 	fmt.Fprintf(cmd.OutOrStdout(), "하이라이트 추가: %s (%s)\n", label, highlightColor)
@@ -244,21 +396,33 @@ func runHighlightList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	switch outputFormat {
+	case formatJSON:
+		if highlights == nil {
+			highlights = []db.HighlightWithVerse{}
+		}
+		return writeJSON(cmd.OutOrStdout(), highlights)
+	case formatMarkdown:
+		for _, h := range highlights {
+			fmt.Fprintf(cmd.OutOrStdout(), "- **%s** %s (%s)\n", h.Range(), h.VerseText, h.Color)
+		}
+		return nil
+	}
+
 	if len(highlights) == 0 {
 		fmt.Fprintln(cmd.OutOrStdout(), "저장된 하이라이트가 없습니다.")
 		return nil
 	}
 
 	for _, h := range highlights {
-		fmt.Fprintf(cmd.OutOrStdout(), "[%s] %s %d:%d — %s\n",
-			h.Color, h.BookName, h.Chapter, h.VerseNum, h.VerseText)
+		fmt.Fprintf(cmd.OutOrStdout(), "[%s] %s — %s\n", h.Color, h.Range(), h.VerseText)
 	}
 
 	return nil
 }
 
 func runHighlightRemove(cmd *cobra.Command, args []string) error {
-	verseID, label, err := resolveVerseID(highlightVersion, args)
+	spans, label, err := resolveVerses(highlightVersion, args)
 	if err != nil {
 		return err
 	}
@@ -268,9 +432,13 @@ func runHighlightRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("open database: %w", err)
 	}
 
-	err = database.RemoveHighlight(verseID)
-	if err != nil {
-		return err
+	// Highlights covering any of the verses go, whole ranges included.
+	for _, verses := range spans {
+		for _, v := range verses {
+			if err := database.RemoveHighlight(v.ID); err != nil {
+				return err
+			}
+		}
 	}
 
 	fmt.Fprintf(cmd.OutOrStdout(), "하이라이트 삭제: %s\n", label)
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -119,6 +120,117 @@ func TestBookmarkRemove(t *testing.T) {
 	}
 }
 
+func TestBookmarkEdit(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+
+	verses, err := database.GetVerses("GAE", "gen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := database.AddBookmark(verses[0].ID, "처음")
+	if err != nil {
+		t.Fatal(err)
+	}
+	arg := strconv.FormatInt(id, 10)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"bookmark", "edit", arg})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--note is required") {
+		t.Errorf("expected missing --note error, got %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"bookmark", "edit", arg, "--note", "다시 읽기"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "책갈피 메모 수정: #"+arg) {
+		t.Errorf("expected edit message, got: %s", buf.String())
+	}
+	if bm, _ := database.GetBookmark(id); bm.Note != "다시 읽기" {
+		t.Errorf("expected note updated, got %q", bm.Note)
+	}
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"bookmark", "edit", arg, "--note", ""})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bm, _ := database.GetBookmark(id); bm.Note != "" || !strings.Contains(buf.String(), "메모 삭제") {
+		t.Errorf("expected note cleared, got %q (%s)", bm.Note, buf.String())
+	}
+
+	rootCmd.SetArgs([]string{"bookmark", "edit", "999", "--note", "없음"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestBookmarkTags(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		bookmarkTags = nil
+		bookmarkListTag = ""
+		outputFormat = formatText
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"bookmark", "add", "창", "1:1", "--tag", "암송", "--tag", "복음"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "책갈피 추가: 창세기 1:1 #암송 #복음") {
+		t.Errorf("expected tags in add message, got: %s", buf.String())
+	}
+
+	bookmarkTags = nil
+	rootCmd.SetArgs([]string{"bookmark", "add", "창", "1:3", "--tag", "설교"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"bookmark", "list", "--tag", "암송"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "창세기 1:1") || strings.Contains(output, "창세기 1:3") {
+		t.Errorf("expected only the 암송 bookmark, got: %s", output)
+	}
+	if !strings.Contains(output, "태그: #복음 #암송") {
+		t.Errorf("expected tags line, got: %s", output)
+	}
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"bookmark", "list", "--tag", "위로"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "#위로 태그가 붙은 책갈피가 없습니다") {
+		t.Errorf("expected empty tag message, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	bookmarkListTag = ""
+	rootCmd.SetArgs([]string{"bookmark", "tags", "--format", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{`"name": "복음"`, `"name": "설교"`, `"count": 1`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("expected tags JSON to contain %s, got: %s", want, buf.String())
+		}
+	}
+}
+
 func TestHighlightAdd(t *testing.T) {
 	database := setupTestDB(t)
 	testDB = database
@@ -320,3 +432,104 @@ func TestHighlightAddInvalidColor(t *testing.T) {
 		t.Fatalf("expected error for invalid color, got nil")
 	}
 }
+
+func TestBookmarkAdd_ReferenceList(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"bookmark", "add", "창", "1:1,3"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "창세기 1:1, 3") {
+		t.Errorf("expected canonical label '창세기 1:1, 3', got: %s", buf.String())
+	}
+
+	bookmarks, err := database.ListBookmarks(10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bookmarks) != 2 {
+		t.Errorf("expected 2 bookmarks, got %d", len(bookmarks))
+	}
+}
+
+func TestBookmarkAndHighlightRange(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		highlightColor = "yellow"
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"bookmark", "add", "창", "1:1-3"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bookmarks, err := database.ListBookmarks(10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bookmarks) != 1 || bookmarks[0].VerseNum != 1 || bookmarks[0].EndVerse != 3 {
+		t.Fatalf("expected one 1:1-3 bookmark, got %+v", bookmarks)
+	}
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"bookmark", "list"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "창세기 1:1-3 — 태초에 하나님이 천지를 창조하시니라 땅이 혼돈하고") {
+		t.Errorf("expected the range label and text, got: %s", buf.String())
+	}
+
+	rootCmd.SetArgs([]string{"highlight", "add", "창", "1:2-3", "--color", "blue"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buf.Reset()
+	rootCmd.SetArgs([]string{"highlight", "list"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "[blue] 창세기 1:2-3 — ") {
+		t.Errorf("expected the range highlight, got: %s", buf.String())
+	}
+
+	// Removing one verse of the range removes the whole highlight.
+	rootCmd.SetArgs([]string{"highlight", "remove", "창", "1:3"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if highlights, _ := database.ListHighlights(10, 0); len(highlights) != 0 {
+		t.Errorf("expected no highlights, got %+v", highlights)
+	}
+
+	rootCmd.SetArgs([]string{"bookmark", "add", "창", "1:3-출", "1:1"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "within one book") {
+		t.Errorf("expected a cross-book error, got %v", err)
+	}
+}
+
+func TestBookmarkAdd_WholeChapterRejected(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"bookmark", "add", "창", "1"})
+
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("expected error for whole-chapter reference")
+	}
+}
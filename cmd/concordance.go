@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/yangsijun/bible-tui/internal/db"
+)
+
+var concordanceCmd = &cobra.Command{
+	Use:   "concordance <단어>",
+	Short: "용어 색인",
+	Long: `단어가 나오는 모든 구절을 책별로 묶어 횟수와 함께 보여줍니다.
+검색처럼 낱말 안에서도 찾으므로 "사랑"은 사랑하사, 사랑을도 포함합니다.
+예: bible concordance 사랑, bible concordance 믿음 --book 롬,갈`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runConcordance,
+}
+
+var (
+	concordanceVersion   string
+	concordanceBooks     []string
+	concordanceTestament string
+)
+
+// concordanceMaxForms is how many word forms the summary line lists.
+const concordanceMaxForms = 10
+
+func init() {
+	concordanceCmd.Flags().StringVarP(&concordanceVersion, "version", "v", "", "성경 버전 코드 (기본: 설정된 역본)")
+	concordanceCmd.Flags().StringSliceVar(&concordanceBooks, "book", nil, "찾을 책 (예: 롬, 여러 권은 롬,고전)")
+	concordanceCmd.Flags().StringVar(&concordanceTestament, "testament", "", "찾을 성경: old(구약) 또는 new(신약)")
+	rootCmd.AddCommand(concordanceCmd)
+}
+
+func runConcordance(cmd *cobra.Command, args []string) error {
+	word := strings.Join(args, " ")
+	var scope db.SearchQuery
+	if err := applyScopeFlags(&scope, concordanceBooks, concordanceTestament); err != nil {
+		return err
+	}
+
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+
+	versionCode, err := resolveVersion(database, concordanceVersion)
+	if err != nil {
+		return err
+	}
+
+	c, err := database.Concordance(versionCode, word, scope)
+	if err != nil {
+		return fmt.Errorf("concordance: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if c.Total == 0 {
+		fmt.Fprintf(out, "\"%s\"이(가) 나오는 구절이 없습니다\n", c.Word)
+		return nil
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	highlightStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
+
+	fmt.Fprintf(out, "\"%s\" 용어 색인: %d회 (%d절, %d권)\n", c.Word, c.Total, c.Verses, len(c.Books))
+	if len(c.Forms) > 0 {
+		var forms []string
+		for _, f := range c.Forms[:min(len(c.Forms), concordanceMaxForms)] {
+			forms = append(forms, fmt.Sprintf("%s %d", f.Word, f.Count))
+		}
+		line := strings.Join(forms, " · ")
+		if rest := len(c.Forms) - concordanceMaxForms; rest > 0 {
+			line += fmt.Sprintf(" 외 %d개", rest)
+		}
+		fmt.Fprintf(out, "형태: %s\n", line)
+	}
+
+	for _, book := range c.Books {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, titleStyle.Render(fmt.Sprintf("%s (%d회)", book.BookName, book.Count)))
+		for _, r := range book.Results {
+			snippet := r.Snippet
+			if snippet == "" {
+				snippet = r.Verse.Text
+			}
+			for _, term := range r.Highlights {
+				snippet = highlightSearchTerm(snippet, term, highlightStyle)
+			}
+			ref := fmt.Sprintf("%d:%d", r.Verse.Chapter, r.Verse.VerseNum)
+			fmt.Fprintf(out, "  %-7s %s\n", ref, snippet)
+		}
+	}
+	return nil
+}
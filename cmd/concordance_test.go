@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConcordanceCommand(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"concordance", "하나님"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		`"하나님" 용어 색인: 3회 (3절, 1권)`,
+		"형태: 하나님이 2 · 하나님의 1",
+		"창세기 (3회)",
+		"1:2",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got: %s", want, output)
+		}
+	}
+}
+
+func TestConcordanceCommand_NotFound(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		concordanceTestament = ""
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"concordance", "하나님", "--testament", "new"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "나오는 구절이 없습니다") {
+		t.Errorf("expected no-occurrence message, got: %s", buf.String())
+	}
+}
@@ -24,7 +24,7 @@ var crawlCmd = &cobra.Command{
 }
 
 func init() {
-	crawlCmd.Flags().StringVar(&crawlVersion, "version", "GAE", "version code")
+	crawlCmd.Flags().StringVar(&crawlVersion, "version", "", "version code (GAE, HAN, ...; default: configured version)")
 	crawlCmd.Flags().StringVar(&crawlBook, "book", "", "specific book code to crawl (empty = all)")
 	crawlCmd.Flags().BoolVar(&crawlDryRun, "dry-run", false, "only create DB schema, don't crawl")
 	crawlCmd.Flags().BoolVar(&crawlReset, "reset", false, "delete crawled data and re-crawl")
@@ -43,26 +43,31 @@ func runCrawl(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("migrate database: %w", err)
 	}
 
+	versionCode, err := resolveVersion(database, crawlVersion)
+	if err != nil {
+		return err
+	}
+
 	if crawlDryRun {
 		fmt.Fprintf(cmd.OutOrStdout(), "DB 스키마 생성 완료\n")
 		return nil
 	}
 
 	if crawlReset {
-		deleted, err := database.ResetCrawlData(crawlVersion, crawlBook)
+		deleted, err := database.ResetCrawlData(versionCode, crawlBook)
 		if err != nil {
 			return fmt.Errorf("reset crawl data: %w", err)
 		}
-		target := crawlVersion
+		target := versionCode
 		if crawlBook != "" {
 			target += "/" + crawlBook
 		}
-		fmt.Fprintf(cmd.OutOrStdout(), "%s 크롤링 데이터 삭제 완료 (구절 %d개)\n", target, deleted)
+		fmt.Fprintf(cmd.OutOrStdout(), "%s 크롤링 데이터 삭제 완료 (구절 %d개, 책갈피·하이라이트 유지)\n", target, deleted)
 	}
 
 	c := crawler.New(
 		database,
-		crawler.WithVersionCode(crawlVersion),
+		crawler.WithVersionCode(versionCode),
 		crawler.WithOnProgress(func(bookName string, chapter, totalChapters int) {
 			fmt.Fprintf(cmd.OutOrStdout(), "[%d/%d] %s %d장 크롤링 완료\n", chapter, totalChapters, bookName, chapter)
 		}),
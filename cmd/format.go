@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+)
+
+// Output formats selected with the global --format flag.
+const (
+	formatText     = "text"     // styled for a terminal, unstyled when piped
+	formatPlain    = "plain"    // text without ANSI styling
+	formatJSON     = "json"     // the JSON schema of the db types
+	formatMarkdown = "markdown" // for pasting into notes
+)
+
+// formatsAnnotation lists the formats beyond text and plain that a command
+// can print, such as "json,markdown". Every command accepts text and plain.
+const formatsAnnotation = "formats"
+
+var outputFormat string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", formatText, "출력 형식: text, json, markdown, plain")
+	rootCmd.PersistentPreRunE = checkOutputFormat
+}
+
+// checkOutputFormat validates --format for cmd and turns off ANSI styling
+// unless text goes to a terminal.
+func checkOutputFormat(cmd *cobra.Command, args []string) error {
+	switch outputFormat {
+	case formatText, formatPlain:
+	case formatJSON, formatMarkdown:
+		if !slices.Contains(strings.Split(cmd.Annotations[formatsAnnotation], ","), outputFormat) {
+			return fmt.Errorf("--format %s is not supported by %s", outputFormat, cmd.CommandPath())
+		}
+	default:
+		return fmt.Errorf("unknown format %q (use text, json, markdown or plain)", outputFormat)
+	}
+	if outputFormat != formatText || !isTerminal(cmd.OutOrStdout()) {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	return nil
+}
+
+// allFormats is the Annotations of commands that print every format.
+var allFormats = map[string]string{formatsAnnotation: "json,markdown"}
+
+// jsonFormat is the Annotations of commands that print JSON but not
+// Markdown.
+var jsonFormat = map[string]string{formatsAnnotation: "json"}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encode json: %w", err)
+	}
+	return nil
+}
+
+// markdownCell escapes text for a Markdown table cell.
+func markdownCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
+
+// markdownBold renders text in bold Markdown, for highlighting matches.
+func markdownBold(text string) string {
+	return "**" + text + "**"
+}
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/yangsijun/bible-tui/internal/db"
+)
+
+func TestFormat_ReadJSON(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		outputFormat = formatText
+		readFootnotes = false
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"read", "창", "1:1-2", "--footnotes", "--format", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got struct {
+		Reference string        `json:"reference"`
+		Version   string        `json:"version"`
+		Verses    []db.Verse    `json:"verses"`
+		Footnotes []db.Footnote `json:"footnotes"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if got.Reference != "창세기 1:1-2" || got.Version != "GAE" {
+		t.Errorf("reference/version = %q/%q", got.Reference, got.Version)
+	}
+	if len(got.Verses) != 2 || got.Verses[1].VerseNum != 2 || got.Verses[0].BookCode != "gen" || got.Verses[0].SectionTitle != "천지 창조" {
+		t.Errorf("unexpected verses: %+v", got.Verses)
+	}
+	if len(got.Footnotes) != 1 || got.Footnotes[0].Marker != "1)" || got.Footnotes[0].VerseID != got.Verses[1].ID {
+		t.Errorf("unexpected footnotes: %+v", got.Footnotes)
+	}
+	for _, key := range []string{`"book_name": "창세기"`, `"has_footnote": true`, `"verse": 1`} {
+		if !strings.Contains(buf.String(), key) {
+			t.Errorf("expected JSON to contain %s, got: %s", key, buf.String())
+		}
+	}
+}
+
+func TestFormat_ReadMarkdown(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		outputFormat = formatText
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"read", "창", "1:1-3", "--format", "markdown"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"## 창세기 1장\n", "### 천지 창조\n", "**1** 태초에", "**3** 하나님이 이르시되"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got: %s", want, output)
+		}
+	}
+	if strings.Contains(output, "\x1b[") {
+		t.Errorf("expected no ANSI escapes, got: %q", output)
+	}
+}
+
+func TestFormat_ReadCompareMarkdown(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		outputFormat = formatText
+		readCompare = ""
+	}()
+
+	vID, err := database.InsertVersion("HAN", "개역한글", "ko")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bookID, err := database.InsertBook(vID, "gen", "창세기", "창", "old", 50, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.InsertVerse(bookID, 1, 1, "태초에 | 한글", "", false); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"read", "창", "1:1-2", "--compare", "han", "--format", "markdown"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"| 절 | GAE | HAN |", `| 1 | 태초에 하나님이 천지를 창조하시니라 | 태초에 \| 한글 |`, "| 2 | 땅이 혼돈하고"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got: %s", want, output)
+		}
+	}
+}
+
+func TestFormat_SearchJSON(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		outputFormat = formatText
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"search", "빛", "--format", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got struct {
+		Query      string            `json:"query"`
+		Total      int               `json:"total"`
+		Results    []db.SearchResult `json:"results"`
+		BookCounts []db.BookCount    `json:"book_counts"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if got.Query != "빛" || got.Total != 1 || len(got.Results) != 1 {
+		t.Fatalf("unexpected search JSON: %+v", got)
+	}
+	if r := got.Results[0]; r.Verse.VerseNum != 3 || r.MatchCount != 2 || len(r.Highlights) == 0 {
+		t.Errorf("unexpected result: %+v", r)
+	}
+	if len(got.BookCounts) != 1 || got.BookCounts[0].BookCode != "gen" {
+		t.Errorf("unexpected book counts: %+v", got.BookCounts)
+	}
+
+	// No results is still an object with empty lists.
+	buf.Reset()
+	rootCmd.SetArgs([]string{"search", "없는말", "--format", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"results": []`) || !strings.Contains(buf.String(), `"total": 0`) {
+		t.Errorf("expected empty results, got: %s", buf.String())
+	}
+}
+
+func TestFormat_SearchMarkdown(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		outputFormat = formatText
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"search", "빛", "--format", "markdown"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{`## "빛" 검색 결과 (1건)`, "1. **창세기 1:3** — ", "**빛**이 있으라"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got: %s", want, output)
+		}
+	}
+}
+
+func TestFormat_RandomAndListsJSON(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		outputFormat = formatText
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+
+	// Empty lists print [] rather than null.
+	for _, args := range [][]string{{"bookmark", "list"}, {"highlight", "list"}} {
+		buf.Reset()
+		rootCmd.SetArgs(append(args, "--format", "json"))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		if strings.TrimSpace(buf.String()) != "[]" {
+			t.Errorf("%v: expected [], got: %s", args, buf.String())
+		}
+	}
+
+	verses, err := database.GetVerses("GAE", "gen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.AddBookmark(verses[0].ID, "시작"); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.AddHighlight(verses[2].ID, "green"); err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"bookmark", "list", "--format", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var bookmarks []db.BookmarkWithVerse
+	if err := json.Unmarshal(buf.Bytes(), &bookmarks); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if len(bookmarks) != 1 || bookmarks[0].Note != "시작" || bookmarks[0].VerseText != verses[0].Text || bookmarks[0].VerseNum != 1 || bookmarks[0].VersionCode != "GAE" {
+		t.Errorf("unexpected bookmarks: %+v", bookmarks)
+	}
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"highlight", "list", "--format", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var highlights []db.HighlightWithVerse
+	if err := json.Unmarshal(buf.Bytes(), &highlights); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if len(highlights) != 1 || highlights[0].Color != "green" || highlights[0].BookCode != "gen" || highlights[0].VerseNum != 3 || highlights[0].VersionCode != "GAE" {
+		t.Errorf("unexpected highlights: %+v", highlights)
+	}
+	if !strings.Contains(buf.String(), `"version": "GAE"`) {
+		t.Errorf("expected the version in the JSON, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"random", "--format", "json"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var verse db.Verse
+	if err := json.Unmarshal(buf.Bytes(), &verse); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if verse.BookCode != "gen" || verse.Text == "" {
+		t.Errorf("unexpected random verse: %+v", verse)
+	}
+}
+
+func TestFormat_Unsupported(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		outputFormat = formatText
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+
+	rootCmd.SetArgs([]string{"stats", "words", "--format", "json"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "--format json is not supported by bible stats words") {
+		t.Errorf("expected unsupported format error, got %v", err)
+	}
+
+	rootCmd.SetArgs([]string{"random", "--format", "yaml"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), `unknown format "yaml"`) {
+		t.Errorf("expected unknown format error, got %v", err)
+	}
+
+	// plain works everywhere.
+	buf.Reset()
+	rootCmd.SetArgs([]string{"stats", "words", "--format", "plain"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "단어 빈도") || strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("expected unstyled word counts, got: %q", buf.String())
+	}
+}
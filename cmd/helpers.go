@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/yangsijun/bible-tui/internal/bible"
+	"github.com/yangsijun/bible-tui/internal/config"
 	"github.com/yangsijun/bible-tui/internal/db"
 )
 
@@ -18,13 +21,56 @@ func getDB() (*db.DB, error) {
 }
 
 func openDB() (*db.DB, error) {
-	configDir, err := os.UserConfigDir()
+	configDir, err := config.Dir()
 	if err != nil {
-		return nil, fmt.Errorf("get config dir: %w", err)
+		return nil, err
 	}
-	dbPath := filepath.Join(configDir, "bible-tui", "bible.db")
+	dbPath := filepath.Join(configDir, "bible.db")
 	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
 		return nil, fmt.Errorf("create config dir: %w", err)
 	}
-	return db.Open(dbPath)
+	database, err := db.Open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	// Bring databases from older releases up to date before any command
+	// queries tables whose layout has changed.
+	if err := database.Migrate(); err != nil {
+		database.Close()
+		return nil, fmt.Errorf("migrate database: %w", err)
+	}
+	return database, nil
+}
+
+// resolveVersion returns the version code given by a --version flag,
+// falling back to the configured default version when the flag is empty.
+func resolveVersion(database *db.DB, flagValue string) (string, error) {
+	if flagValue != "" {
+		return strings.ToUpper(flagValue), nil
+	}
+	cfg, err := config.LoadConfig(database)
+	if err != nil {
+		return "", fmt.Errorf("load config: %w", err)
+	}
+	return cfg.VersionCode, nil
+}
+
+// applyScopeFlags narrows q to the books of a --book flag and the
+// testament of a --testament flag.
+func applyScopeFlags(q *db.SearchQuery, books []string, testament string) error {
+	for _, name := range books {
+		book, err := bible.FindBook(name)
+		if err != nil {
+			return fmt.Errorf("--book: %w", err)
+		}
+		q.Books = append(q.Books, book.Code)
+	}
+	if testament != "" {
+		t, err := db.ParseTestament(testament)
+		if err != nil {
+			return fmt.Errorf("--testament: %w", err)
+		}
+		q.Testament = t
+	}
+	return nil
 }
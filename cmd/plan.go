@@ -0,0 +1,717 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
+	"github.com/yangsijun/bible-tui/internal/config"
+	"github.com/yangsijun/bible-tui/internal/db"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "읽기 계획 관리",
+	Long: `읽기 계획을 만들고, 오늘 읽을 곳을 보고, 읽은 곳을 체크합니다.
+--plan을 주지 않으면 가장 최근에 만든 계획을 씁니다.`,
+}
+
+var planTodayCmd = &cobra.Command{
+	Use:   "today",
+	Short: "오늘 읽을 곳",
+	Long: `오늘 읽을 곳과 각 읽기의 ID를 출력합니다. --text를 주면 본문도 함께 출력합니다.
+예: bible plan today, bible plan today --text, bible plan today --plan 2`,
+	Args:        cobra.NoArgs,
+	RunE:        runPlanToday,
+	Annotations: allFormats,
+}
+
+var planCheckCmd = &cobra.Command{
+	Use:   "check <읽기 ID|all>",
+	Short: "읽은 곳 체크",
+	Long: `bible plan today에 나온 ID의 읽기를 완료로 체크합니다. all은 오늘 읽을 곳을 모두 체크합니다.
+예: bible plan check 34, bible plan check all`,
+	Args:        cobra.ExactArgs(1),
+	RunE:        runPlanCheck,
+	Annotations: jsonFormat,
+}
+
+var planStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "진행률과 연속 읽기",
+	Long: `계획마다 진행률과 연속으로 읽은 날 수를 보여줍니다. 연속 기록은 오늘 아직 읽지 않았으면 어제까지로 셉니다.
+예: bible plan status, bible plan status --plan 2`,
+	Args:        cobra.NoArgs,
+	RunE:        runPlanStatus,
+	Annotations: jsonFormat,
+}
+
+var planListCmd = &cobra.Command{
+	Use:         "list",
+	Short:       "읽기 계획 목록",
+	Args:        cobra.NoArgs,
+	RunE:        runPlanList,
+	Annotations: jsonFormat,
+}
+
+var planDeleteCmd = &cobra.Command{
+	Use:         "delete <id>",
+	Short:       "읽기 계획 삭제",
+	Long:        "읽기 계획과 체크한 기록을 삭제합니다. 예: bible plan delete 3",
+	Args:        cobra.ExactArgs(1),
+	RunE:        runPlanDelete,
+	Annotations: jsonFormat,
+}
+
+var planNewCmd = &cobra.Command{
+	Use:   "new [프리셋]",
+	Short: "읽기 계획 만들기",
+	Long: `프리셋이나 고른 책으로 읽기 계획을 만듭니다. 분량은 기본으로 절 수에 맞춰 나눠서,
+짧은 장이 많은 날과 긴 장이 있는 날의 읽을 양이 비슷해집니다.
+
+프리셋:
+  chronological     연대순 1년 통독
+  nt90              신약 90일
+  psalms-proverbs   시편·잠언 한 달
+
+예: bible plan new nt90
+    bible plan new --books mat-jhn --days 40
+    bible plan new --books 롬,갈,엡 --per-day 2 --weekdays`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPlanNew,
+}
+
+var planImportCmd = &cobra.Command{
+	Use:   "import <파일>",
+	Short: "계획 파일 가져오기",
+	Long: `JSON 계획 파일로 읽기 계획을 만듭니다. days의 N번째 항목이 N일차에 읽을 곳이고,
+빈 문자열은 쉬는 날입니다. 가져온 파일은 계획 폴더에 복사되어 TUI의 새 계획 화면에서 다시 고를 수 있습니다.
+
+  {
+    "name": "사복음서 40일",
+    "start_date": "2026-01-01",
+    "days": ["마 1-3", "마 4-6; 시 1", ""]
+  }
+
+예: bible plan import gospels.json --start 2026-03-01`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlanImport,
+}
+
+var planExportCmd = &cobra.Command{
+	Use:   "export <id>",
+	Short: "읽기 계획을 계획 파일로 내보내기",
+	Long:  "읽기 계획을 bible plan import로 다시 가져올 수 있는 JSON 계획 파일로 출력합니다. 완료 여부는 포함되지 않습니다. 예: bible plan export 3 > plan.json",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPlanExport,
+}
+
+var (
+	planVersion  string
+	planStart    string
+	planName     string
+	planBooks    string
+	planDays     int
+	planPerDay   int
+	planWeekdays bool
+	planBalance  string
+	planSelect   int64
+	planText     bool
+)
+
+func init() {
+	planNewCmd.Flags().StringVarP(&planVersion, "version", "v", "", "성경 버전 코드 (기본: 설정된 역본)")
+	planNewCmd.Flags().StringVar(&planStart, "start", "", "시작일 YYYY-MM-DD (기본: 오늘)")
+	planNewCmd.Flags().StringVar(&planName, "name", "", "계획 이름 (기본: 책과 기간으로 지은 이름)")
+	planNewCmd.Flags().StringVar(&planBooks, "books", "", "읽을 책, 예: mat-jhn, 마-요, 롬,갈,엡")
+	planNewCmd.Flags().IntVar(&planDays, "days", 0, "읽는 날 수")
+	planNewCmd.Flags().IntVar(&planPerDay, "per-day", 0, "하루에 읽을 장 수 (--days 대신)")
+	planNewCmd.Flags().BoolVar(&planWeekdays, "weekdays", false, "주말은 쉬기")
+	planNewCmd.Flags().StringVar(&planBalance, "balance", "verses", "분량 기준: verses(절 수) 또는 chapters(장 수)")
+
+	planImportCmd.Flags().StringVarP(&planVersion, "version", "v", "", "성경 버전 코드 (기본: 설정된 역본)")
+	planImportCmd.Flags().StringVar(&planStart, "start", "", "시작일 YYYY-MM-DD (기본: 파일의 start_date, 없으면 오늘)")
+
+	for _, c := range []*cobra.Command{planTodayCmd, planCheckCmd, planStatusCmd} {
+		c.Flags().Int64Var(&planSelect, "plan", 0, "계획 ID (기본: 가장 최근에 만든 계획)")
+	}
+	planTodayCmd.Flags().BoolVar(&planText, "text", false, "본문도 함께 출력")
+
+	planCmd.AddCommand(planTodayCmd, planCheckCmd, planStatusCmd, planListCmd,
+		planNewCmd, planImportCmd, planExportCmd, planDeleteCmd)
+	rootCmd.AddCommand(planCmd)
+}
+
+func runPlanNew(cmd *cobra.Command, args []string) error {
+	if planBalance != "verses" && planBalance != "chapters" {
+		return fmt.Errorf("--balance must be verses or chapters: %s", planBalance)
+	}
+	start, err := parsePlanStart()
+	if err != nil {
+		return err
+	}
+
+	var spec bible.PlanSpec
+	planType, name := "custom", planName
+	switch {
+	case len(args) == 1 && planBooks != "":
+		return fmt.Errorf("a preset cannot be combined with --books")
+	case len(args) == 1:
+		preset, ok := bible.GetPlanPreset(args[0])
+		if !ok {
+			return fmt.Errorf("unknown preset: %s", args[0])
+		}
+		spec, planType = preset.Spec, preset.Key
+		if name == "" {
+			name = preset.Name
+		}
+	case planBooks != "":
+		books, err := bible.ParseBookList(planBooks)
+		if err != nil {
+			return fmt.Errorf("--books: %w", err)
+		}
+		spec.Tracks = [][]string{books}
+		if name == "" {
+			name = bible.BookListLabel(books)
+		}
+	default:
+		return fmt.Errorf("give a preset or --books")
+	}
+
+	// --days and --per-day replace the pace of a preset, too.
+	if planDays != 0 || planPerDay != 0 {
+		spec.Days, spec.ChaptersPerDay = planDays, planPerDay
+		if planName == "" && planType != "custom" {
+			name = fmt.Sprintf("%s (%s)", name, spec.PaceLabel())
+		}
+	}
+	if planName == "" && planType == "custom" {
+		name += " " + spec.PaceLabel()
+	}
+	spec.WeekdaysOnly = planWeekdays
+	spec.Start = start
+
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	versionID, err := planVersionID(database)
+	if err != nil {
+		return err
+	}
+	if planBalance == "verses" {
+		if spec.VerseCounts, err = database.VerseCounts(versionID); err != nil {
+			return err
+		}
+	}
+
+	planID, err := database.CreateGeneratedPlan(versionID, name, planType, spec)
+	if err != nil {
+		return err
+	}
+	return printPlanCreated(cmd, database, planID)
+}
+
+// parsePlanStart parses --start, returning the zero time when it is unset.
+func parsePlanStart() (time.Time, error) {
+	if planStart == "" {
+		return time.Time{}, nil
+	}
+	start, err := time.ParseInLocation("2006-01-02", planStart, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--start must be YYYY-MM-DD: %s", planStart)
+	}
+	return start, nil
+}
+
+// planVersionID returns the ID of the version given by -v, or of the
+// configured one.
+func planVersionID(database *db.DB) (int64, error) {
+	versionCode, err := resolveVersion(database, planVersion)
+	if err != nil {
+		return 0, err
+	}
+	version, err := database.GetVersionByCode(versionCode)
+	if err != nil {
+		return 0, fmt.Errorf("unknown version %s: %w", versionCode, err)
+	}
+	return version.ID, nil
+}
+
+func printPlanCreated(cmd *cobra.Command, database *db.DB, planID int64) error {
+	plan, err := database.GetPlan(planID)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "읽기 계획 추가: %s (ID:%d, %d일, %s 시작)\n",
+		plan.Name, plan.ID, plan.TotalDays, plan.StartDate.Format("2006-01-02"))
+	return nil
+}
+
+func runPlanImport(cmd *cobra.Command, args []string) error {
+	path := args[0]
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read plan file: %w", err)
+	}
+	f, err := db.ParsePlanFile(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if _, err := parsePlanStart(); err != nil {
+		return err
+	} else if planStart != "" {
+		f.StartDate = planStart
+	}
+
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	versionID, err := planVersionID(database)
+	if err != nil {
+		return err
+	}
+
+	planID, err := database.ImportPlan(versionID, f)
+	if err != nil {
+		return err
+	}
+	if err := printPlanCreated(cmd, database, planID); err != nil {
+		return err
+	}
+
+	// The plan is already saved; failing to keep a copy only costs the
+	// TUI shortcut, so it is reported without failing the import.
+	if err := savePlanFile(path, data); err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "계획 폴더에 복사하지 못했습니다: %v\n", err)
+	}
+	return nil
+}
+
+// savePlanFile copies an imported plan file into the plan folder under its
+// own name, unless it is already there.
+func savePlanFile(path string, data []byte) error {
+	dir, err := config.PlanDir()
+	if err != nil {
+		return err
+	}
+	dest := filepath.Join(dir, filepath.Base(path))
+	if existing, err := os.ReadFile(dest); err == nil && bytes.Equal(existing, data) {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(dest, data, 0o644)
+}
+
+func runPlanExport(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid plan ID: %w", err)
+	}
+
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	f, err := database.ExportPlan(id)
+	if err != nil {
+		return err
+	}
+	return writeJSON(cmd.OutOrStdout(), f)
+}
+
+// selectPlan returns the plan given by --plan, or the most recently
+// created one.
+func selectPlan(database *db.DB) (*db.ReadingPlan, error) {
+	if planSelect != 0 {
+		plan, err := database.GetPlan(planSelect)
+		if err != nil {
+			return nil, err
+		}
+		if plan == nil {
+			return nil, fmt.Errorf("plan %d not found", planSelect)
+		}
+		return plan, nil
+	}
+	plans, err := database.ListPlans()
+	if err != nil {
+		return nil, err
+	}
+	if len(plans) == 0 {
+		return nil, fmt.Errorf("no reading plan (create one with bible plan new)")
+	}
+	return &plans[0], nil
+}
+
+// planVersionCode returns the code of the version a plan was made for.
+func planVersionCode(database *db.DB, plan *db.ReadingPlan) (string, error) {
+	versions, err := database.ListVersions()
+	if err != nil {
+		return "", err
+	}
+	for _, v := range versions {
+		if v.ID == plan.VersionID {
+			return v.Code, nil
+		}
+	}
+	return "", fmt.Errorf("version of plan %d not found", plan.ID)
+}
+
+// entryLabel names the chapters of a plan entry, e.g. "창세기 1-3".
+func entryLabel(e db.PlanEntry) string {
+	return bible.FormatReferences([]bible.Range{e.Range()})
+}
+
+// planDayLabel describes where a plan stands on its day: "12일차", or
+// when it has not started yet "2026-03-01 시작 (3일 남음)".
+func planDayLabel(plan *db.ReadingPlan, day int) string {
+	if day < 1 {
+		return fmt.Sprintf("%s 시작 (%d일 남음)", plan.StartDate.Format("2006-01-02"), 1-day)
+	}
+	return fmt.Sprintf("%d일차", day)
+}
+
+// planTodayJSON is the --format json output of plan today.
+type planTodayJSON struct {
+	Plan    db.ReadingPlan `json:"plan"`
+	Date    string         `json:"date"`
+	Day     int            `json:"day"`
+	Entries []db.PlanEntry `json:"entries"`
+	Overdue []db.PlanEntry `json:"overdue"`
+	Verses  []db.Verse     `json:"verses,omitempty"`
+}
+
+func runPlanToday(cmd *cobra.Command, args []string) error {
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	plan, err := selectPlan(database)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	entries, err := database.GetEntriesOn(plan.ID, now)
+	if err != nil {
+		return err
+	}
+	status, err := database.GetPlanStatus(plan.ID, now)
+	if err != nil {
+		return err
+	}
+	// Past the last day the last day's readings are shown.
+	day := status.Day
+	if len(entries) > 0 {
+		day = entries[0].DayNumber
+	}
+
+	var versionCode string
+	var verses []db.Verse
+	if planText && len(entries) > 0 {
+		if versionCode, err = planVersionCode(database, plan); err != nil {
+			return err
+		}
+		var ranges []bible.Range
+		for _, e := range entries {
+			ranges = append(ranges, e.Range())
+		}
+		if verses, err = database.GetPassage(versionCode, ranges); err != nil {
+			return err
+		}
+		if len(verses) == 0 {
+			return fmt.Errorf("no verses found for %s", bible.FormatReferences(ranges))
+		}
+	}
+
+	out := cmd.OutOrStdout()
+	switch outputFormat {
+	case formatJSON:
+		if entries == nil {
+			entries = []db.PlanEntry{}
+		}
+		overdue := status.Overdue
+		if overdue == nil {
+			overdue = []db.PlanEntry{}
+		}
+		return writeJSON(out, planTodayJSON{
+			Plan:    *plan,
+			Date:    now.Format("2006-01-02"),
+			Day:     day,
+			Entries: entries,
+			Overdue: overdue,
+			Verses:  verses,
+		})
+	case formatMarkdown:
+		fmt.Fprintf(out, "## %s — %s\n\n", plan.Name, planDayLabel(plan, day))
+		for _, e := range entries {
+			check := " "
+			if e.Completed {
+				check = "x"
+			}
+			fmt.Fprintf(out, "- [%s] %s\n", check, entryLabel(e))
+		}
+		if len(verses) > 0 {
+			fmt.Fprintln(out)
+			printReadMarkdown(cmd, verses, nil, versionCode, "", nil)
+		}
+		return nil
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	fmt.Fprintln(out, titleStyle.Render(fmt.Sprintf("%s — %s (%s)", plan.Name, planDayLabel(plan, day), now.Format("2006-01-02"))))
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "오늘 읽을 곳이 없습니다.")
+	}
+	for _, e := range entries {
+		check := "[ ]"
+		if e.Completed {
+			check = "[✓]"
+		}
+		fmt.Fprintf(out, "%s %s (ID:%d)\n", check, entryLabel(e), e.ID)
+	}
+	if status.BehindDays > 0 {
+		fmt.Fprintf(out, "%d일 밀림 · 밀린 읽기 %d개\n", status.BehindDays, len(status.Overdue))
+	}
+	if len(verses) > 0 {
+		printVerses(cmd, verses, nil)
+	}
+	return nil
+}
+
+func runPlanCheck(cmd *cobra.Command, args []string) error {
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+
+	var entries []db.PlanEntry
+	if args[0] == "all" {
+		plan, err := selectPlan(database)
+		if err != nil {
+			return err
+		}
+		if entries, err = database.GetEntriesOn(plan.ID, time.Now()); err != nil {
+			return err
+		}
+	} else {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid entry ID (use an ID from bible plan today or all): %s", args[0])
+		}
+		e, err := database.GetPlanEntry(id)
+		if err != nil {
+			return err
+		}
+		if e == nil {
+			return fmt.Errorf("plan entry %d not found", id)
+		}
+		entries = []db.PlanEntry{*e}
+	}
+
+	checked := []db.PlanEntry{}
+	for _, e := range entries {
+		if !e.Completed {
+			if err := database.MarkEntryCompleted(e.ID); err != nil {
+				return err
+			}
+		}
+		updated, err := database.GetPlanEntry(e.ID)
+		if err != nil {
+			return err
+		}
+		checked = append(checked, *updated)
+	}
+
+	out := cmd.OutOrStdout()
+	if outputFormat == formatJSON {
+		return writeJSON(out, checked)
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "오늘 읽을 곳이 없습니다.")
+	}
+	for _, e := range entries {
+		if e.Completed {
+			fmt.Fprintf(out, "이미 읽음: %s (ID:%d)\n", entryLabel(e), e.ID)
+		} else {
+			fmt.Fprintf(out, "읽음: %s (ID:%d)\n", entryLabel(e), e.ID)
+		}
+	}
+	return nil
+}
+
+// planStatusJSON is the --format json output of plan status.
+type planStatusJSON struct {
+	db.ReadingPlan
+	Day        int           `json:"day"`
+	Completed  int           `json:"completed"`
+	Total      int           `json:"total"`
+	BehindDays int           `json:"behind_days"`
+	Streak     db.PlanStreak `json:"streak"`
+}
+
+func runPlanStatus(cmd *cobra.Command, args []string) error {
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+
+	var plans []db.ReadingPlan
+	if planSelect != 0 {
+		plan, err := selectPlan(database)
+		if err != nil {
+			return err
+		}
+		plans = []db.ReadingPlan{*plan}
+	} else if plans, err = database.ListPlans(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	statuses := []planStatusJSON{}
+	for _, p := range plans {
+		completed, total, err := database.GetPlanProgress(p.ID)
+		if err != nil {
+			return err
+		}
+		status, err := database.GetPlanStatus(p.ID, now)
+		if err != nil {
+			return err
+		}
+		streak, err := database.GetPlanStreak(p.ID, now)
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, planStatusJSON{
+			ReadingPlan: p,
+			Day:         status.Day,
+			Completed:   completed,
+			Total:       total,
+			BehindDays:  status.BehindDays,
+			Streak:      streak,
+		})
+	}
+
+	out := cmd.OutOrStdout()
+	if outputFormat == formatJSON {
+		return writeJSON(out, statuses)
+	}
+	if len(statuses) == 0 {
+		fmt.Fprintln(out, "읽기 계획이 없습니다.")
+		return nil
+	}
+
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	for i, s := range statuses {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		day := planDayLabel(&s.ReadingPlan, s.Day)
+		if s.Day > s.TotalDays {
+			day = "기간 끝남"
+		}
+		fmt.Fprintf(out, "%s (ID:%d) — %s / %d일\n", titleStyle.Render(s.Name), s.ID, day, s.TotalDays)
+		fmt.Fprintf(out, "  %s\n", progressBar(s.Completed, s.Total, 20))
+		line := fmt.Sprintf("연속 %d일 · 최장 %d일", s.Streak.Current, s.Streak.Longest)
+		if s.BehindDays > 0 {
+			line += fmt.Sprintf(" · %d일 밀림", s.BehindDays)
+		}
+		fmt.Fprintf(out, "  %s\n", line)
+	}
+	return nil
+}
+
+// progressBar renders "████░░░░ 3/8 (37%)" with width cells.
+func progressBar(completed, total, width int) string {
+	filled, pct := 0, 0
+	if total > 0 {
+		filled = min(completed*width/total, width)
+		pct = completed * 100 / total
+	}
+	return fmt.Sprintf("%s%s %d/%d (%d%%)", strings.Repeat("█", filled), strings.Repeat("░", width-filled), completed, total, pct)
+}
+
+// planListJSON is a plan in `bible plan list --format json`, shaped like
+// the plans of GET /v1/plans.
+type planListJSON struct {
+	db.ReadingPlan
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+func runPlanList(cmd *cobra.Command, args []string) error {
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	plans, err := database.ListPlans()
+	if err != nil {
+		return err
+	}
+
+	progress := []planListJSON{}
+	for _, p := range plans {
+		completed, total, err := database.GetPlanProgress(p.ID)
+		if err != nil {
+			return err
+		}
+		progress = append(progress, planListJSON{ReadingPlan: p, Completed: completed, Total: total})
+	}
+
+	out := cmd.OutOrStdout()
+	if outputFormat == formatJSON {
+		return writeJSON(out, progress)
+	}
+	if len(progress) == 0 {
+		fmt.Fprintln(out, "읽기 계획이 없습니다.")
+		return nil
+	}
+	for _, p := range progress {
+		pct := 0
+		if p.Total > 0 {
+			pct = p.Completed * 100 / p.Total
+		}
+		fmt.Fprintf(out, "[ID:%d] %s — %d일, %s 시작, %d%% 완료\n",
+			p.ID, p.Name, p.TotalDays, p.StartDate.Format("2006-01-02"), pct)
+	}
+	return nil
+}
+
+func runPlanDelete(cmd *cobra.Command, args []string) error {
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid plan ID: %w", err)
+	}
+
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	plan, err := database.GetPlan(id)
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		return fmt.Errorf("plan %d not found", id)
+	}
+	if err := database.DeletePlan(id); err != nil {
+		return err
+	}
+
+	if outputFormat == formatJSON {
+		return writeJSON(cmd.OutOrStdout(), plan)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "읽기 계획 삭제: %s (ID:%d)\n", plan.Name, plan.ID)
+	return nil
+}
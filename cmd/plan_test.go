@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yangsijun/bible-tui/internal/db"
+)
+
+func resetPlanNewFlags() {
+	planStart, planName, planBooks, planBalance = "", "", "", "verses"
+	planDays, planPerDay, planWeekdays = 0, 0, false
+}
+
+func TestPlanNew(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	defer resetPlanNewFlags()
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"plan", "new", "--books", "mat-jhn", "--days", "40", "--start", "2026-01-01"}, "읽기 계획 추가: 마태복음-요한복음 40일 (ID:1, 40일, 2026-01-01 시작)"},
+		{[]string{"plan", "new", "nt90", "--start", "2026-01-01"}, "읽기 계획 추가: 신약 90일 (ID:2, 90일, 2026-01-01 시작)"},
+		{[]string{"plan", "new", "psalms-proverbs", "--days", "62", "--start", "2026-01-01"}, "읽기 계획 추가: 시편·잠언 한 달 (62일) (ID:3, 62일, 2026-01-01 시작)"},
+		// 2026-01-02 is a Friday: 16 chapters at 4 a day take Friday and the
+		// next Monday to Wednesday.
+		{[]string{"plan", "new", "--books", "롬", "--per-day", "4", "--weekdays", "--balance", "chapters", "--start", "2026-01-02"}, "읽기 계획 추가: 로마서 하루 4장 (ID:4, 6일, 2026-01-02 시작)"},
+	}
+	for _, tt := range tests {
+		resetPlanNewFlags()
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetArgs(tt.args)
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		if out := buf.String(); !strings.Contains(out, tt.want) {
+			t.Errorf("%v: expected %q, got %q", tt.args, tt.want, out)
+		}
+	}
+
+	plan, err := database.GetPlan(2)
+	if err != nil || plan == nil || plan.PlanType != "nt90" {
+		t.Errorf("expected plan type nt90, got %+v, %v", plan, err)
+	}
+	entries, err := database.GetEntriesOn(1, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.Local))
+	if err != nil || len(entries) == 0 || entries[0].BookCode != "mat" || entries[0].ChapterStart != 1 {
+		t.Errorf("expected Matthew 1 on day 1, got %+v, %v", entries, err)
+	}
+}
+
+func TestPlanNewErrors(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+	defer resetPlanNewFlags()
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"plan", "new", "nt90", "--books", "rom"}, "cannot be combined"},
+		{[]string{"plan", "new"}, "preset or --books"},
+		{[]string{"plan", "new", "weekly"}, "unknown preset"},
+		{[]string{"plan", "new", "--books", "없는책", "--days", "3"}, "--books"},
+		{[]string{"plan", "new", "--books", "rom"}, "either days or chapters"},
+		{[]string{"plan", "new", "--books", "rom", "--days", "3", "--balance", "words"}, "--balance"},
+		{[]string{"plan", "new", "--books", "rom", "--days", "3", "--start", "3/1"}, "--start"},
+	}
+	for _, tt := range tests {
+		resetPlanNewFlags()
+		rootCmd.SetOut(new(bytes.Buffer))
+		rootCmd.SetErr(new(bytes.Buffer))
+		rootCmd.SetArgs(tt.args)
+		err := rootCmd.Execute()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%v: expected error with %q, got %v", tt.args, tt.want, err)
+		}
+	}
+	if plans, _ := database.ListPlans(); len(plans) != 0 {
+		t.Errorf("expected no plans created, got %+v", plans)
+	}
+}
+
+func TestPlanImportExport(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	defer func() { planStart = "" }()
+
+	path := filepath.Join(t.TempDir(), "genesis.json")
+	data := "{\n  \"name\": \"창세기 사흘\",\n  \"days\": [\"창 1-2\", \"\", \"창 3; 시 1\"]\n}\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"plan", "import", path, "--start", "2026-01-01"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("plan import: %v", err)
+	}
+	if out := buf.String(); !strings.Contains(out, "읽기 계획 추가: 창세기 사흘 (ID:1, 3일, 2026-01-01 시작)") {
+		t.Errorf("unexpected import output: %s", out)
+	}
+
+	// The imported file is kept for the TUI picker.
+	saved, err := os.ReadFile(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "bible-tui", "plans", "genesis.json"))
+	if err != nil || string(saved) != data {
+		t.Errorf("expected plan file copied to the plan folder, got %q, %v", saved, err)
+	}
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"plan", "export", "1"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("plan export: %v", err)
+	}
+	f, err := db.ParsePlanFile(buf.Bytes())
+	if err != nil {
+		t.Fatalf("exported file does not parse: %v\n%s", err, buf.String())
+	}
+	if f.Name != "창세기 사흘" || f.StartDate != "2026-01-01" || len(f.Days) != 3 || f.Days[1] != "" {
+		t.Errorf("unexpected exported plan %+v", f)
+	}
+}
+
+func TestPlanImportReportsLine(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	path := filepath.Join(t.TempDir(), "bad.json")
+	data := "{\n  \"name\": \"오타\",\n  \"days\": [\n    \"창 1\",\n    \"창셰기 2\"\n  ]\n}\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"plan", "import", path})
+	err := rootCmd.Execute()
+	if err == nil {
+		t.Fatal("expected error for a bad reference")
+	}
+	if !strings.Contains(err.Error(), "bad.json: line 5: day 2:") {
+		t.Errorf("expected error pointing at line 5, got %v", err)
+	}
+	if plans, _ := database.ListPlans(); len(plans) != 0 {
+		t.Errorf("expected no plan created, got %+v", plans)
+	}
+}
+
+// runPlanArgs runs bible with args and returns its output.
+func runPlanArgs(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	outputFormat, planSelect, planText = formatText, 0, false
+	return buf.String(), err
+}
+
+func TestPlanDailyCommands(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+	defer resetPlanNewFlags()
+
+	if _, err := runPlanArgs(t, "plan", "today"); err == nil || !strings.Contains(err.Error(), "no reading plan") {
+		t.Errorf("expected an error without plans, got %v", err)
+	}
+	if _, err := runPlanArgs(t, "plan", "new", "--books", "창", "--days", "50", "--balance", "chapters"); err != nil {
+		t.Fatalf("plan new: %v", err)
+	}
+	today := time.Now().Format("2006-01-02")
+
+	out, err := runPlanArgs(t, "plan", "today", "--text")
+	if err != nil {
+		t.Fatalf("plan today: %v", err)
+	}
+	for _, want := range []string{"창세기 50일 — 1일차 (" + today + ")", "[ ] 창세기 1 (ID:1)", "태초에 하나님이"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in plan today output:\n%s", want, out)
+		}
+	}
+
+	out, err = runPlanArgs(t, "plan", "today", "--format", "json")
+	if err != nil {
+		t.Fatalf("plan today --format json: %v", err)
+	}
+	var got planTodayJSON
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if got.Plan.ID != 1 || got.Day != 1 || len(got.Entries) != 1 || got.Entries[0].BookCode != "gen" || got.Verses != nil {
+		t.Errorf("unexpected plan today JSON %+v", got)
+	}
+
+	out, err = runPlanArgs(t, "plan", "check", "1")
+	if err != nil || !strings.Contains(out, "읽음: 창세기 1 (ID:1)") {
+		t.Errorf("plan check: %q, %v", out, err)
+	}
+	out, err = runPlanArgs(t, "plan", "check", "all")
+	if err != nil || !strings.Contains(out, "이미 읽음: 창세기 1 (ID:1)") {
+		t.Errorf("plan check all: %q, %v", out, err)
+	}
+	if _, err := runPlanArgs(t, "plan", "check", "999"); err == nil || !strings.Contains(err.Error(), "plan entry 999 not found") {
+		t.Errorf("expected an error for a missing entry, got %v", err)
+	}
+	if _, err := runPlanArgs(t, "plan", "check", "1", "--format", "markdown"); err == nil {
+		t.Error("expected plan check to reject --format markdown")
+	}
+
+	out, err = runPlanArgs(t, "plan", "status")
+	if err != nil {
+		t.Fatalf("plan status: %v", err)
+	}
+	for _, want := range []string{"창세기 50일 (ID:1) — 1일차 / 50일", "1/50 (2%)", "연속 1일 · 최장 1일"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in plan status output:\n%s", want, out)
+		}
+	}
+
+	out, err = runPlanArgs(t, "plan", "list")
+	if err != nil || !strings.Contains(out, "[ID:1] 창세기 50일 — 50일, "+today+" 시작, 2% 완료") {
+		t.Errorf("plan list: %q, %v", out, err)
+	}
+
+	out, err = runPlanArgs(t, "plan", "delete", "1")
+	if err != nil || !strings.Contains(out, "읽기 계획 삭제: 창세기 50일 (ID:1)") {
+		t.Errorf("plan delete: %q, %v", out, err)
+	}
+	if _, err := runPlanArgs(t, "plan", "delete", "1"); err == nil || !strings.Contains(err.Error(), "plan 1 not found") {
+		t.Errorf("expected an error deleting a missing plan, got %v", err)
+	}
+	out, err = runPlanArgs(t, "plan", "list", "--format", "json")
+	if err != nil || strings.TrimSpace(out) != "[]" {
+		t.Errorf("expected an empty JSON list, got %q, %v", out, err)
+	}
+}
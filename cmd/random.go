@@ -7,16 +7,17 @@ import (
 )
 
 var randomCmd = &cobra.Command{
-	Use:   "random",
-	Short: "랜덤 성경 구절",
-	Long:  "랜덤으로 성경 구절 하나를 출력합니다.",
-	RunE:  runRandom,
+	Use:         "random",
+	Short:       "랜덤 성경 구절",
+	Long:        "랜덤으로 성경 구절 하나를 출력합니다.",
+	RunE:        runRandom,
+	Annotations: allFormats,
 }
 
 var randomVersion string
 
 func init() {
-	randomCmd.Flags().StringVarP(&randomVersion, "version", "v", "GAE", "성경 버전 코드")
+	randomCmd.Flags().StringVarP(&randomVersion, "version", "v", "", "성경 버전 코드 (기본: 설정된 역본)")
 	rootCmd.AddCommand(randomCmd)
 }
 
@@ -26,11 +27,25 @@ func runRandom(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("open database: %w", err)
 	}
 
-	verse, err := database.GetRandomVerse(randomVersion)
+	versionCode, err := resolveVersion(database, randomVersion)
+	if err != nil {
+		return err
+	}
+
+	verse, err := database.GetRandomVerse(versionCode)
 	if err != nil {
 		return fmt.Errorf("get random verse: %w", err)
 	}
 
+	switch outputFormat {
+	case formatJSON:
+		return writeJSON(cmd.OutOrStdout(), verse)
+	case formatMarkdown:
+		fmt.Fprintf(cmd.OutOrStdout(), "> %s\n>\n> — %s %d:%d\n",
+			verse.Text, verse.BookName, verse.Chapter, verse.VerseNum)
+		return nil
+	}
+
 	fmt.Fprintf(cmd.OutOrStdout(), "%s %d:%d — %s\n",
 		verse.BookName, verse.Chapter, verse.VerseNum, verse.Text)
 
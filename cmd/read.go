@@ -12,23 +12,32 @@ import (
 )
 
 var readCmd = &cobra.Command{
-	Use:   "read <책이름> <장>[:<절>[-<끝절>]]",
+	Use:   "read <참조>",
 	Short: "성경 본문 읽기",
-	Long:  "지정한 성경 본문을 출력합니다. 예: bible read 창세기 1, bible read 창 1:3-5",
-	Args:  cobra.MinimumNArgs(2),
-	RunE:  runRead,
+	Long: `지정한 성경 본문을 출력합니다.
+예: bible read 창세기 1, bible read 창 1:3-5, bible read 창 1:26-2:3,
+    bible read 요 3:16,18, bible read "롬 8:28; 12:1-2", bible read 창세기 1장 3절`,
+	Args:        cobra.MinimumNArgs(1),
+	RunE:        runRead,
+	Annotations: allFormats,
 }
 
-var readVersion string
+var (
+	readVersion   string
+	readFootnotes bool
+	readCompare   string
+)
 
 func init() {
-	readCmd.Flags().StringVarP(&readVersion, "version", "v", "GAE", "성경 버전 코드")
+	readCmd.Flags().StringVarP(&readVersion, "version", "v", "", "성경 버전 코드 (기본: 설정된 역본)")
+	readCmd.Flags().BoolVar(&readFootnotes, "footnotes", false, "본문 뒤에 각주 출력")
+	readCmd.Flags().StringVar(&readCompare, "compare", "", "함께 볼 역본 코드 (예: HAN)")
 	rootCmd.AddCommand(readCmd)
 }
 
 func runRead(cmd *cobra.Command, args []string) error {
 	input := strings.Join(args, " ")
-	ref, err := bible.ParseReference(input)
+	ranges, err := bible.ParseReferences(input)
 	if err != nil {
 		return fmt.Errorf("parse reference: %w", err)
 	}
@@ -38,48 +47,91 @@ func runRead(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("open database: %w", err)
 	}
 
-	verses, err := database.GetVerses(readVersion, ref.BookCode, ref.Chapter)
+	versionCode, err := resolveVersion(database, readVersion)
 	if err != nil {
-		return fmt.Errorf("get verses: %w", err)
+		return err
 	}
 
+	verses, err := database.GetPassage(versionCode, ranges)
+	if err != nil {
+		return err
+	}
 	if len(verses) == 0 {
-		return fmt.Errorf("no verses found for %s %d", ref.BookCode, ref.Chapter)
-	}
-
-	if ref.VerseStart > 0 {
-		filtered := []db.Verse{}
-		for _, v := range verses {
-			if v.VerseNum >= ref.VerseStart {
-				if ref.VerseEnd > 0 {
-					if v.VerseNum <= ref.VerseEnd {
-						filtered = append(filtered, v)
-					}
-				} else {
-					if v.VerseNum == ref.VerseStart {
-						filtered = append(filtered, v)
-					}
-				}
+		return fmt.Errorf("no verses found for %s", bible.FormatReferences(ranges))
+	}
+
+	footnotes := map[int64][]db.Footnote{}
+	if readFootnotes {
+		for _, ch := range verseChapters(verses) {
+			list, err := database.GetFootnotes(versionCode, ch.BookCode, ch.Chapter)
+			if err != nil {
+				return fmt.Errorf("get footnotes: %w", err)
+			}
+			for _, fn := range list {
+				footnotes[fn.VerseID] = append(footnotes[fn.VerseID], fn)
 			}
 		}
-		verses = filtered
 	}
 
-	if len(verses) == 0 {
-		return fmt.Errorf("no verses found in specified range")
+	var compareCode string
+	var parallel []chapterPairs
+	if readCompare != "" {
+		compareCode = strings.ToUpper(readCompare)
+		parallel, err = parallelChapters(database, versionCode, compareCode, ranges)
+		if err != nil {
+			return err
+		}
 	}
 
-	bookName := verses[0].BookName
-	chapter := verses[0].Chapter
+	switch outputFormat {
+	case formatJSON:
+		return writeJSON(cmd.OutOrStdout(), newReadJSON(ranges, versionCode, compareCode, verses, parallel, footnotes))
+	case formatMarkdown:
+		printReadMarkdown(cmd, verses, parallel, versionCode, compareCode, footnotes)
+		return nil
+	}
 
 	titleStyle := lipgloss.NewStyle().Bold(true)
-	verseNumStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
+	markerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
 
-	fmt.Fprintln(cmd.OutOrStdout())
-	fmt.Fprintln(cmd.OutOrStdout(), titleStyle.Render(fmt.Sprintf("%s %d장", bookName, chapter)))
-	fmt.Fprintln(cmd.OutOrStdout())
+	if readCompare != "" {
+		for _, ch := range parallel {
+			printParallel(cmd, ch.Pairs, versionCode, compareCode, footnotes, bible.GetBookName(ch.BookCode), ch.Chapter)
+		}
+		if readFootnotes {
+			printFootnotes(cmd, verses, footnotes, titleStyle, markerStyle)
+		}
+		return nil
+	}
+
+	printVerses(cmd, verses, footnotes)
+
+	if readFootnotes {
+		printFootnotes(cmd, verses, footnotes, titleStyle, markerStyle)
+	}
+
+	return nil
+}
+
+// printVerses prints verses under a heading for each chapter, with the
+// markers of their footnotes.
+func printVerses(cmd *cobra.Command, verses []db.Verse, footnotes map[int64][]db.Footnote) {
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	verseNumStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
+	markerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
 
+	var last bible.Location
 	for _, v := range verses {
+		if here := (bible.Location{BookCode: v.BookCode, Chapter: v.Chapter}); here != last {
+			if last.Chapter != 0 {
+				fmt.Fprintln(cmd.OutOrStdout())
+			}
+			fmt.Fprintln(cmd.OutOrStdout())
+			fmt.Fprintln(cmd.OutOrStdout(), titleStyle.Render(fmt.Sprintf("%s %d장", v.BookName, v.Chapter)))
+			fmt.Fprintln(cmd.OutOrStdout())
+			last = here
+		}
+
 		if v.SectionTitle != "" {
 			fmt.Fprintln(cmd.OutOrStdout())
 			fmt.Fprintln(cmd.OutOrStdout(), titleStyle.Render(v.SectionTitle))
@@ -90,12 +142,203 @@ func runRead(cmd *cobra.Command, args []string) error {
 		paddedNum := padLeft(verseNumStr, 3)
 		coloredNum := verseNumStyle.Render(paddedNum)
 
-		fmt.Fprintf(cmd.OutOrStdout(), "%s  %s\n", coloredNum, v.Text)
+		text := v.Text
+		for _, fn := range footnotes[v.ID] {
+			text += " " + markerStyle.Render(fn.Marker)
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%s  %s\n", coloredNum, text)
 	}
 
 	fmt.Fprintln(cmd.OutOrStdout())
+}
 
-	return nil
+// verseChapters lists the distinct chapters of verses, in order.
+func verseChapters(verses []db.Verse) []bible.Location {
+	var chapters []bible.Location
+	for _, v := range verses {
+		loc := bible.Location{BookCode: v.BookCode, Chapter: v.Chapter}
+		if len(chapters) == 0 || chapters[len(chapters)-1] != loc {
+			chapters = append(chapters, loc)
+		}
+	}
+	return chapters
+}
+
+// chapterPairs is one chapter of a parallel reading, limited to the
+// requested verses.
+type chapterPairs struct {
+	bible.Location
+	Pairs []db.VersePair
+}
+
+// parallelChapters aligns the requested verses of ranges in two versions,
+// chapter by chapter.
+func parallelChapters(database *db.DB, primaryCode, secondaryCode string, ranges []bible.Range) ([]chapterPairs, error) {
+	var chapters []chapterPairs
+	for _, r := range ranges {
+		for _, ch := range r.Chapters() {
+			pairs, err := database.GetParallelVerses(primaryCode, secondaryCode, ch.BookCode, ch.Chapter)
+			if err != nil {
+				return nil, err
+			}
+			filtered := []db.VersePair{}
+			for _, p := range pairs {
+				if r.Contains(ch.BookCode, ch.Chapter, p.VerseNum) {
+					filtered = append(filtered, p)
+				}
+			}
+			if len(filtered) > 0 {
+				chapters = append(chapters, chapterPairs{Location: ch, Pairs: filtered})
+			}
+		}
+	}
+	return chapters, nil
+}
+
+// readJSON is the --format json output of read.
+type readJSON struct {
+	Reference      string        `json:"reference"`
+	Version        string        `json:"version"`
+	Verses         []db.Verse    `json:"verses"`
+	Footnotes      []db.Footnote `json:"footnotes,omitempty"`
+	CompareVersion string        `json:"compare_version,omitempty"`
+	CompareVerses  []db.Verse    `json:"compare_verses,omitempty"`
+}
+
+func newReadJSON(ranges []bible.Range, versionCode, compareCode string, verses []db.Verse, parallel []chapterPairs, footnotes map[int64][]db.Footnote) readJSON {
+	out := readJSON{
+		Reference:      bible.FormatReferences(ranges),
+		Version:        versionCode,
+		Verses:         verses,
+		CompareVersion: compareCode,
+	}
+	for _, v := range verses {
+		out.Footnotes = append(out.Footnotes, footnotes[v.ID]...)
+	}
+	if compareCode != "" {
+		out.CompareVerses = []db.Verse{}
+		for _, ch := range parallel {
+			for _, p := range ch.Pairs {
+				if p.Secondary != nil {
+					out.CompareVerses = append(out.CompareVerses, *p.Secondary)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// printReadMarkdown prints a passage as Markdown: a heading per chapter
+// and bold verse numbers, or a table per chapter when comparing versions.
+func printReadMarkdown(cmd *cobra.Command, verses []db.Verse, parallel []chapterPairs, primaryCode, secondaryCode string, footnotes map[int64][]db.Footnote) {
+	out := cmd.OutOrStdout()
+	markers := func(v *db.Verse) string {
+		var s string
+		for _, fn := range footnotes[v.ID] {
+			s += " " + fn.Marker
+		}
+		return s
+	}
+
+	if secondaryCode != "" {
+		for i, ch := range parallel {
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintf(out, "## %s %d장\n\n", bible.GetBookName(ch.BookCode), ch.Chapter)
+			fmt.Fprintf(out, "| 절 | %s | %s |\n|---:|---|---|\n", primaryCode, secondaryCode)
+			for _, p := range ch.Pairs {
+				primary, secondary := "—", "—"
+				if p.Primary != nil {
+					primary = markdownCell(p.Primary.Text) + markers(p.Primary)
+				}
+				if p.Secondary != nil {
+					secondary = markdownCell(p.Secondary.Text)
+				}
+				fmt.Fprintf(out, "| %d | %s | %s |\n", p.VerseNum, primary, secondary)
+			}
+		}
+	} else {
+		var last bible.Location
+		for i := range verses {
+			v := &verses[i]
+			if here := (bible.Location{BookCode: v.BookCode, Chapter: v.Chapter}); here != last {
+				if last.Chapter != 0 {
+					fmt.Fprintln(out)
+				}
+				fmt.Fprintf(out, "## %s %d장\n\n", v.BookName, v.Chapter)
+				last = here
+			}
+			if v.SectionTitle != "" {
+				fmt.Fprintf(out, "### %s\n\n", v.SectionTitle)
+			}
+			fmt.Fprintf(out, "**%d** %s%s\n\n", v.VerseNum, v.Text, markers(v))
+		}
+	}
+
+	printed := false
+	for _, v := range verses {
+		for _, fn := range footnotes[v.ID] {
+			if !printed {
+				fmt.Fprint(out, "\n### 각주\n\n")
+				printed = true
+			}
+			fmt.Fprintf(out, "- %d:%d %s %s\n", v.Chapter, v.VerseNum, fn.Marker, fn.Content)
+		}
+	}
+}
+
+// printParallel prints two versions of a passage interleaved verse by verse.
+func printParallel(cmd *cobra.Command, pairs []db.VersePair, primaryCode, secondaryCode string, footnotes map[int64][]db.Footnote, bookName string, chapter int) {
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	verseNumStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("12"))
+	codeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	markerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("14"))
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, titleStyle.Render(fmt.Sprintf("%s %d장 (%s | %s)", bookName, chapter, primaryCode, secondaryCode)))
+	fmt.Fprintln(out)
+
+	blank := strings.Repeat(" ", 3)
+	for _, p := range pairs {
+		primary := "—"
+		if p.Primary != nil {
+			primary = p.Primary.Text
+			for _, fn := range footnotes[p.Primary.ID] {
+				primary += " " + markerStyle.Render(fn.Marker)
+			}
+		}
+		secondary := "—"
+		if p.Secondary != nil {
+			secondary = p.Secondary.Text
+		}
+
+		num := verseNumStyle.Render(padLeft(fmt.Sprintf("%d", p.VerseNum), 3))
+		fmt.Fprintf(out, "%s  %s %s\n", num, codeStyle.Render("["+primaryCode+"]"), primary)
+		fmt.Fprintf(out, "%s  %s %s\n", blank, codeStyle.Render("["+secondaryCode+"]"), secondary)
+	}
+
+	fmt.Fprintln(out)
+}
+
+// printFootnotes prints the footnotes of the given verses in verse order.
+func printFootnotes(cmd *cobra.Command, verses []db.Verse, footnotes map[int64][]db.Footnote, titleStyle, markerStyle lipgloss.Style) {
+	printed := false
+	for _, v := range verses {
+		for _, fn := range footnotes[v.ID] {
+			if !printed {
+				fmt.Fprintln(cmd.OutOrStdout(), titleStyle.Render("각주"))
+				printed = true
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%s  %s %s\n",
+				padLeft(fmt.Sprintf("%d:%d", v.Chapter, v.VerseNum), 7), markerStyle.Render(fn.Marker), fn.Content)
+		}
+	}
+	if printed {
+		fmt.Fprintln(cmd.OutOrStdout())
+	}
 }
 
 func padLeft(s string, width int) string {
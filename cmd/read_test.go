@@ -76,3 +76,168 @@ func TestReadCommand_InvalidBook(t *testing.T) {
 		t.Errorf("expected error to contain 'unknown book', got: %v", err)
 	}
 }
+
+func TestReadCommand_Footnotes(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+	defer func() { readFootnotes = false }()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"read", "창세기", "1", "--footnotes"})
+
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "각주") {
+		t.Errorf("expected output to contain '각주' section, got: %s", output)
+	}
+	if !strings.Contains(output, "1) 또는 형체가 없는") {
+		t.Errorf("expected output to contain footnote content, got: %s", output)
+	}
+}
+
+func TestReadCommand_FootnotesOutsideRange(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+	defer func() { readFootnotes = false }()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"read", "창세기", "1:3", "--footnotes"})
+
+	err := rootCmd.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "또는 형체가 없는") {
+		t.Errorf("expected footnote of verse 2 to be omitted for 1:3, got: %s", output)
+	}
+}
+
+func TestReadCommand_ConfiguredVersion(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+
+	vID, err := database.InsertVersion("HAN", "개역한글", "ko")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bookID, err := database.InsertBook(vID, "gen", "창세기", "창", "old", 50, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.InsertVerse(bookID, 1, 1, "태초에 하나님이 천지를 창조하시니라 (한글)", "", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.SetSetting("default_version", "HAN"); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"read", "창세기", "1"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "(한글)") {
+		t.Errorf("expected HAN text from configured version, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	rootCmd.SetArgs([]string{"read", "창세기", "1", "-v", "gae"})
+	defer func() { readVersion = "" }()
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "(한글)") {
+		t.Errorf("expected GAE text with explicit --version, got: %s", buf.String())
+	}
+}
+
+func TestReadCommand_Compare(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+
+	vID, err := database.InsertVersion("HAN", "개역한글", "ko")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bookID, err := database.InsertBook(vID, "gen", "창세기", "창", "old", 50, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.InsertVerse(bookID, 1, 1, "태초에 하나님이 천지를 창조하시니라 (한글)", "", false); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"read", "창", "1:1-2", "--compare", "han"})
+	defer func() { readCompare = "" }()
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	output := buf.String()
+	for _, want := range []string{"(GAE | HAN)", "[GAE] 태초에", "[HAN] 태초에 하나님이 천지를 창조하시니라 (한글)", "[HAN] —"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q, got: %s", want, output)
+		}
+	}
+	if strings.Contains(output, "빛이 있으라") {
+		t.Errorf("expected verse 3 to be outside the range, got: %s", output)
+	}
+}
+
+func TestReadCommand_ReferenceList(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+
+	tests := []struct {
+		args    []string
+		want    []string
+		notWant []string
+	}{
+		{[]string{"read", "창", "1:1,3"}, []string{"태초에", "빛이 있으라"}, []string{"땅이 혼돈하고"}},
+		{[]string{"read", "창세기", "1장", "2절"}, []string{"땅이 혼돈하고"}, []string{"태초에", "빛이 있으라"}},
+		{[]string{"read", "창1:2-3"}, []string{"땅이 혼돈하고", "빛이 있으라"}, []string{"태초에"}},
+	}
+
+	for _, tt := range tests {
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetArgs(tt.args)
+
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", tt.args, err)
+		}
+		output := buf.String()
+		for _, w := range tt.want {
+			if !strings.Contains(output, w) {
+				t.Errorf("%v: expected output to contain %q, got: %s", tt.args, w, output)
+			}
+		}
+		for _, w := range tt.notWant {
+			if strings.Contains(output, w) {
+				t.Errorf("%v: expected output to NOT contain %q, got: %s", tt.args, w, output)
+			}
+		}
+	}
+}
@@ -6,65 +6,229 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
+	"github.com/yangsijun/bible-tui/internal/db"
 )
 
 var searchCmd = &cobra.Command{
-	Use:   "search <검색어>",
+	Use:   "search <검색어> | --history | --saved <이름>",
 	Short: "성경 검색",
-	Long:  "성경 본문에서 검색어를 검색합니다.",
-	Args:  cobra.MinimumNArgs(1),
-	RunE:  runSearch,
+	Long: `성경 본문에서 검색어를 검색합니다.
+
+  사랑 믿음            두 단어가 모두 있는 구절 (AND 생략 가능)
+  사랑 OR 믿음         둘 중 하나라도 있는 구절
+  사랑 -미움           "미움"이 없는 구절 (NOT 미움과 같음, 셸에서는 -- 뒤에: bible search -- 사랑 -미움)
+  '"세상을 이처럼"'    이어지는 구절 그대로 (셸에서는 따옴표를 한 번 더 감싸기)
+  사랑 NEAR/5 믿음     5단어 이내에 함께 나오는 구절
+  (사랑 OR 긍휼) 믿음  괄호로 묶기
+  book:롬 range:마-요 chapter:롬8 testament:new   범위 지정
+
+초성만 입력하면 초성으로 검색합니다 (예: bible search ㅎㄴㄴㅇ → 하나님의).
+--regex를 주면 검색어를 정규식으로 찾습니다 (예: bible search --regex '여호와(의|께서)', '^그러므로').
+
+검색어는 기록에 남습니다 (--history로 보기). --save <이름>으로 검색을 저장하고
+--saved <이름>으로 다시 실행합니다 (예: bible search 믿음 --book 롬 --save "로마서 믿음").`,
+	Args:        cobra.ArbitraryArgs,
+	RunE:        runSearch,
+	Annotations: allFormats,
 }
 
 var (
-	searchVersion string
-	searchLimit   int
+	searchVersion   string
+	searchLimit     int
+	searchOffset    int
+	searchSort      string
+	searchBooks     []string
+	searchTestament string
+	searchRegex     bool
+	searchHistory   bool
+	searchSaved     string
+	searchSaveAs    string
 )
 
+// searchHistoryShown is how many past searches --history lists.
+const searchHistoryShown = 20
+
 func init() {
-	searchCmd.Flags().StringVarP(&searchVersion, "version", "v", "GAE", "성경 버전 코드")
-	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "검색 결과 최대 개수")
+	searchCmd.Flags().StringVarP(&searchVersion, "version", "v", "", "성경 버전 코드 (기본: 설정된 역본)")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 20, "검색 결과 최대 개수 (0: 전부)")
+	searchCmd.Flags().IntVar(&searchOffset, "offset", 0, "건너뛸 검색 결과 수 (다음 쪽: --offset 20)")
+	searchCmd.Flags().StringVar(&searchSort, "sort", "relevance", "정렬: relevance(관련도), canonical(성경 순서), book(책별)")
+	searchCmd.Flags().StringSliceVar(&searchBooks, "book", nil, "검색할 책 (예: 롬, 여러 권은 롬,고전)")
+	searchCmd.Flags().StringVar(&searchTestament, "testament", "", "검색할 성경: old(구약) 또는 new(신약)")
+	searchCmd.Flags().BoolVar(&searchRegex, "regex", false, "검색어를 정규식(Go RE2 문법)으로 찾기")
+	searchCmd.Flags().BoolVar(&searchHistory, "history", false, "최근 검색어와 저장한 검색 보기")
+	searchCmd.Flags().StringVar(&searchSaved, "saved", "", "저장한 검색 실행")
+	searchCmd.Flags().StringVar(&searchSaveAs, "save", "", "이 검색을 이름을 붙여 저장")
 	rootCmd.AddCommand(searchCmd)
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
-	query := strings.Join(args, " ")
-
 	database, err := getDB()
 	if err != nil {
 		return fmt.Errorf("open database: %w", err)
 	}
+	if searchHistory {
+		return printSearchHistory(cmd, database)
+	}
+
+	query := strings.Join(args, " ")
+	regex := searchRegex
+	if searchSaved != "" {
+		if query != "" {
+			return fmt.Errorf("--saved cannot be combined with a search query")
+		}
+		saved, err := database.GetSavedSearch(searchSaved)
+		if err != nil {
+			return err
+		}
+		if saved == nil {
+			return fmt.Errorf("no saved search named %q (see bible search --history)", searchSaved)
+		}
+		query, regex = saved.Query, saved.Regex || searchRegex
+	}
+	if strings.TrimSpace(query) == "" {
+		return fmt.Errorf("requires a search query")
+	}
+
+	q, err := db.ParseSearchQuery(query)
+	if err != nil {
+		return fmt.Errorf("parse search query: %w", err)
+	}
+	order, err := db.ParseSearchSort(searchSort)
+	if err != nil {
+		return fmt.Errorf("--sort: %w", err)
+	}
+	if searchOffset < 0 {
+		return fmt.Errorf("--offset must not be negative")
+	}
+	if err := applyScopeFlags(&q, searchBooks, searchTestament); err != nil {
+		return err
+	}
+
+	versionCode, err := resolveVersion(database, searchVersion)
+	if err != nil {
+		return err
+	}
 
-	results, err := database.SearchVerses(searchVersion, query, searchLimit)
+	page, err := database.Search(versionCode, q, db.SearchOptions{Sort: order, Offset: searchOffset, Limit: searchLimit, Regex: regex})
 	if err != nil {
 		return fmt.Errorf("search verses: %w", err)
 	}
+	// History and --save keep the scope flags as filters of the query.
+	full := query
+	for _, code := range q.Books[len(q.Books)-len(searchBooks):] {
+		full += " book:" + bible.GetBookAbbrev(code)
+	}
+	if searchTestament != "" {
+		full += " testament:" + q.Testament
+	}
+	if err := database.AddSearchHistory(full, regex); err != nil {
+		return err
+	}
+	if searchSaveAs != "" {
+		if err := database.SaveSearch(searchSaveAs, full, regex); err != nil {
+			return err
+		}
+		// Keep stdout to the results, which may be piped as JSON.
+		fmt.Fprintf(cmd.ErrOrStderr(), "검색 저장: %s (%s)\n", searchSaveAs, full)
+	}
+
+	switch outputFormat {
+	case formatJSON:
+		return writeJSON(cmd.OutOrStdout(), newSearchJSON(query, regex, searchOffset, page))
+	case formatMarkdown:
+		printSearchMarkdown(cmd, query, page)
+		return nil
+	}
+	results := page.Results
 
 	if len(results) == 0 {
+		if page.Total > 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "\"%s\" 검색 결과는 %d건입니다 (--offset %d 이후 없음)\n", query, page.Total, searchOffset)
+			return nil
+		}
 		fmt.Fprintf(cmd.OutOrStdout(), "검색 결과가 없습니다: \"%s\"\n", query)
 		return nil
 	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "\"%s\" 검색 결과 (%d건):\n", query, len(results))
+	mode := ""
+	switch {
+	case regex:
+		mode = " 정규식"
+	case q.IsChosung():
+		mode = " 초성"
+	}
+	if len(results) == page.Total {
+		fmt.Fprintf(cmd.OutOrStdout(), "\"%s\"%s 검색 결과 (%d건):\n", query, mode, page.Total)
+	} else {
+		fmt.Fprintf(cmd.OutOrStdout(), "\"%s\"%s 검색 결과 (%d건 중 %d-%d):\n",
+			query, mode, page.Total, searchOffset+1, searchOffset+len(results))
+	}
 
 	highlightStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("11"))
 
 	for i, result := range results {
-		fmt.Fprintf(cmd.OutOrStdout(), "[%d] %s %d:%d\n", i+1, result.Verse.BookName, result.Verse.Chapter, result.Verse.VerseNum)
+		fmt.Fprintf(cmd.OutOrStdout(), "[%d] %s %d:%d\n", searchOffset+i+1, result.Verse.BookName, result.Verse.Chapter, result.Verse.VerseNum)
 
 		snippet := result.Snippet
 		if snippet == "" {
 			snippet = result.Verse.Text
 		}
 
-		highlightedSnippet := highlightSearchTerm(snippet, query, highlightStyle)
-		fmt.Fprintf(cmd.OutOrStdout(), "    %s\n", highlightedSnippet)
+		for _, term := range result.Highlights {
+			snippet = highlightSearchTerm(snippet, term, highlightStyle)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "    %s\n", snippet)
 	}
 
 	return nil
 }
 
+// searchJSON is the --format json output of search.
+type searchJSON struct {
+	Query  string `json:"query"`
+	Regex  bool   `json:"regex"`
+	Offset int    `json:"offset"`
+	*db.SearchPage
+}
+
+func newSearchJSON(query string, regex bool, offset int, page *db.SearchPage) searchJSON {
+	if page.Results == nil {
+		page.Results = []db.SearchResult{}
+	}
+	if page.BookCounts == nil {
+		page.BookCounts = []db.BookCount{}
+	}
+	return searchJSON{Query: query, Regex: regex, Offset: offset, SearchPage: page}
+}
+
+// printSearchMarkdown prints a page of results as a numbered Markdown list
+// with the matches in bold.
+func printSearchMarkdown(cmd *cobra.Command, query string, page *db.SearchPage) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "## \"%s\" 검색 결과 (%d건)\n\n", query, page.Total)
+	for i, result := range page.Results {
+		snippet := result.Snippet
+		if snippet == "" {
+			snippet = result.Verse.Text
+		}
+		for _, term := range result.Highlights {
+			snippet = markSearchTerm(snippet, term, markdownBold)
+		}
+		fmt.Fprintf(out, "%d. **%s %d:%d** — %s\n", searchOffset+i+1,
+			result.Verse.BookName, result.Verse.Chapter, result.Verse.VerseNum, snippet)
+	}
+}
+
 func highlightSearchTerm(text, query string, style lipgloss.Style) string {
+	return markSearchTerm(text, query, func(s string) string { return style.Render(s) })
+}
+
+// markSearchTerm passes each case-insensitive occurrence of query in text
+// through mark.
+func markSearchTerm(text, query string, mark func(string) string) string {
 	if query == "" {
 		return text
 	}
@@ -85,10 +249,92 @@ func highlightSearchTerm(text, query string, style lipgloss.Style) string {
 		result.WriteString(text[pos : pos+idx])
 
 		matchText := text[pos+idx : pos+idx+len(query)]
-		result.WriteString(style.Render(matchText))
+		result.WriteString(mark(matchText))
 
 		pos += idx + len(query)
 	}
 
 	return result.String()
 }
+
+// printSearchHistory lists recent searches and saved searches.
+func printSearchHistory(cmd *cobra.Command, database *db.DB) error {
+	history, err := database.ListSearchHistory(searchHistoryShown)
+	if err != nil {
+		return err
+	}
+	saved, err := database.ListSavedSearches()
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	switch outputFormat {
+	case formatJSON:
+		if history == nil {
+			history = []db.SearchHistoryEntry{}
+		}
+		if saved == nil {
+			saved = []db.SavedSearch{}
+		}
+		return writeJSON(out, searchHistoryJSON{History: history, Saved: saved})
+	case formatMarkdown:
+		printSearchHistoryMarkdown(cmd, history, saved)
+		return nil
+	}
+	if len(history) == 0 && len(saved) == 0 {
+		fmt.Fprintln(out, "검색 기록이 없습니다.")
+		return nil
+	}
+	if len(history) > 0 {
+		fmt.Fprintln(out, "최근 검색:")
+		for _, e := range history {
+			fmt.Fprintf(out, "  %s  %s\n", e.SearchedAt.Local().Format("2006-01-02 15:04"), regexLabel(e.Query, e.Regex))
+		}
+	}
+	if len(saved) > 0 {
+		if len(history) > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintln(out, "저장한 검색 (--saved <이름>):")
+		for _, s := range saved {
+			fmt.Fprintf(out, "  %s: %s\n", s.Name, regexLabel(s.Query, s.Regex))
+		}
+	}
+	return nil
+}
+
+// searchHistoryJSON is the --format json output of search --history.
+type searchHistoryJSON struct {
+	History []db.SearchHistoryEntry `json:"history"`
+	Saved   []db.SavedSearch        `json:"saved"`
+}
+
+// printSearchHistoryMarkdown prints the recent and saved searches as two
+// Markdown lists.
+func printSearchHistoryMarkdown(cmd *cobra.Command, history []db.SearchHistoryEntry, saved []db.SavedSearch) {
+	out := cmd.OutOrStdout()
+	if len(history) > 0 {
+		fmt.Fprintf(out, "## 최근 검색\n\n")
+		for _, e := range history {
+			fmt.Fprintf(out, "- %s — %s\n", e.SearchedAt.Local().Format("2006-01-02 15:04"), regexLabel(e.Query, e.Regex))
+		}
+	}
+	if len(saved) > 0 {
+		if len(history) > 0 {
+			fmt.Fprintln(out)
+		}
+		fmt.Fprintf(out, "## 저장한 검색\n\n")
+		for _, s := range saved {
+			fmt.Fprintf(out, "- **%s** — %s\n", s.Name, regexLabel(s.Query, s.Regex))
+		}
+	}
+}
+
+// regexLabel marks regex queries so they can be told apart from words.
+func regexLabel(query string, regex bool) string {
+	if regex {
+		return query + " (정규식)"
+	}
+	return query
+}
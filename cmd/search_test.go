@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 )
@@ -33,6 +34,99 @@ func TestSearchCommand(t *testing.T) {
 	}
 }
 
+func TestSearchCommand_Chosung(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() { testDB = nil }()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"search", "ㅎㄴㄴ"})
+
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "초성 검색 결과") {
+		t.Errorf("expected chosung search header, got: %s", output)
+	}
+	if !strings.Contains(output, "하나님") {
+		t.Errorf("expected output to contain '하나님', got: %s", output)
+	}
+}
+
+func TestSearchCommand_ScopeFlags(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		searchBooks = nil
+		searchTestament = ""
+	}()
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"search", "하나님", "--book", "창"}, "검색 결과 ("},
+		{[]string{"search", "하나님", "--testament", "new"}, "검색 결과가 없습니다"},
+		{[]string{"search", "--testament", "old", "--", "하나님", "-빛"}, "검색 결과 (2건)"},
+	}
+	for _, tt := range tests {
+		searchBooks, searchTestament = nil, ""
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetArgs(tt.args)
+
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", tt.args, err)
+		}
+		if !strings.Contains(buf.String(), tt.want) {
+			t.Errorf("%v: expected %q in output, got: %s", tt.args, tt.want, buf.String())
+		}
+	}
+
+	searchBooks, searchTestament = nil, ""
+	rootCmd.SetArgs([]string{"search", "하나님", "--book", "없는책"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown book") {
+		t.Errorf("expected unknown book error, got %v", err)
+	}
+}
+
+func TestSearchCommand_Paging(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		searchLimit, searchOffset, searchSort = 20, 0, "relevance"
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"search", "하나님", "--sort", "canonical", "--limit", "1", "--offset", "1"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "검색 결과 (3건 중 2-2)") {
+		t.Errorf("expected page header, got: %s", output)
+	}
+	if !strings.Contains(output, "[2] 창세기 1:2") {
+		t.Errorf("expected the second verse in Bible order, got: %s", output)
+	}
+
+	searchLimit, searchOffset, searchSort = 20, 0, "relevance"
+	rootCmd.SetArgs([]string{"search", "하나님", "--sort", "date"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "unknown sort") {
+		t.Errorf("expected unknown sort error, got %v", err)
+	}
+}
+
 func TestSearchCommand_NoResults(t *testing.T) {
 	database := setupTestDB(t)
 	testDB = database
@@ -57,6 +151,105 @@ func TestSearchCommand_NoResults(t *testing.T) {
 	}
 }
 
+func TestSearchCommand_Regex(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		searchRegex = false
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"search", "--regex", "^하나님(이|의)"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "정규식 검색 결과 (1건)") || !strings.Contains(output, "창세기 1:3") {
+		t.Errorf("expected the one verse starting with 하나님이, got: %s", output)
+	}
+
+	rootCmd.SetArgs([]string{"search", "--regex", "하나님("})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "invalid regex") {
+		t.Errorf("expected invalid regex error, got %v", err)
+	}
+}
+
+func TestSearchCommand_HistoryAndSaved(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		searchBooks, searchHistory, searchSaved, searchSaveAs = nil, false, "", ""
+		outputFormat = formatText
+	}()
+
+	run := func(args ...string) string {
+		t.Helper()
+		searchBooks, searchHistory, searchSaved, searchSaveAs = nil, false, "", ""
+		buf := new(bytes.Buffer)
+		rootCmd.SetOut(buf)
+		rootCmd.SetErr(buf)
+		rootCmd.SetArgs(append([]string{"search"}, args...))
+		if err := rootCmd.Execute(); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		return buf.String()
+	}
+
+	if out := run("--history"); !strings.Contains(out, "검색 기록이 없습니다") {
+		t.Errorf("expected empty history, got: %s", out)
+	}
+
+	out := run("빛", "--book", "창", "--save", "창세기 빛")
+	if !strings.Contains(out, "검색 저장: 창세기 빛 (빛 book:창)") {
+		t.Errorf("expected saved search, got: %s", out)
+	}
+	run("하나님")
+
+	out = run("--history")
+	lines := strings.Split(out, "\n")
+	if len(lines) < 3 || lines[0] != "최근 검색:" || !strings.HasSuffix(lines[1], " 하나님") || !strings.HasSuffix(lines[2], " 빛 book:창") {
+		t.Errorf("expected recent searches newest first, got: %s", out)
+	}
+	if !strings.Contains(out, "창세기 빛: 빛 book:창") {
+		t.Errorf("expected saved search listed, got: %s", out)
+	}
+
+	out = run("--history", "--format", "json")
+	var got searchHistoryJSON
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(got.History) != 2 || got.History[0].Query != "하나님" || len(got.Saved) != 1 || got.Saved[0].Name != "창세기 빛" {
+		t.Errorf("unexpected history JSON %+v", got)
+	}
+	out = run("--history", "--format", "markdown")
+	if !strings.Contains(out, "## 최근 검색") || !strings.Contains(out, "- **창세기 빛** — 빛 book:창") {
+		t.Errorf("unexpected history markdown: %s", out)
+	}
+	outputFormat = formatText
+
+	out = run("--saved", "창세기 빛")
+	if !strings.Contains(out, "\"빛 book:창\" 검색 결과 (1건)") {
+		t.Errorf("expected saved search to run, got: %s", out)
+	}
+
+	searchSaved = ""
+	rootCmd.SetArgs([]string{"search", "--saved", "없는 검색"})
+	if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), "no saved search") {
+		t.Errorf("expected missing saved search error, got %v", err)
+	}
+	searchSaved = ""
+	rootCmd.SetArgs([]string{"search"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("expected an error without a query")
+	}
+}
+
 func TestSearchCommand_Help(t *testing.T) {
 	buf := new(bytes.Buffer)
 	rootCmd.SetOut(buf)
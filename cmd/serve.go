@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yangsijun/bible-tui/internal/server"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "JSON API 서버 실행",
+	Long: `성경 데이터베이스를 HTTP JSON API로 제공합니다. 교회 화면이나 채팅 도구에서
+본문, 검색, 책갈피, 읽기 계획을 가져갈 때 씁니다.
+
+  GET    /v1/versions                 역본 목록
+  GET    /v1/books?version=GAE        책 목록
+  GET    /v1/passage?ref=요3:16-18    본문 (ETag 지원)
+  GET    /v1/search?q=사랑&limit=20   검색 (offset, sort, regex, version)
+  GET    /v1/random                   랜덤 구절
+  GET    /v1/bookmarks                책갈피 목록 (limit, offset, tag)
+  POST   /v1/bookmarks                책갈피 추가 (범위마다 하나) {"ref": "고전 13:4-7", "note": "...", "tags": ["암송"]}
+  GET    /v1/bookmarks/{id}           책갈피
+  PATCH  /v1/bookmarks/{id}           메모 수정 {"note": "..."}
+  DELETE /v1/bookmarks/{id}           책갈피 삭제
+  GET    /v1/plans                    읽기 계획과 진행률
+  GET    /v1/plans/{id}               읽기 계획, 진행률, 오늘 읽을 곳
+
+기본으로는 이 컴퓨터에서만 접속할 수 있습니다. 다른 기기에서 쓰려면 --addr :8080.`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+var (
+	serveAddr    string
+	serveVersion string
+)
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8080", "접속을 받을 주소")
+	serveCmd.Flags().StringVarP(&serveVersion, "version", "v", "", "기본 성경 버전 코드 (기본: 설정된 역본)")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+
+	versionCode, err := resolveVersion(database, serveVersion)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", serveAddr)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Handler:           server.New(database, versionCode),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "http://%s 에서 API 제공 중 (%s, Ctrl+C로 종료)\n", listener.Addr(), versionCode)
+	if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
+	"github.com/yangsijun/bible-tui/internal/db"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "성경 통계",
+	Long:  "성경 본문의 통계를 보여줍니다.",
+}
+
+var statsWordsCmd = &cobra.Command{
+	Use:   "words",
+	Short: "자주 나오는 단어",
+	Long: `지정한 범위에서 가장 자주 나오는 단어를 보여줍니다.
+단어는 본문에 쓰인 그대로 세므로 하나님이와 하나님의는 따로 셉니다.
+예: bible stats words --book 롬 --top 50, bible stats words --testament new`,
+	Args: cobra.NoArgs,
+	RunE: runStatsWords,
+}
+
+var (
+	statsVersion   string
+	statsBooks     []string
+	statsTestament string
+	statsTop       int
+)
+
+func init() {
+	statsWordsCmd.Flags().StringVarP(&statsVersion, "version", "v", "", "성경 버전 코드 (기본: 설정된 역본)")
+	statsWordsCmd.Flags().StringSliceVar(&statsBooks, "book", nil, "셀 책 (예: 롬, 여러 권은 롬,고전)")
+	statsWordsCmd.Flags().StringVar(&statsTestament, "testament", "", "셀 성경: old(구약) 또는 new(신약)")
+	statsWordsCmd.Flags().IntVar(&statsTop, "top", 20, "보여줄 단어 수 (0: 전부)")
+	statsCmd.AddCommand(statsWordsCmd)
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStatsWords(cmd *cobra.Command, args []string) error {
+	if statsTop < 0 {
+		return fmt.Errorf("--top must not be negative")
+	}
+	var scope db.SearchQuery
+	if err := applyScopeFlags(&scope, statsBooks, statsTestament); err != nil {
+		return err
+	}
+
+	database, err := getDB()
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+
+	versionCode, err := resolveVersion(database, statsVersion)
+	if err != nil {
+		return err
+	}
+
+	words, err := database.WordFrequencies(versionCode, scope, statsTop)
+	if err != nil {
+		return fmt.Errorf("word frequencies: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if len(words) == 0 {
+		fmt.Fprintf(out, "%s에 단어가 없습니다\n", scopeLabel(scope))
+		return nil
+	}
+	fmt.Fprintf(out, "%s 단어 빈도 (%d개):\n", scopeLabel(scope), len(words))
+	for i, w := range words {
+		fmt.Fprintf(out, "%4d  %6d  %s\n", i+1, w.Count, w.Word)
+	}
+	return nil
+}
+
+// scopeLabel describes the books and testament of a scope, e.g. "로마서,
+// 갈라디아서" or "신약".
+func scopeLabel(scope db.SearchQuery) string {
+	var parts []string
+	for _, code := range scope.Books {
+		parts = append(parts, bible.GetBookName(code))
+	}
+	label := strings.Join(parts, ", ")
+	switch scope.Testament {
+	case "old":
+		label = strings.TrimSpace(label + " 구약")
+	case "new":
+		label = strings.TrimSpace(label + " 신약")
+	}
+	if label == "" {
+		return "성경 전체"
+	}
+	return label
+}
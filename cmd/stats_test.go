@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestStatsWordsCommand(t *testing.T) {
+	database := setupTestDB(t)
+	testDB = database
+	defer func() {
+		testDB = nil
+		statsBooks, statsTop = nil, 20
+	}()
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOut(buf)
+	rootCmd.SetErr(buf)
+	rootCmd.SetArgs([]string{"stats", "words", "--book", "창", "--top", "3"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "창세기 단어 빈도 (3개):") {
+		t.Errorf("expected header, got: %s", output)
+	}
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 4 || strings.Fields(lines[3])[2] != "하나님이" {
+		t.Errorf("expected three ranked words ending with 하나님이, got: %s", output)
+	}
+
+	statsBooks, statsTop = nil, 20
+	rootCmd.SetArgs([]string{"stats", "words", "--top", "-1"})
+	if err := rootCmd.Execute(); err == nil {
+		t.Error("expected an error for a negative --top")
+	}
+}
@@ -34,11 +34,15 @@ func setupTestDB(t *testing.T) *db.DB {
 		t.Fatal(err)
 	}
 
-	_, err = database.InsertVerse(bookID, 1, 2, "땅이 혼돈하고 공허하며 흑암이 깊음 위에 있고 하나님의 영은 수면 위에 운행하시니라", "", true)
+	verse2ID, err := database.InsertVerse(bookID, 1, 2, "땅이 혼돈하고 공허하며 흑암이 깊음 위에 있고 하나님의 영은 수면 위에 운행하시니라", "", true)
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	if err := database.InsertFootnote(verse2ID, "1)", "또는 형체가 없는"); err != nil {
+		t.Fatal(err)
+	}
+
 	_, err = database.InsertVerse(bookID, 1, 3, "하나님이 이르시되 빛이 있으라 하시니 빛이 있었고", "", false)
 	if err != nil {
 		t.Fatal(err)
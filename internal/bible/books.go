@@ -1,86 +1,94 @@
 package bible
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 // BookInfo contains metadata for a single Bible book
 type BookInfo struct {
-	Code         string // English code: "gen", "exo", etc.
-	NameKo       string // Korean full name: "창세기"
-	AbbrevKo     string // Korean abbreviation: "창"
-	Testament    string // "old" or "new"
-	ChapterCount int    // Number of chapters
+	Code         string   // English code: "gen", "exo", etc.
+	NameKo       string   // Korean full name: "창세기"
+	AbbrevKo     string   // Korean abbreviation: "창"
+	NameEn       string   // English name: "Genesis"
+	OSIS         string   // OSIS book ID: "Gen"
+	AbbrevsEn    []string // SBL and common English abbreviations: "Gen", "Ge", "Gn"
+	Testament    string   // "old" or "new"
+	ChapterCount int      // Number of chapters
 }
 
 var allBooks = []BookInfo{
 	// Old Testament (39 books)
-	{Code: "gen", NameKo: "창세기", AbbrevKo: "창", Testament: "old", ChapterCount: 50},
-	{Code: "exo", NameKo: "출애굽기", AbbrevKo: "출", Testament: "old", ChapterCount: 40},
-	{Code: "lev", NameKo: "레위기", AbbrevKo: "레", Testament: "old", ChapterCount: 27},
-	{Code: "num", NameKo: "민수기", AbbrevKo: "민", Testament: "old", ChapterCount: 36},
-	{Code: "deu", NameKo: "신명기", AbbrevKo: "신", Testament: "old", ChapterCount: 34},
-	{Code: "jos", NameKo: "여호수아", AbbrevKo: "수", Testament: "old", ChapterCount: 24},
-	{Code: "jdg", NameKo: "사사기", AbbrevKo: "삿", Testament: "old", ChapterCount: 21},
-	{Code: "rut", NameKo: "룻기", AbbrevKo: "룻", Testament: "old", ChapterCount: 4},
-	{Code: "1sa", NameKo: "사무엘상", AbbrevKo: "삼상", Testament: "old", ChapterCount: 31},
-	{Code: "2sa", NameKo: "사무엘하", AbbrevKo: "삼하", Testament: "old", ChapterCount: 24},
-	{Code: "1ki", NameKo: "열왕기상", AbbrevKo: "왕상", Testament: "old", ChapterCount: 22},
-	{Code: "2ki", NameKo: "열왕기하", AbbrevKo: "왕하", Testament: "old", ChapterCount: 25},
-	{Code: "1ch", NameKo: "역대상", AbbrevKo: "대상", Testament: "old", ChapterCount: 29},
-	{Code: "2ch", NameKo: "역대하", AbbrevKo: "대하", Testament: "old", ChapterCount: 36},
-	{Code: "ezr", NameKo: "에스라", AbbrevKo: "스", Testament: "old", ChapterCount: 10},
-	{Code: "neh", NameKo: "느헤미야", AbbrevKo: "느", Testament: "old", ChapterCount: 13},
-	{Code: "est", NameKo: "에스더", AbbrevKo: "에", Testament: "old", ChapterCount: 10},
-	{Code: "job", NameKo: "욥기", AbbrevKo: "욥", Testament: "old", ChapterCount: 42},
-	{Code: "psa", NameKo: "시편", AbbrevKo: "시", Testament: "old", ChapterCount: 150},
-	{Code: "pro", NameKo: "잠언", AbbrevKo: "잠", Testament: "old", ChapterCount: 31},
-	{Code: "ecc", NameKo: "전도서", AbbrevKo: "전", Testament: "old", ChapterCount: 12},
-	{Code: "sng", NameKo: "아가", AbbrevKo: "아", Testament: "old", ChapterCount: 8},
-	{Code: "isa", NameKo: "이사야", AbbrevKo: "사", Testament: "old", ChapterCount: 66},
-	{Code: "jer", NameKo: "예레미야", AbbrevKo: "렘", Testament: "old", ChapterCount: 52},
-	{Code: "lam", NameKo: "예레미야애가", AbbrevKo: "애", Testament: "old", ChapterCount: 5},
-	{Code: "ezk", NameKo: "에스겔", AbbrevKo: "겔", Testament: "old", ChapterCount: 48},
-	{Code: "dan", NameKo: "다니엘", AbbrevKo: "단", Testament: "old", ChapterCount: 12},
-	{Code: "hos", NameKo: "호세아", AbbrevKo: "호", Testament: "old", ChapterCount: 14},
-	{Code: "jol", NameKo: "요엘", AbbrevKo: "욜", Testament: "old", ChapterCount: 3},
-	{Code: "amo", NameKo: "아모스", AbbrevKo: "암", Testament: "old", ChapterCount: 9},
-	{Code: "oba", NameKo: "오바댜", AbbrevKo: "옵", Testament: "old", ChapterCount: 1},
-	{Code: "jnh", NameKo: "요나", AbbrevKo: "욘", Testament: "old", ChapterCount: 4},
-	{Code: "mic", NameKo: "미가", AbbrevKo: "미", Testament: "old", ChapterCount: 7},
-	{Code: "nam", NameKo: "나훔", AbbrevKo: "나", Testament: "old", ChapterCount: 3},
-	{Code: "hab", NameKo: "하박국", AbbrevKo: "합", Testament: "old", ChapterCount: 3},
-	{Code: "zep", NameKo: "스바냐", AbbrevKo: "습", Testament: "old", ChapterCount: 3},
-	{Code: "hag", NameKo: "학개", AbbrevKo: "학", Testament: "old", ChapterCount: 2},
-	{Code: "zec", NameKo: "스가랴", AbbrevKo: "슥", Testament: "old", ChapterCount: 14},
-	{Code: "mal", NameKo: "말라기", AbbrevKo: "말", Testament: "old", ChapterCount: 4},
+	{Code: "gen", NameKo: "창세기", AbbrevKo: "창", NameEn: "Genesis", OSIS: "Gen", AbbrevsEn: []string{"Gen", "Ge", "Gn"}, Testament: "old", ChapterCount: 50},
+	{Code: "exo", NameKo: "출애굽기", AbbrevKo: "출", NameEn: "Exodus", OSIS: "Exod", AbbrevsEn: []string{"Exod", "Ex", "Exo"}, Testament: "old", ChapterCount: 40},
+	{Code: "lev", NameKo: "레위기", AbbrevKo: "레", NameEn: "Leviticus", OSIS: "Lev", AbbrevsEn: []string{"Lev", "Le", "Lv"}, Testament: "old", ChapterCount: 27},
+	{Code: "num", NameKo: "민수기", AbbrevKo: "민", NameEn: "Numbers", OSIS: "Num", AbbrevsEn: []string{"Num", "Nu", "Nm"}, Testament: "old", ChapterCount: 36},
+	{Code: "deu", NameKo: "신명기", AbbrevKo: "신", NameEn: "Deuteronomy", OSIS: "Deut", AbbrevsEn: []string{"Deut", "Dt", "De"}, Testament: "old", ChapterCount: 34},
+	{Code: "jos", NameKo: "여호수아", AbbrevKo: "수", NameEn: "Joshua", OSIS: "Josh", AbbrevsEn: []string{"Josh", "Jsh"}, Testament: "old", ChapterCount: 24},
+	{Code: "jdg", NameKo: "사사기", AbbrevKo: "삿", NameEn: "Judges", OSIS: "Judg", AbbrevsEn: []string{"Judg", "Jdg", "Jg"}, Testament: "old", ChapterCount: 21},
+	{Code: "rut", NameKo: "룻기", AbbrevKo: "룻", NameEn: "Ruth", OSIS: "Ruth", AbbrevsEn: []string{"Ruth", "Ru", "Rth"}, Testament: "old", ChapterCount: 4},
+	{Code: "1sa", NameKo: "사무엘상", AbbrevKo: "삼상", NameEn: "1 Samuel", OSIS: "1Sam", AbbrevsEn: []string{"1 Sam", "1Sa", "1S"}, Testament: "old", ChapterCount: 31},
+	{Code: "2sa", NameKo: "사무엘하", AbbrevKo: "삼하", NameEn: "2 Samuel", OSIS: "2Sam", AbbrevsEn: []string{"2 Sam", "2Sa", "2S"}, Testament: "old", ChapterCount: 24},
+	{Code: "1ki", NameKo: "열왕기상", AbbrevKo: "왕상", NameEn: "1 Kings", OSIS: "1Kgs", AbbrevsEn: []string{"1 Kgs", "1Ki", "1K"}, Testament: "old", ChapterCount: 22},
+	{Code: "2ki", NameKo: "열왕기하", AbbrevKo: "왕하", NameEn: "2 Kings", OSIS: "2Kgs", AbbrevsEn: []string{"2 Kgs", "2Ki", "2K"}, Testament: "old", ChapterCount: 25},
+	{Code: "1ch", NameKo: "역대상", AbbrevKo: "대상", NameEn: "1 Chronicles", OSIS: "1Chr", AbbrevsEn: []string{"1 Chr", "1Ch"}, Testament: "old", ChapterCount: 29},
+	{Code: "2ch", NameKo: "역대하", AbbrevKo: "대하", NameEn: "2 Chronicles", OSIS: "2Chr", AbbrevsEn: []string{"2 Chr", "2Ch"}, Testament: "old", ChapterCount: 36},
+	{Code: "ezr", NameKo: "에스라", AbbrevKo: "스", NameEn: "Ezra", OSIS: "Ezra", AbbrevsEn: []string{"Ezra", "Ezr"}, Testament: "old", ChapterCount: 10},
+	{Code: "neh", NameKo: "느헤미야", AbbrevKo: "느", NameEn: "Nehemiah", OSIS: "Neh", AbbrevsEn: []string{"Neh", "Ne"}, Testament: "old", ChapterCount: 13},
+	{Code: "est", NameKo: "에스더", AbbrevKo: "에", NameEn: "Esther", OSIS: "Esth", AbbrevsEn: []string{"Esth", "Est", "Es"}, Testament: "old", ChapterCount: 10},
+	{Code: "job", NameKo: "욥기", AbbrevKo: "욥", NameEn: "Job", OSIS: "Job", AbbrevsEn: []string{"Job", "Jb"}, Testament: "old", ChapterCount: 42},
+	{Code: "psa", NameKo: "시편", AbbrevKo: "시", NameEn: "Psalms", OSIS: "Ps", AbbrevsEn: []string{"Ps", "Pss", "Psa", "Psalm"}, Testament: "old", ChapterCount: 150},
+	{Code: "pro", NameKo: "잠언", AbbrevKo: "잠", NameEn: "Proverbs", OSIS: "Prov", AbbrevsEn: []string{"Prov", "Pr", "Prv"}, Testament: "old", ChapterCount: 31},
+	{Code: "ecc", NameKo: "전도서", AbbrevKo: "전", NameEn: "Ecclesiastes", OSIS: "Eccl", AbbrevsEn: []string{"Eccl", "Ecc", "Ec", "Qoh"}, Testament: "old", ChapterCount: 12},
+	{Code: "sng", NameKo: "아가", AbbrevKo: "아", NameEn: "Song of Songs", OSIS: "Song", AbbrevsEn: []string{"Song", "Song of Solomon", "SS", "Cant"}, Testament: "old", ChapterCount: 8},
+	{Code: "isa", NameKo: "이사야", AbbrevKo: "사", NameEn: "Isaiah", OSIS: "Isa", AbbrevsEn: []string{"Isa", "Is"}, Testament: "old", ChapterCount: 66},
+	{Code: "jer", NameKo: "예레미야", AbbrevKo: "렘", NameEn: "Jeremiah", OSIS: "Jer", AbbrevsEn: []string{"Jer", "Je", "Jr"}, Testament: "old", ChapterCount: 52},
+	{Code: "lam", NameKo: "예레미야애가", AbbrevKo: "애", NameEn: "Lamentations", OSIS: "Lam", AbbrevsEn: []string{"Lam", "La"}, Testament: "old", ChapterCount: 5},
+	{Code: "ezk", NameKo: "에스겔", AbbrevKo: "겔", NameEn: "Ezekiel", OSIS: "Ezek", AbbrevsEn: []string{"Ezek", "Eze", "Ezk"}, Testament: "old", ChapterCount: 48},
+	{Code: "dan", NameKo: "다니엘", AbbrevKo: "단", NameEn: "Daniel", OSIS: "Dan", AbbrevsEn: []string{"Dan", "Da", "Dn"}, Testament: "old", ChapterCount: 12},
+	{Code: "hos", NameKo: "호세아", AbbrevKo: "호", NameEn: "Hosea", OSIS: "Hos", AbbrevsEn: []string{"Hos", "Ho"}, Testament: "old", ChapterCount: 14},
+	{Code: "jol", NameKo: "요엘", AbbrevKo: "욜", NameEn: "Joel", OSIS: "Joel", AbbrevsEn: []string{"Joel", "Jl"}, Testament: "old", ChapterCount: 3},
+	{Code: "amo", NameKo: "아모스", AbbrevKo: "암", NameEn: "Amos", OSIS: "Amos", AbbrevsEn: []string{"Amos", "Am"}, Testament: "old", ChapterCount: 9},
+	{Code: "oba", NameKo: "오바댜", AbbrevKo: "옵", NameEn: "Obadiah", OSIS: "Obad", AbbrevsEn: []string{"Obad", "Ob"}, Testament: "old", ChapterCount: 1},
+	{Code: "jnh", NameKo: "요나", AbbrevKo: "욘", NameEn: "Jonah", OSIS: "Jonah", AbbrevsEn: []string{"Jonah", "Jnh", "Jon"}, Testament: "old", ChapterCount: 4},
+	{Code: "mic", NameKo: "미가", AbbrevKo: "미", NameEn: "Micah", OSIS: "Mic", AbbrevsEn: []string{"Mic", "Mi"}, Testament: "old", ChapterCount: 7},
+	{Code: "nam", NameKo: "나훔", AbbrevKo: "나", NameEn: "Nahum", OSIS: "Nah", AbbrevsEn: []string{"Nah", "Na"}, Testament: "old", ChapterCount: 3},
+	{Code: "hab", NameKo: "하박국", AbbrevKo: "합", NameEn: "Habakkuk", OSIS: "Hab", AbbrevsEn: []string{"Hab", "Hb"}, Testament: "old", ChapterCount: 3},
+	{Code: "zep", NameKo: "스바냐", AbbrevKo: "습", NameEn: "Zephaniah", OSIS: "Zeph", AbbrevsEn: []string{"Zeph", "Zep", "Zp"}, Testament: "old", ChapterCount: 3},
+	{Code: "hag", NameKo: "학개", AbbrevKo: "학", NameEn: "Haggai", OSIS: "Hag", AbbrevsEn: []string{"Hag", "Hg"}, Testament: "old", ChapterCount: 2},
+	{Code: "zec", NameKo: "스가랴", AbbrevKo: "슥", NameEn: "Zechariah", OSIS: "Zech", AbbrevsEn: []string{"Zech", "Zec", "Zc"}, Testament: "old", ChapterCount: 14},
+	{Code: "mal", NameKo: "말라기", AbbrevKo: "말", NameEn: "Malachi", OSIS: "Mal", AbbrevsEn: []string{"Mal", "Ml"}, Testament: "old", ChapterCount: 4},
 
 	// New Testament (27 books)
-	{Code: "mat", NameKo: "마태복음", AbbrevKo: "마", Testament: "new", ChapterCount: 28},
-	{Code: "mrk", NameKo: "마가복음", AbbrevKo: "막", Testament: "new", ChapterCount: 16},
-	{Code: "luk", NameKo: "누가복음", AbbrevKo: "눅", Testament: "new", ChapterCount: 24},
-	{Code: "jhn", NameKo: "요한복음", AbbrevKo: "요", Testament: "new", ChapterCount: 21},
-	{Code: "act", NameKo: "사도행전", AbbrevKo: "행", Testament: "new", ChapterCount: 28},
-	{Code: "rom", NameKo: "로마서", AbbrevKo: "롬", Testament: "new", ChapterCount: 16},
-	{Code: "1co", NameKo: "고린도전서", AbbrevKo: "고전", Testament: "new", ChapterCount: 16},
-	{Code: "2co", NameKo: "고린도후서", AbbrevKo: "고후", Testament: "new", ChapterCount: 13},
-	{Code: "gal", NameKo: "갈라디아서", AbbrevKo: "갈", Testament: "new", ChapterCount: 6},
-	{Code: "eph", NameKo: "에베소서", AbbrevKo: "엡", Testament: "new", ChapterCount: 6},
-	{Code: "php", NameKo: "빌립보서", AbbrevKo: "빌", Testament: "new", ChapterCount: 4},
-	{Code: "col", NameKo: "골로새서", AbbrevKo: "골", Testament: "new", ChapterCount: 4},
-	{Code: "1th", NameKo: "데살로니가전서", AbbrevKo: "살전", Testament: "new", ChapterCount: 5},
-	{Code: "2th", NameKo: "데살로니가후서", AbbrevKo: "살후", Testament: "new", ChapterCount: 3},
-	{Code: "1ti", NameKo: "디모데전서", AbbrevKo: "딤전", Testament: "new", ChapterCount: 6},
-	{Code: "2ti", NameKo: "디모데후서", AbbrevKo: "딤후", Testament: "new", ChapterCount: 4},
-	{Code: "tit", NameKo: "디도서", AbbrevKo: "딛", Testament: "new", ChapterCount: 3},
-	{Code: "phm", NameKo: "빌레몬서", AbbrevKo: "몬", Testament: "new", ChapterCount: 1},
-	{Code: "heb", NameKo: "히브리서", AbbrevKo: "히", Testament: "new", ChapterCount: 13},
-	{Code: "jas", NameKo: "야고보서", AbbrevKo: "약", Testament: "new", ChapterCount: 5},
-	{Code: "1pe", NameKo: "베드로전서", AbbrevKo: "벧전", Testament: "new", ChapterCount: 5},
-	{Code: "2pe", NameKo: "베드로후서", AbbrevKo: "벧후", Testament: "new", ChapterCount: 3},
-	{Code: "1jn", NameKo: "요한1서", AbbrevKo: "요일", Testament: "new", ChapterCount: 5},
-	{Code: "2jn", NameKo: "요한2서", AbbrevKo: "요이", Testament: "new", ChapterCount: 1},
-	{Code: "3jn", NameKo: "요한3서", AbbrevKo: "요삼", Testament: "new", ChapterCount: 1},
-	{Code: "jud", NameKo: "유다서", AbbrevKo: "유", Testament: "new", ChapterCount: 1},
-	{Code: "rev", NameKo: "요한계시록", AbbrevKo: "계", Testament: "new", ChapterCount: 22},
+	{Code: "mat", NameKo: "마태복음", AbbrevKo: "마", NameEn: "Matthew", OSIS: "Matt", AbbrevsEn: []string{"Matt", "Mt"}, Testament: "new", ChapterCount: 28},
+	{Code: "mrk", NameKo: "마가복음", AbbrevKo: "막", NameEn: "Mark", OSIS: "Mark", AbbrevsEn: []string{"Mark", "Mk", "Mr"}, Testament: "new", ChapterCount: 16},
+	{Code: "luk", NameKo: "누가복음", AbbrevKo: "눅", NameEn: "Luke", OSIS: "Luke", AbbrevsEn: []string{"Luke", "Lk", "Lu"}, Testament: "new", ChapterCount: 24},
+	{Code: "jhn", NameKo: "요한복음", AbbrevKo: "요", NameEn: "John", OSIS: "John", AbbrevsEn: []string{"John", "Jn", "Jhn"}, Testament: "new", ChapterCount: 21},
+	{Code: "act", NameKo: "사도행전", AbbrevKo: "행", NameEn: "Acts", OSIS: "Acts", AbbrevsEn: []string{"Acts", "Ac"}, Testament: "new", ChapterCount: 28},
+	{Code: "rom", NameKo: "로마서", AbbrevKo: "롬", NameEn: "Romans", OSIS: "Rom", AbbrevsEn: []string{"Rom", "Ro", "Rm"}, Testament: "new", ChapterCount: 16},
+	{Code: "1co", NameKo: "고린도전서", AbbrevKo: "고전", NameEn: "1 Corinthians", OSIS: "1Cor", AbbrevsEn: []string{"1 Cor", "1Co"}, Testament: "new", ChapterCount: 16},
+	{Code: "2co", NameKo: "고린도후서", AbbrevKo: "고후", NameEn: "2 Corinthians", OSIS: "2Cor", AbbrevsEn: []string{"2 Cor", "2Co"}, Testament: "new", ChapterCount: 13},
+	{Code: "gal", NameKo: "갈라디아서", AbbrevKo: "갈", NameEn: "Galatians", OSIS: "Gal", AbbrevsEn: []string{"Gal", "Ga"}, Testament: "new", ChapterCount: 6},
+	{Code: "eph", NameKo: "에베소서", AbbrevKo: "엡", NameEn: "Ephesians", OSIS: "Eph", AbbrevsEn: []string{"Eph", "Ep"}, Testament: "new", ChapterCount: 6},
+	{Code: "php", NameKo: "빌립보서", AbbrevKo: "빌", NameEn: "Philippians", OSIS: "Phil", AbbrevsEn: []string{"Phil", "Php", "Pp"}, Testament: "new", ChapterCount: 4},
+	{Code: "col", NameKo: "골로새서", AbbrevKo: "골", NameEn: "Colossians", OSIS: "Col", AbbrevsEn: []string{"Col", "Co"}, Testament: "new", ChapterCount: 4},
+	{Code: "1th", NameKo: "데살로니가전서", AbbrevKo: "살전", NameEn: "1 Thessalonians", OSIS: "1Thess", AbbrevsEn: []string{"1 Thess", "1Th"}, Testament: "new", ChapterCount: 5},
+	{Code: "2th", NameKo: "데살로니가후서", AbbrevKo: "살후", NameEn: "2 Thessalonians", OSIS: "2Thess", AbbrevsEn: []string{"2 Thess", "2Th"}, Testament: "new", ChapterCount: 3},
+	{Code: "1ti", NameKo: "디모데전서", AbbrevKo: "딤전", NameEn: "1 Timothy", OSIS: "1Tim", AbbrevsEn: []string{"1 Tim", "1Ti"}, Testament: "new", ChapterCount: 6},
+	{Code: "2ti", NameKo: "디모데후서", AbbrevKo: "딤후", NameEn: "2 Timothy", OSIS: "2Tim", AbbrevsEn: []string{"2 Tim", "2Ti"}, Testament: "new", ChapterCount: 4},
+	{Code: "tit", NameKo: "디도서", AbbrevKo: "딛", NameEn: "Titus", OSIS: "Titus", AbbrevsEn: []string{"Titus", "Tit", "Ti"}, Testament: "new", ChapterCount: 3},
+	{Code: "phm", NameKo: "빌레몬서", AbbrevKo: "몬", NameEn: "Philemon", OSIS: "Phlm", AbbrevsEn: []string{"Phlm", "Phm", "Pm"}, Testament: "new", ChapterCount: 1},
+	{Code: "heb", NameKo: "히브리서", AbbrevKo: "히", NameEn: "Hebrews", OSIS: "Heb", AbbrevsEn: []string{"Heb", "He"}, Testament: "new", ChapterCount: 13},
+	{Code: "jas", NameKo: "야고보서", AbbrevKo: "약", NameEn: "James", OSIS: "Jas", AbbrevsEn: []string{"Jas", "Jm"}, Testament: "new", ChapterCount: 5},
+	{Code: "1pe", NameKo: "베드로전서", AbbrevKo: "벧전", NameEn: "1 Peter", OSIS: "1Pet", AbbrevsEn: []string{"1 Pet", "1Pe", "1Pt"}, Testament: "new", ChapterCount: 5},
+	{Code: "2pe", NameKo: "베드로후서", AbbrevKo: "벧후", NameEn: "2 Peter", OSIS: "2Pet", AbbrevsEn: []string{"2 Pet", "2Pe", "2Pt"}, Testament: "new", ChapterCount: 3},
+	{Code: "1jn", NameKo: "요한1서", AbbrevKo: "요일", NameEn: "1 John", OSIS: "1John", AbbrevsEn: []string{"1 John", "1Jn", "1Jo"}, Testament: "new", ChapterCount: 5},
+	{Code: "2jn", NameKo: "요한2서", AbbrevKo: "요이", NameEn: "2 John", OSIS: "2John", AbbrevsEn: []string{"2 John", "2Jn", "2Jo"}, Testament: "new", ChapterCount: 1},
+	{Code: "3jn", NameKo: "요한3서", AbbrevKo: "요삼", NameEn: "3 John", OSIS: "3John", AbbrevsEn: []string{"3 John", "3Jn", "3Jo"}, Testament: "new", ChapterCount: 1},
+	{Code: "jud", NameKo: "유다서", AbbrevKo: "유", NameEn: "Jude", OSIS: "Jude", AbbrevsEn: []string{"Jude", "Jd"}, Testament: "new", ChapterCount: 1},
+	{Code: "rev", NameKo: "요한계시록", AbbrevKo: "계", NameEn: "Revelation", OSIS: "Rev", AbbrevsEn: []string{"Rev", "Re", "Rv"}, Testament: "new", ChapterCount: 22},
 }
 
 // AllBooks returns a copy of all 66 Bible books
@@ -90,6 +98,16 @@ func AllBooks() []BookInfo {
 	return result
 }
 
+// bookIndex returns the canonical position of a book, or -1 if unknown.
+func bookIndex(code string) int {
+	for i := range allBooks {
+		if allBooks[i].Code == code {
+			return i
+		}
+	}
+	return -1
+}
+
 // GetBookByCode looks up a book by its English code (case-insensitive)
 func GetBookByCode(code string) (*BookInfo, bool) {
 	lowerCode := strings.ToLower(code)
@@ -120,3 +138,139 @@ func GetBookByAbbrev(abbrev string) (*BookInfo, bool) {
 	}
 	return nil, false
 }
+
+// bookKeys maps every exact spelling of a book (codes, Korean names and
+// abbreviations, English names, OSIS IDs, English abbreviations) to the
+// book, normalized with bookKey.
+var bookKeys = func() map[string]*BookInfo {
+	keys := make(map[string]*BookInfo)
+	for i := range allBooks {
+		b := &allBooks[i]
+		names := append([]string{b.Code, b.NameKo, b.AbbrevKo, b.NameEn, b.OSIS}, b.AbbrevsEn...)
+		for _, name := range names {
+			keys[bookKey(name)] = b
+		}
+	}
+	return keys
+}()
+
+// bookKey normalizes a book name for matching: lower case, without spaces
+// or periods, so "1 Cor.", "1cor" and "1Cor" compare equal.
+func bookKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '.' || r == '\t' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// GetBookByAnyName looks a book up by any exact spelling FindBook accepts,
+// without prefix matching.
+func GetBookByAnyName(name string) (*BookInfo, bool) {
+	b, ok := bookKeys[bookKey(name)]
+	return b, ok
+}
+
+// FindBook resolves a book name in any supported form: Korean name or
+// abbreviation, internal code, English name, OSIS ID or English
+// abbreviation, ignoring case, spaces and periods. Without an exact match
+// it accepts a prefix of a full name ("Phili", "고린도전") when only one
+// book starts with it. The error suggests candidates for ambiguous or
+// misspelled names.
+func FindBook(name string) (*BookInfo, error) {
+	key := bookKey(name)
+	if key == "" {
+		return nil, fmt.Errorf("unknown book: %q", name)
+	}
+	if b, ok := GetBookByAnyName(name); ok {
+		return b, nil
+	}
+
+	var matches []*BookInfo
+	for i := range allBooks {
+		b := &allBooks[i]
+		if strings.HasPrefix(bookKey(b.NameEn), key) || strings.HasPrefix(bookKey(b.NameKo), key) {
+			matches = append(matches, b)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		if similar := similarBooks(key); len(similar) > 0 {
+			return nil, fmt.Errorf("unknown book: %s (did you mean %s?)", name, bookList(similar, name))
+		}
+		return nil, fmt.Errorf("unknown book: %s", name)
+	default:
+		return nil, fmt.Errorf("ambiguous book: %s (did you mean %s?)", name, bookList(matches, name))
+	}
+}
+
+// similarBooks returns up to three books with a spelling within edit
+// distance 2 of key, closest first.
+func similarBooks(key string) []*BookInfo {
+	const maxDistance = 2
+	best := make(map[*BookInfo]int)
+	for k, b := range bookKeys {
+		d := editDistance(key, k)
+		if d > maxDistance || d >= utf8.RuneCountInString(key) {
+			continue
+		}
+		if prev, ok := best[b]; !ok || d < prev {
+			best[b] = d
+		}
+	}
+
+	var result []*BookInfo
+	for d := 1; d <= maxDistance && len(result) < 3; d++ {
+		for i := range allBooks {
+			if dist, ok := best[&allBooks[i]]; ok && dist == d && len(result) < 3 {
+				result = append(result, &allBooks[i])
+			}
+		}
+	}
+	return result
+}
+
+// bookList names candidate books in the script the user typed.
+func bookList(books []*BookInfo, input string) string {
+	english := true
+	for _, r := range input {
+		if r >= utf8.RuneSelf {
+			english = false
+			break
+		}
+	}
+	names := make([]string, len(books))
+	for i, b := range books {
+		if english {
+			names[i] = b.NameEn
+		} else {
+			names[i] = b.NameKo
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// editDistance is the Levenshtein distance between a and b, in runes.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,228 @@
+package bible
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxPlanDays bounds generated plans, so a typo in a pace cannot fill the
+// database with decades of empty days.
+const maxPlanDays = 3650
+
+// PlanSpec describes a reading plan for GeneratePlan.
+type PlanSpec struct {
+	// Tracks are read side by side, each spread over the reading days on
+	// its own, like the psalms and the proverb of a monthly plan. A track
+	// lists book codes in reading order.
+	Tracks [][]string
+	// Days is the number of reading days. When it is 0, ChaptersPerDay
+	// sets the pace instead and the plan takes as many days as it needs.
+	Days           int
+	ChaptersPerDay int
+	// WeekdaysOnly leaves Saturdays and Sundays free. Start is the date of
+	// day 1, which decides where the weekends fall.
+	WeekdaysOnly bool
+	Start        time.Time
+	// VerseCounts, when set, balances the days by verses instead of
+	// chapters, so a day of short psalms is as long as a day of Genesis.
+	// If it has no count for some chapter, for example because the book
+	// has not been crawled, the days are balanced by chapters.
+	VerseCounts func(bookCode string, chapter int) int
+}
+
+// PaceLabel describes the pace of a spec for a plan title: "40일" or
+// "하루 3장".
+func (s PlanSpec) PaceLabel() string {
+	if s.Days == 0 {
+		return fmt.Sprintf("하루 %d장", s.ChaptersPerDay)
+	}
+	return fmt.Sprintf("%d일", s.Days)
+}
+
+// PlanPreset is a ready-made plan offered by `bible plan new` and the TUI.
+type PlanPreset struct {
+	Key  string
+	Name string
+	Desc string
+	Spec PlanSpec
+}
+
+// PlanPresets returns the built-in generated plans.
+func PlanPresets() []PlanPreset {
+	newTestament, _ := ParseBookList("mat-rev")
+	return []PlanPreset{
+		{"chronological", "연대순 1년 통독", "일어난 순서로 배열한 성경, 365일", PlanSpec{Tracks: [][]string{ChronologicalBooks()}, Days: 365}},
+		{"nt90", "신약 90일", "마태복음→요한계시록, 90일", PlanSpec{Tracks: [][]string{newTestament}, Days: 90}},
+		{"psalms-proverbs", "시편·잠언 한 달", "매일 시편 약 5편과 잠언 1장, 31일", PlanSpec{Tracks: [][]string{{"psa"}, {"pro"}}, Days: 31}},
+	}
+}
+
+// GetPlanPreset returns the preset with the given key.
+func GetPlanPreset(key string) (PlanPreset, bool) {
+	for _, p := range PlanPresets() {
+		if p.Key == key {
+			return p, true
+		}
+	}
+	return PlanPreset{}, false
+}
+
+// chronologicalOrder places each book at the time its events happened or
+// it was written, following common chronological reading plans. It orders
+// whole books, so parallel accounts such as Kings and Chronicles are read
+// one after the other rather than interleaved.
+var chronologicalOrder = []string{
+	"gen", "job", "exo", "lev", "num", "deu", "jos", "jdg", "rut",
+	"1sa", "2sa", "1ch", "psa", "1ki", "pro", "ecc", "sng", "2ch",
+	"oba", "jol", "jnh", "amo", "hos", "isa", "mic", "2ki", "nam",
+	"zep", "hab", "jer", "lam", "ezk", "dan", "ezr", "hag", "zec",
+	"est", "neh", "mal",
+	"mat", "mrk", "luk", "jhn", "act", "jas", "gal", "1th", "2th",
+	"1co", "2co", "rom", "eph", "php", "col", "phm", "1ti", "tit",
+	"1pe", "heb", "2ti", "2pe", "jud", "1jn", "2jn", "3jn", "rev",
+}
+
+// ChronologicalBooks returns every book code in chronological order.
+func ChronologicalBooks() []string {
+	return append([]string(nil), chronologicalOrder...)
+}
+
+// ParseBookList reads a list of books such as "마-요", "mat-jhn" or
+// "롬,갈,엡" into book codes in the order given. A span "a-b" covers the
+// books from a to b in canonical order.
+func ParseBookList(value string) ([]string, error) {
+	var codes []string
+	for _, part := range strings.Split(value, ",") {
+		startName, endName, isSpan := strings.Cut(strings.TrimSpace(part), "-")
+		start, err := FindBook(strings.TrimSpace(startName))
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if isSpan {
+			if end, err = FindBook(strings.TrimSpace(endName)); err != nil {
+				return nil, err
+			}
+		}
+		first, last := bookIndex(start.Code), bookIndex(end.Code)
+		if last < first {
+			return nil, fmt.Errorf("book range ends before it starts: %s", part)
+		}
+		for i := first; i <= last; i++ {
+			codes = append(codes, allBooks[i].Code)
+		}
+	}
+	return codes, nil
+}
+
+// BookListLabel names a list of books for a plan title: "로마서",
+// "마태복음-요한복음" for books in canonical order without gaps, or
+// "로마서 외 2권".
+func BookListLabel(codes []string) string {
+	if len(codes) == 0 {
+		return ""
+	}
+	first := GetBookName(codes[0])
+	if len(codes) == 1 {
+		return first
+	}
+	for i := 1; i < len(codes); i++ {
+		if bookIndex(codes[i]) != bookIndex(codes[i-1])+1 {
+			return fmt.Sprintf("%s 외 %d권", first, len(codes)-1)
+		}
+	}
+	return first + "-" + GetBookName(codes[len(codes)-1])
+}
+
+// GeneratePlan spreads the chapters of spec's tracks over its reading days
+// and returns the readings of each calendar day from spec.Start; with
+// WeekdaysOnly the weekends are days without readings. Each track keeps
+// its order, and every day gets as close to an equal share of it as whole
+// chapters allow.
+func GeneratePlan(spec PlanSpec) ([][]Portion, error) {
+	if len(spec.Tracks) == 0 {
+		return nil, fmt.Errorf("plan has no books")
+	}
+	if spec.Days < 0 || spec.ChaptersPerDay < 0 {
+		return nil, fmt.Errorf("days and chapters per day must not be negative")
+	}
+	if spec.Days == 0 && spec.ChaptersPerDay == 0 {
+		return nil, fmt.Errorf("either days or chapters per day is required")
+	}
+
+	type weightedChapter struct {
+		ref    chapterRef
+		weight int
+	}
+	tracks := make([][]weightedChapter, len(spec.Tracks))
+	chapters := 0
+	byVerses := spec.VerseCounts != nil
+	for i, books := range spec.Tracks {
+		if len(books) == 0 {
+			return nil, fmt.Errorf("track %d has no books", i+1)
+		}
+		for _, code := range books {
+			book, ok := GetBookByCode(code)
+			if !ok {
+				return nil, fmt.Errorf("unknown book code %q", code)
+			}
+			for ch := 1; ch <= book.ChapterCount; ch++ {
+				weight := 1
+				if byVerses {
+					weight = spec.VerseCounts(code, ch)
+					byVerses = weight > 0
+				}
+				tracks[i] = append(tracks[i], weightedChapter{chapterRef{code, ch}, weight})
+			}
+		}
+		chapters += len(tracks[i])
+	}
+	if !byVerses {
+		for _, track := range tracks {
+			for j := range track {
+				track[j].weight = 1
+			}
+		}
+	}
+
+	days := spec.Days
+	if days == 0 {
+		days = (chapters + spec.ChaptersPerDay - 1) / spec.ChaptersPerDay
+	}
+	if days > maxPlanDays {
+		return nil, fmt.Errorf("plan would take %d days (at most %d)", days, maxPlanDays)
+	}
+
+	reading := make([][]chapterRef, days)
+	for _, track := range tracks {
+		total := 0
+		for _, c := range track {
+			total += c.weight
+		}
+		// A chapter goes to the day its midpoint falls on, which keeps
+		// the track in order and every day within a chapter of its share.
+		read := 0
+		for _, c := range track {
+			day := (2*read + c.weight) * days / (2 * total)
+			reading[day] = append(reading[day], c.ref)
+			read += c.weight
+		}
+	}
+
+	var schedule [][]Portion
+	date := spec.Start
+	for _, refs := range reading {
+		for spec.WeekdaysOnly && isWeekend(date) {
+			schedule = append(schedule, nil)
+			date = date.AddDate(0, 0, 1)
+		}
+		schedule = append(schedule, groupChapterRefs(refs))
+		date = date.AddDate(0, 0, 1)
+	}
+	return schedule, nil
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
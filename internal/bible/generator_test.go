@@ -0,0 +1,233 @@
+package bible
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countChapters returns the chapters read on each day of a schedule.
+func countChapters(schedule [][]Portion) []int {
+	counts := make([]int, len(schedule))
+	for i, day := range schedule {
+		for _, p := range day {
+			counts[i] += p.ChapterEnd - p.ChapterStart + 1
+		}
+	}
+	return counts
+}
+
+func TestParseBookList(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"mat-jhn", []string{"mat", "mrk", "luk", "jhn"}},
+		{"마-요", []string{"mat", "mrk", "luk", "jhn"}},
+		{"롬, 갈,엡", []string{"rom", "gal", "eph"}},
+		{"시", []string{"psa"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseBookList(tt.input)
+		if err != nil {
+			t.Errorf("ParseBookList(%q): %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseBookList(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+	for _, bad := range []string{"요-마", "없는책", "마-"} {
+		if _, err := ParseBookList(bad); err == nil {
+			t.Errorf("ParseBookList(%q): expected error", bad)
+		}
+	}
+}
+
+func TestBookListLabel(t *testing.T) {
+	tests := []struct {
+		codes []string
+		want  string
+	}{
+		{[]string{"rom"}, "로마서"},
+		{[]string{"mat", "mrk", "luk", "jhn"}, "마태복음-요한복음"},
+		{[]string{"rom", "gal", "eph"}, "로마서 외 2권"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := BookListLabel(tt.codes); got != tt.want {
+			t.Errorf("BookListLabel(%v) = %q, want %q", tt.codes, got, tt.want)
+		}
+	}
+	if got := (PlanSpec{Days: 40}).PaceLabel(); got != "40일" {
+		t.Errorf("PaceLabel = %q, want 40일", got)
+	}
+	if got := (PlanSpec{ChaptersPerDay: 3}).PaceLabel(); got != "하루 3장" {
+		t.Errorf("PaceLabel = %q, want 하루 3장", got)
+	}
+}
+
+func TestGeneratePlan_Days(t *testing.T) {
+	books, _ := ParseBookList("mat-jhn")
+	schedule, err := GeneratePlan(PlanSpec{Tracks: [][]string{books}, Days: 40})
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+	if len(schedule) != 40 {
+		t.Fatalf("expected 40 days, got %d", len(schedule))
+	}
+
+	// 89 chapters over 40 days: every day reads 2 or 3, in order, and
+	// nothing is skipped or repeated.
+	next := map[string]int{}
+	counts := countChapters(schedule)
+	for day, portions := range schedule {
+		if n := counts[day]; n < 2 || n > 3 {
+			t.Errorf("day %d: expected 2-3 chapters, got %d", day+1, n)
+		}
+		for _, p := range portions {
+			if next[p.BookCode] == 0 {
+				next[p.BookCode] = 1
+			}
+			if p.ChapterStart != next[p.BookCode] {
+				t.Errorf("day %d: %s starts at %d, want %d", day+1, p.BookCode, p.ChapterStart, next[p.BookCode])
+			}
+			next[p.BookCode] = p.ChapterEnd + 1
+		}
+	}
+	if schedule[0][0] != (Portion{"mat", 1, 2}) && schedule[0][0] != (Portion{"mat", 1, 3}) {
+		t.Errorf("unexpected first day %v", schedule[0])
+	}
+	if last := schedule[39]; last[len(last)-1].BookCode != "jhn" || last[len(last)-1].ChapterEnd != 21 {
+		t.Errorf("expected the plan to end with John 21, got %v", last)
+	}
+}
+
+func TestGeneratePlan_ChaptersPerDay(t *testing.T) {
+	schedule, err := GeneratePlan(PlanSpec{Tracks: [][]string{{"rom"}}, ChaptersPerDay: 5})
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+	// 16 chapters at 5 a day take 4 days.
+	if got := countChapters(schedule); !reflect.DeepEqual(got, []int{4, 4, 4, 4}) {
+		t.Errorf("expected 4 chapters on each of 4 days, got %v", got)
+	}
+}
+
+func TestGeneratePlan_Tracks(t *testing.T) {
+	preset, ok := GetPlanPreset("psalms-proverbs")
+	if !ok {
+		t.Fatal("expected psalms-proverbs preset")
+	}
+	schedule, err := GeneratePlan(preset.Spec)
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+	if len(schedule) != 31 {
+		t.Fatalf("expected 31 days, got %d", len(schedule))
+	}
+	for day, portions := range schedule {
+		last := portions[len(portions)-1]
+		if last.BookCode != "pro" || last.ChapterStart != day+1 || last.ChapterEnd != day+1 {
+			t.Errorf("day %d: expected Proverbs %d last, got %v", day+1, day+1, portions)
+		}
+		if portions[0].BookCode != "psa" {
+			t.Errorf("day %d: expected psalms first, got %v", day+1, portions)
+		}
+	}
+}
+
+func TestGeneratePlan_WeekdaysOnly(t *testing.T) {
+	// 2026-01-02 is a Friday.
+	start := time.Date(2026, time.January, 2, 0, 0, 0, 0, time.Local)
+	schedule, err := GeneratePlan(PlanSpec{Tracks: [][]string{{"php"}}, Days: 4, WeekdaysOnly: true, Start: start})
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+	// Fri, (Sat, Sun), Mon, Tue.
+	if got := countChapters(schedule); !reflect.DeepEqual(got, []int{1, 0, 0, 1, 1, 1}) {
+		t.Errorf("expected weekends free, got %v", got)
+	}
+}
+
+func TestGeneratePlan_ByVerses(t *testing.T) {
+	// Psalm 119 has 176 verses, more than a day's share when balanced by
+	// verses, so it fills a day alone.
+	verses := map[int]int{117: 2, 118: 29, 119: 176, 120: 7, 121: 8, 122: 9}
+	counts := func(book string, chapter int) int {
+		if n, ok := verses[chapter]; ok {
+			return n
+		}
+		return 10
+	}
+	schedule, err := GeneratePlan(PlanSpec{Tracks: [][]string{{"psa"}}, Days: 150, VerseCounts: counts})
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+	var day119 []Portion
+	for _, portions := range schedule {
+		for _, p := range portions {
+			if p.ChapterStart <= 119 && 119 <= p.ChapterEnd {
+				day119 = portions
+			}
+		}
+	}
+	if !reflect.DeepEqual(day119, []Portion{{"psa", 119, 119}}) {
+		t.Errorf("expected Psalm 119 alone on its day, got %v", day119)
+	}
+
+	// Missing counts fall back to chapters: 150 psalms, one a day.
+	schedule, err = GeneratePlan(PlanSpec{Tracks: [][]string{{"psa"}}, Days: 150, VerseCounts: func(string, int) int { return 0 }})
+	if err != nil {
+		t.Fatalf("GeneratePlan: %v", err)
+	}
+	for day, n := range countChapters(schedule) {
+		if n != 1 {
+			t.Fatalf("day %d: expected 1 psalm without verse counts, got %d", day+1, n)
+		}
+	}
+}
+
+func TestGeneratePlan_Errors(t *testing.T) {
+	tests := []struct {
+		spec PlanSpec
+		want string
+	}{
+		{PlanSpec{Days: 10}, "no books"},
+		{PlanSpec{Tracks: [][]string{{"gen"}}}, "either days or chapters"},
+		{PlanSpec{Tracks: [][]string{{"gen"}}, Days: -1}, "negative"},
+		{PlanSpec{Tracks: [][]string{{"xyz"}}, Days: 10}, "unknown book"},
+		{PlanSpec{Tracks: [][]string{{"gen"}, {}}, Days: 10}, "track 2"},
+		{PlanSpec{Tracks: [][]string{{"gen"}}, Days: 5000}, "at most"},
+	}
+	for _, tt := range tests {
+		_, err := GeneratePlan(tt.spec)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("GeneratePlan(%+v): expected error with %q, got %v", tt.spec, tt.want, err)
+		}
+	}
+}
+
+func TestChronologicalBooks(t *testing.T) {
+	books := ChronologicalBooks()
+	if len(books) != len(AllBooks()) {
+		t.Fatalf("expected %d books, got %d", len(AllBooks()), len(books))
+	}
+	seen := map[string]bool{}
+	for _, code := range books {
+		if _, ok := GetBookByCode(code); !ok || seen[code] {
+			t.Errorf("invalid or repeated book %q", code)
+		}
+		seen[code] = true
+	}
+	if books[1] != "job" {
+		t.Errorf("expected Job right after Genesis, got %q", books[1])
+	}
+
+	preset, _ := GetPlanPreset("chronological")
+	schedule, err := GeneratePlan(preset.Spec)
+	if err != nil || len(schedule) != 365 {
+		t.Fatalf("chronological preset: %d days, %v", len(schedule), err)
+	}
+}
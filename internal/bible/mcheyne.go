@@ -1,12 +1,15 @@
 package bible
 
-// McCheyneEntry represents one reading section for a day in the M'Cheyne plan.
-type McCheyneEntry struct {
+// Portion is a run of consecutive chapters of one book read on a plan day.
+type Portion struct {
 	BookCode     string
 	ChapterStart int
 	ChapterEnd   int
 }
 
+// McCheyneEntry represents one reading section for a day in the M'Cheyne plan.
+type McCheyneEntry = Portion
+
 // chapterRef is a single chapter reference used internally.
 type chapterRef struct {
 	bookCode string
@@ -7,6 +7,8 @@ import (
 	"strings"
 )
 
+// Reference is a passage within a single chapter. VerseStart is 0 for the
+// whole chapter; VerseEnd is 0 unless a verse range was given.
 type Reference struct {
 	BookCode   string
 	Chapter    int
@@ -14,84 +16,310 @@ type Reference struct {
 	VerseEnd   int
 }
 
-var referencePattern = regexp.MustCompile(`^(.+?)\s+(\d+)(?::(\d+)(?:-(\d+))?)?$`)
+// Location is a point in the Bible. Verse 0 stands for the whole chapter:
+// its first verse at the start of a range and its last verse at the end.
+type Location struct {
+	BookCode string
+	Chapter  int
+	Verse    int
+}
+
+// Range is an inclusive span of verses, possibly crossing chapters or books.
+type Range struct {
+	Start Location
+	End   Location
+}
+
+var (
+	refSpecPattern = regexp.MustCompile(`^(\d+)(?:\s*:\s*(\d+))?$`)
+
+	// Korean chapter/verse suffixes: "1장 3절" -> "1:3", "1장" -> "1".
+	koChapterVersePattern = regexp.MustCompile(`(\d+)\s*장\s*(\d+)`)
+	koSuffixPattern       = regexp.MustCompile(`(\d+)\s*[장절]`)
 
+	// OSIS-style dotted references: "Gen.1.1" -> "Gen 1:1", "Gen.1" -> "Gen 1".
+	osisVersePattern   = regexp.MustCompile(`([A-Za-z])\.(\d+)\.(\d+)`)
+	osisChapterPattern = regexp.MustCompile(`([A-Za-z])\.(\d+)`)
+)
+
+// ParseReference parses a reference to a single chapter, such as
+// "창세기 1", "창 1:3" or "창 1:3-5".
 func ParseReference(input string) (*Reference, error) {
+	ranges, err := ParseReferences(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(ranges) != 1 || !ranges[0].SingleChapter() {
+		return nil, fmt.Errorf("reference must be within one chapter: %s", input)
+	}
+	r := ranges[0]
+	ref := &Reference{
+		BookCode:   r.Start.BookCode,
+		Chapter:    r.Start.Chapter,
+		VerseStart: r.Start.Verse,
+	}
+	if r.End.Verse != r.Start.Verse {
+		ref.VerseEnd = r.End.Verse
+	}
+	return ref, nil
+}
+
+// ParseReferences parses one or more references into normalized ranges.
+// Accepted forms include:
+//
+//	창 1:3, 창 1:3-5, 창 1:26-2:3, 시 23-24, 창 50-출 2
+//	요 3:16,18 (verses of the same chapter), 롬 8:28; 12:1-2
+//	창세기 1장 3절, 창세기 1장
+//	John 3:16, 1 Cor 13, Gen.1.1 (English names, SBL and OSIS abbreviations)
+//
+// Book names are matched by FindBook. A reference without a book name
+// reuses the previous one's book.
+func ParseReferences(input string) ([]Range, error) {
 	trimmed := strings.TrimSpace(input)
 	if trimmed == "" {
 		return nil, fmt.Errorf("empty reference")
 	}
+	normalized := koChapterVersePattern.ReplaceAllString(trimmed, "$1:$2")
+	normalized = koSuffixPattern.ReplaceAllString(normalized, "$1")
+	normalized = osisVersePattern.ReplaceAllString(normalized, "$1 $2:$3")
+	normalized = osisChapterPattern.ReplaceAllString(normalized, "$1 $2")
+	normalized = strings.NewReplacer("–", "-", "~", "-").Replace(normalized)
 
-	matches := referencePattern.FindStringSubmatch(trimmed)
-	if matches == nil {
-		return nil, fmt.Errorf("invalid reference format: %s", input)
+	var ranges []Range
+	var p refParser
+	for _, group := range strings.Split(normalized, ";") {
+		// After ";" a bare number is a chapter again, even if the previous
+		// group ended on a verse.
+		p.chapter = 0
+		for _, part := range strings.Split(group, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				return nil, fmt.Errorf("invalid reference format: %s", input)
+			}
+			r, err := p.parseRange(part)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, r)
+		}
 	}
+	return ranges, nil
+}
 
-	bookPart := strings.TrimSpace(matches[1])
-	chapterStr := matches[2]
-	verseStartStr := matches[3]
-	verseEndStr := matches[4]
-
-	book := findBook(bookPart)
-	if book == nil {
-		return nil, fmt.Errorf("unknown book: %s", bookPart)
-	}
+// refParser carries context between the parts of a reference list.
+type refParser struct {
+	book    *BookInfo
+	chapter int // chapter of the previous part when it named a verse
+}
 
-	chapter, err := strconv.Atoi(chapterStr)
+func (p *refParser) parseRange(part string) (Range, error) {
+	startStr, endStr, hasEnd := strings.Cut(part, "-")
+	start, err := p.parseLocation(strings.TrimSpace(startStr), nil)
 	if err != nil {
-		return nil, fmt.Errorf("invalid chapter number: %s", chapterStr)
+		return Range{}, err
+	}
+	end := start
+	if hasEnd {
+		end, err = p.parseLocation(strings.TrimSpace(endStr), &start)
+		if err != nil {
+			return Range{}, err
+		}
 	}
 
-	if chapter < 1 || chapter > book.ChapterCount {
-		return nil, fmt.Errorf("chapter %d out of range for %s (max: %d)", chapter, book.NameKo, book.ChapterCount)
+	if end.Verse != 0 {
+		p.chapter = end.Chapter
+	} else {
+		p.chapter = 0
 	}
 
-	ref := &Reference{
-		BookCode:   book.Code,
-		Chapter:    chapter,
-		VerseStart: 0,
-		VerseEnd:   0,
+	r := Range{Start: start, End: end}
+	if compareLocations(start, end) > 0 {
+		if start.BookCode == end.BookCode && start.Chapter == end.Chapter {
+			return Range{}, fmt.Errorf("end verse %d cannot be less than start verse %d", end.Verse, start.Verse)
+		}
+		return Range{}, fmt.Errorf("range end comes before its start: %s", part)
 	}
+	return r, nil
+}
 
-	if verseStartStr != "" {
-		verseStart, err := strconv.Atoi(verseStartStr)
+// parseLocation parses "<book> c[:v]", "c[:v]" or, within a verse
+// context, a bare verse number. from is the start of the range when
+// parsing its end.
+func (p *refParser) parseLocation(s string, from *Location) (Location, error) {
+	spec := s
+	explicitBook := p.book == nil || !refSpecPattern.MatchString(s)
+	if explicitBook {
+		book, rest, err := splitBook(s)
 		if err != nil {
-			return nil, fmt.Errorf("invalid verse number: %s", verseStartStr)
+			return Location{}, err
 		}
-		if verseStart < 1 {
-			return nil, fmt.Errorf("verse number must be positive: %d", verseStart)
-		}
-		ref.VerseStart = verseStart
+		p.book = book
+		spec = rest
+	}
 
-		if verseEndStr != "" {
-			verseEnd, err := strconv.Atoi(verseEndStr)
-			if err != nil {
-				return nil, fmt.Errorf("invalid verse number: %s", verseEndStr)
-			}
-			if verseEnd < verseStart {
-				return nil, fmt.Errorf("end verse %d cannot be less than start verse %d", verseEnd, verseStart)
-			}
-			ref.VerseEnd = verseEnd
+	m := refSpecPattern.FindStringSubmatch(spec)
+	if m == nil {
+		return Location{}, fmt.Errorf("invalid reference format: %s", s)
+	}
+	n, _ := strconv.Atoi(m[1])
+
+	loc := Location{BookCode: p.book.Code, Chapter: n}
+	isVerse := true
+	switch {
+	case m[2] != "":
+		loc.Verse, _ = strconv.Atoi(m[2])
+	case !explicitBook && from != nil && from.Verse != 0:
+		// "3:16-18": the end is a verse of the start's chapter.
+		loc.Chapter, loc.Verse = from.Chapter, n
+	case !explicitBook && from == nil && p.chapter != 0:
+		// "3:16,18": a bare number after a verse continues that chapter.
+		loc.Chapter, loc.Verse = p.chapter, n
+	default:
+		isVerse = false
+	}
+
+	if loc.Chapter < 1 || loc.Chapter > p.book.ChapterCount {
+		return Location{}, fmt.Errorf("chapter %d out of range for %s (max: %d)", loc.Chapter, p.book.NameKo, p.book.ChapterCount)
+	}
+	if isVerse && loc.Verse < 1 {
+		return Location{}, fmt.Errorf("verse number must be positive: %d", loc.Verse)
+	}
+	return loc, nil
+}
+
+// splitBook splits "<book> <chapter...>" at the first digit boundary that
+// yields a known book, so names containing digits ("요한1서", "1 Cor") work.
+// If none does, the lookup error for the first candidate is returned.
+func splitBook(s string) (*BookInfo, string, error) {
+	var lookupErr error
+	for i := 1; i < len(s); i++ {
+		if !isDigit(s[i]) || isDigit(s[i-1]) {
+			continue
+		}
+		name := strings.TrimSpace(s[:i])
+		rest := strings.TrimSpace(s[i:])
+		if !refSpecPattern.MatchString(rest) {
+			continue
+		}
+		book, err := FindBook(name)
+		if err == nil {
+			return book, rest, nil
 		}
+		if lookupErr == nil {
+			lookupErr = err
+		}
+	}
+	if lookupErr != nil {
+		return nil, "", lookupErr
 	}
+	return nil, "", fmt.Errorf("invalid reference format: %s", s)
+}
 
-	return ref, nil
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
 }
 
-func findBook(name string) *BookInfo {
-	if book, ok := GetBookByName(name); ok {
-		return book
+// compareLocations orders two locations in canonical book order. A whole
+// chapter (verse 0) compares equal to any verse of that chapter.
+func compareLocations(a, b Location) int {
+	if ai, bi := bookIndex(a.BookCode), bookIndex(b.BookCode); ai != bi {
+		return ai - bi
+	}
+	if a.Chapter != b.Chapter {
+		return a.Chapter - b.Chapter
 	}
+	if a.Verse == 0 || b.Verse == 0 {
+		return 0
+	}
+	return a.Verse - b.Verse
+}
+
+// SingleChapter reports whether the range lies within one chapter.
+func (r Range) SingleChapter() bool {
+	return r.Start.BookCode == r.End.BookCode && r.Start.Chapter == r.End.Chapter
+}
 
-	if book, ok := GetBookByAbbrev(name); ok {
-		return book
+// Chapters lists every chapter the range touches, in order, with Verse 0.
+func (r Range) Chapters() []Location {
+	var chapters []Location
+	for bi := bookIndex(r.Start.BookCode); bi >= 0 && bi <= bookIndex(r.End.BookCode); bi++ {
+		book := allBooks[bi]
+		first, last := 1, book.ChapterCount
+		if book.Code == r.Start.BookCode {
+			first = r.Start.Chapter
+		}
+		if book.Code == r.End.BookCode {
+			last = r.End.Chapter
+		}
+		for c := first; c <= last; c++ {
+			chapters = append(chapters, Location{BookCode: book.Code, Chapter: c})
+		}
 	}
+	return chapters
+}
+
+// Contains reports whether a verse falls inside the range.
+func (r Range) Contains(bookCode string, chapter, verse int) bool {
+	loc := Location{BookCode: bookCode, Chapter: chapter, Verse: verse}
+	return compareLocations(r.Start, loc) <= 0 && compareLocations(loc, r.End) <= 0
+}
+
+// String formats the range with the Korean book name, e.g. "창세기 1:26-2:3".
+func (r Range) String() string {
+	return FormatReferences([]Range{r})
+}
+
+// FormatReferences formats ranges as a canonical Korean reference that
+// ParseReferences reads back to the same ranges. Repeated book names are
+// dropped and verses of the same chapter are joined with commas:
+// "요한복음 3:16, 18; 로마서 8:28; 12:1-2".
+func FormatReferences(ranges []Range) string {
+	var b strings.Builder
+	var prev *Range
+	for i := range ranges {
+		r := ranges[i]
+		sameBook := prev != nil && prev.End.BookCode == r.Start.BookCode
+		sameChapter := sameBook && prev.End.Verse != 0 && r.Start.Verse != 0 &&
+			prev.End.Chapter == r.Start.Chapter && r.SingleChapter()
 
-	if book, ok := GetBookByCode(strings.ToLower(name)); ok {
-		return book
+		switch {
+		case prev == nil:
+			b.WriteString(GetBookName(r.Start.BookCode) + " ")
+		case sameChapter:
+			b.WriteString(", ")
+		case sameBook:
+			b.WriteString("; ")
+		default:
+			b.WriteString("; " + GetBookName(r.Start.BookCode) + " ")
+		}
+
+		if sameChapter {
+			b.WriteString(strconv.Itoa(r.Start.Verse))
+		} else {
+			b.WriteString(formatLocation(r.Start))
+		}
+
+		if r.End != r.Start {
+			b.WriteString("-")
+			switch {
+			case r.End.BookCode != r.Start.BookCode:
+				b.WriteString(GetBookName(r.End.BookCode) + " " + formatLocation(r.End))
+			case r.SingleChapter() && r.Start.Verse != 0:
+				b.WriteString(strconv.Itoa(r.End.Verse))
+			default:
+				b.WriteString(formatLocation(r.End))
+			}
+		}
+		prev = &ranges[i]
 	}
+	return b.String()
+}
 
-	return nil
+func formatLocation(l Location) string {
+	if l.Verse == 0 {
+		return strconv.Itoa(l.Chapter)
+	}
+	return fmt.Sprintf("%d:%d", l.Chapter, l.Verse)
 }
 
 func GetBookName(code string) string {
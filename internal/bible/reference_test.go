@@ -1,6 +1,7 @@
 package bible
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -190,6 +191,242 @@ func TestParseReference_InvalidVerseRange(t *testing.T) {
 	}
 }
 
+func TestParseReferences(t *testing.T) {
+	loc := func(book string, chapter, verse int) Location {
+		return Location{BookCode: book, Chapter: chapter, Verse: verse}
+	}
+	tests := []struct {
+		input    string
+		expected []Range
+	}{
+		{"창 1", []Range{{loc("gen", 1, 0), loc("gen", 1, 0)}}},
+		{"창 1:26-2:3", []Range{{loc("gen", 1, 26), loc("gen", 2, 3)}}},
+		{"요 3:16,18", []Range{
+			{loc("jhn", 3, 16), loc("jhn", 3, 16)},
+			{loc("jhn", 3, 18), loc("jhn", 3, 18)},
+		}},
+		{"롬 8:28; 12:1-2", []Range{
+			{loc("rom", 8, 28), loc("rom", 8, 28)},
+			{loc("rom", 12, 1), loc("rom", 12, 2)},
+		}},
+		{"시 23-24", []Range{{loc("psa", 23, 0), loc("psa", 24, 0)}}},
+		{"시 23, 24", []Range{
+			{loc("psa", 23, 0), loc("psa", 23, 0)},
+			{loc("psa", 24, 0), loc("psa", 24, 0)},
+		}},
+		{"창세기 1장 3절", []Range{{loc("gen", 1, 3), loc("gen", 1, 3)}}},
+		{"창세기 1장 3-5절", []Range{{loc("gen", 1, 3), loc("gen", 1, 5)}}},
+		{"창세기 1장", []Range{{loc("gen", 1, 0), loc("gen", 1, 0)}}},
+		{"창1:1", []Range{{loc("gen", 1, 1), loc("gen", 1, 1)}}},
+		{"창 50-출 2", []Range{{loc("gen", 50, 0), loc("exo", 2, 0)}}},
+		{"창 1:1; 출 3:14", []Range{
+			{loc("gen", 1, 1), loc("gen", 1, 1)},
+			{loc("exo", 3, 14), loc("exo", 3, 14)},
+		}},
+		{"요일 4:8, 16", []Range{
+			{loc("1jn", 4, 8), loc("1jn", 4, 8)},
+			{loc("1jn", 4, 16), loc("1jn", 4, 16)},
+		}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseReferences(tt.input)
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", tt.input, err)
+			continue
+		}
+		if len(got) != len(tt.expected) {
+			t.Errorf("for %q expected %d ranges, got %d: %+v", tt.input, len(tt.expected), len(got), got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.expected[i] {
+				t.Errorf("for %q range %d: expected %+v, got %+v", tt.input, i, tt.expected[i], got[i])
+			}
+		}
+	}
+}
+
+func TestParseReferences_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"요 3:16,",
+		"창 2:3-1:26",
+		"출 1-창 2",
+		"롬 8:28; 없는책 1",
+		"창 1:0",
+		"요 3:16,0",
+	}
+
+	for _, input := range tests {
+		if _, err := ParseReferences(input); err == nil {
+			t.Errorf("expected error for %q, got nil", input)
+		}
+	}
+}
+
+func TestParseReferences_EnglishAndOSIS(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Range
+	}{
+		{"John 3:16", Range{Location{"jhn", 3, 16}, Location{"jhn", 3, 16}}},
+		{"Jn 3:16", Range{Location{"jhn", 3, 16}, Location{"jhn", 3, 16}}},
+		{"jn 3:16", Range{Location{"jhn", 3, 16}, Location{"jhn", 3, 16}}},
+		{"1 Cor 13", Range{Location{"1co", 13, 0}, Location{"1co", 13, 0}}},
+		{"1Co 13", Range{Location{"1co", 13, 0}, Location{"1co", 13, 0}}},
+		{"1 cor. 13:4-7", Range{Location{"1co", 13, 4}, Location{"1co", 13, 7}}},
+		{"Gen.1.1", Range{Location{"gen", 1, 1}, Location{"gen", 1, 1}}},
+		{"Gen.1", Range{Location{"gen", 1, 0}, Location{"gen", 1, 0}}},
+		{"Matt.5.3-Matt.5.12", Range{Location{"mat", 5, 3}, Location{"mat", 5, 12}}},
+		{"Song of Songs 2", Range{Location{"sng", 2, 0}, Location{"sng", 2, 0}}},
+		{"Phili 4:13", Range{Location{"php", 4, 13}, Location{"php", 4, 13}}},
+		{"revelation 21", Range{Location{"rev", 21, 0}, Location{"rev", 21, 0}}},
+		{"고린도전 13", Range{Location{"1co", 13, 0}, Location{"1co", 13, 0}}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseReferences(tt.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.input, err)
+			continue
+		}
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("%q: got %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFindBook_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"Jo", []string{"ambiguous book: Jo", "did you mean", "Joshua", "John", "Jonah"}},
+		{"Phi", []string{"ambiguous book", "Philippians", "Philemon"}},
+		{"요한", []string{"ambiguous book", "요한복음", "요한1서"}},
+		{"Genisis", []string{"unknown book: Genisis", "did you mean Genesis?"}},
+		{"Xyz", []string{"unknown book: Xyz"}},
+	}
+
+	for _, tt := range tests {
+		_, err := FindBook(tt.input)
+		if err == nil {
+			t.Errorf("%q: expected error, got nil", tt.input)
+			continue
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("%q: error %q does not contain %q", tt.input, err, want)
+			}
+		}
+	}
+
+	if _, err := ParseReferences("Jo 3:16"); err == nil || !strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("expected ParseReferences to report the ambiguity, got %v", err)
+	}
+}
+
+func TestBookKeys_Unique(t *testing.T) {
+	seen := map[string]string{}
+	for _, b := range AllBooks() {
+		names := append([]string{b.Code, b.NameKo, b.AbbrevKo, b.NameEn, b.OSIS}, b.AbbrevsEn...)
+		for _, name := range names {
+			key := bookKey(name)
+			if other, ok := seen[key]; ok && other != b.Code {
+				t.Errorf("%q names both %s and %s", name, other, b.Code)
+			}
+			seen[key] = b.Code
+		}
+	}
+}
+
+func TestParseReference_RejectsMultipleChapters(t *testing.T) {
+	for _, input := range []string{"창 1:26-2:3", "요 3:16,18", "시 23-24"} {
+		if _, err := ParseReference(input); err == nil {
+			t.Errorf("expected error for %q, got nil", input)
+		}
+	}
+}
+
+func TestFormatReferences_RoundTrip(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"창 1", "창세기 1"},
+		{"창 1:3", "창세기 1:3"},
+		{"창 1:3-5", "창세기 1:3-5"},
+		{"창 1:26-2:3", "창세기 1:26-2:3"},
+		{"요 3:16,18", "요한복음 3:16, 18"},
+		{"롬 8:28; 12:1-2", "로마서 8:28; 12:1-2"},
+		{"시 23-24", "시편 23-24"},
+		{"창 50-출 2", "창세기 50-출애굽기 2"},
+		{"창 1:1; 출 3:14", "창세기 1:1; 출애굽기 3:14"},
+		{"창세기 1장 3절", "창세기 1:3"},
+	}
+
+	for _, tt := range tests {
+		ranges, err := ParseReferences(tt.input)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", tt.input, err)
+		}
+		formatted := FormatReferences(ranges)
+		if formatted != tt.expected {
+			t.Errorf("for %q expected %q, got %q", tt.input, tt.expected, formatted)
+		}
+
+		again, err := ParseReferences(formatted)
+		if err != nil {
+			t.Fatalf("re-parse %q: %v", formatted, err)
+		}
+		if len(again) != len(ranges) {
+			t.Fatalf("round trip of %q changed range count", tt.input)
+		}
+		for i := range again {
+			if again[i] != ranges[i] {
+				t.Errorf("round trip of %q: expected %+v, got %+v", tt.input, ranges[i], again[i])
+			}
+		}
+	}
+}
+
+func TestRange_ContainsAndChapters(t *testing.T) {
+	ranges, err := ParseReferences("창 1:26-2:3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := ranges[0]
+
+	tests := []struct {
+		chapter, verse int
+		expected       bool
+	}{
+		{1, 25, false},
+		{1, 26, true},
+		{1, 31, true},
+		{2, 3, true},
+		{2, 4, false},
+	}
+	for _, tt := range tests {
+		if got := r.Contains("gen", tt.chapter, tt.verse); got != tt.expected {
+			t.Errorf("Contains(gen %d:%d) = %v, expected %v", tt.chapter, tt.verse, got, tt.expected)
+		}
+	}
+	if r.Contains("exo", 1, 1) {
+		t.Error("expected exo 1:1 outside the range")
+	}
+
+	ranges, _ = ParseReferences("창 49-출 2")
+	chapters := ranges[0].Chapters()
+	if len(chapters) != 4 {
+		t.Fatalf("expected 4 chapters, got %d: %+v", len(chapters), chapters)
+	}
+	if chapters[1] != (Location{BookCode: "gen", Chapter: 50}) || chapters[2] != (Location{BookCode: "exo", Chapter: 1}) {
+		t.Errorf("unexpected chapters across books: %+v", chapters)
+	}
+}
+
 func TestAllBooks(t *testing.T) {
 	books := AllBooks()
 	if len(books) != 66 {
@@ -350,3 +587,26 @@ func TestAllBooksData(t *testing.T) {
 		}
 	}
 }
+
+func TestGetVersionByCode(t *testing.T) {
+	v, ok := GetVersionByCode("HAN")
+	if !ok {
+		t.Fatal("expected to find version 'HAN'")
+	}
+	if v.Name != "개역한글" {
+		t.Errorf("expected Name '개역한글', got '%s'", v.Name)
+	}
+
+	v, ok = GetVersionByCode("gae")
+	if !ok {
+		t.Fatal("expected to find version 'gae' (case-insensitive)")
+	}
+	if v.Code != "GAE" {
+		t.Errorf("expected Code 'GAE', got '%s'", v.Code)
+	}
+
+	_, ok = GetVersionByCode("XYZ")
+	if ok {
+		t.Error("expected not to find version 'XYZ'")
+	}
+}
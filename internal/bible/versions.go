@@ -0,0 +1,38 @@
+package bible
+
+import "strings"
+
+// VersionInfo describes a Bible translation offered by bskorea.or.kr
+type VersionInfo struct {
+	Code string // site version code: "GAE", "HAN", etc.
+	Name string // display name: "개역개정"
+	Lang string // "ko" or "en"
+}
+
+var allVersions = []VersionInfo{
+	{Code: "GAE", Name: "개역개정", Lang: "ko"},
+	{Code: "HAN", Name: "개역한글", Lang: "ko"},
+	{Code: "SAE", Name: "표준새번역", Lang: "ko"},
+	{Code: "SAENEW", Name: "새번역", Lang: "ko"},
+	{Code: "COG", Name: "공동번역", Lang: "ko"},
+	{Code: "COGNEW", Name: "공동번역개정판", Lang: "ko"},
+	{Code: "CEV", Name: "CEV", Lang: "en"},
+}
+
+// AllVersions returns a copy of all known versions
+func AllVersions() []VersionInfo {
+	result := make([]VersionInfo, len(allVersions))
+	copy(result, allVersions)
+	return result
+}
+
+// GetVersionByCode looks up a version by its code (case-insensitive)
+func GetVersionByCode(code string) (*VersionInfo, bool) {
+	upperCode := strings.ToUpper(code)
+	for i := range allVersions {
+		if allVersions[i].Code == upperCode {
+			return &allVersions[i], true
+		}
+	}
+	return nil, false
+}
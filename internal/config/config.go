@@ -2,22 +2,47 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
 
 	"github.com/yangsijun/bible-tui/internal/db"
 )
 
+// Dir returns the directory holding the database and other user files.
+func Dir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("get config dir: %w", err)
+	}
+	return filepath.Join(configDir, "bible-tui"), nil
+}
+
+// PlanDir returns the directory of imported plan files, which the TUI
+// offers when creating a plan.
+func PlanDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "plans"), nil
+}
+
 type Config struct {
 	ThemeName   string
 	FontSize    int
 	VersionCode string
+	// AutoCompletePlans checks off plan readings once their chapters have
+	// been read to the end in the reader.
+	AutoCompletePlans bool
 }
 
 func LoadConfig(database *db.DB) (*Config, error) {
 	cfg := &Config{
-		ThemeName:   "dark",
-		FontSize:    2,
-		VersionCode: "GAE",
+		ThemeName:         "dark",
+		FontSize:          2,
+		VersionCode:       "GAE",
+		AutoCompletePlans: true,
 	}
 
 	themeName, err := database.GetSetting("theme_name")
@@ -48,6 +73,18 @@ func LoadConfig(database *db.DB) (*Config, error) {
 		cfg.VersionCode = versionCode
 	}
 
+	autoComplete, err := database.GetSetting("auto_complete_plans")
+	if err != nil {
+		return nil, fmt.Errorf("get auto_complete_plans setting: %w", err)
+	}
+	if autoComplete != "" {
+		enabled, err := strconv.ParseBool(autoComplete)
+		if err != nil {
+			return nil, fmt.Errorf("parse auto_complete_plans: %w", err)
+		}
+		cfg.AutoCompletePlans = enabled
+	}
+
 	return cfg, nil
 }
 
@@ -64,5 +101,9 @@ func SaveConfig(database *db.DB, cfg *Config) error {
 		return fmt.Errorf("set default_version: %w", err)
 	}
 
+	if err := database.SetSetting("auto_complete_plans", strconv.FormatBool(cfg.AutoCompletePlans)); err != nil {
+		return fmt.Errorf("set auto_complete_plans: %w", err)
+	}
+
 	return nil
 }
@@ -35,6 +35,9 @@ func TestLoadConfig_Defaults(t *testing.T) {
 	if cfg.VersionCode != "GAE" {
 		t.Errorf("VersionCode: got %q, want %q", cfg.VersionCode, "GAE")
 	}
+	if !cfg.AutoCompletePlans {
+		t.Error("AutoCompletePlans: got false, want true")
+	}
 }
 
 func TestSaveAndLoadConfig(t *testing.T) {
@@ -76,6 +79,9 @@ func TestSaveAndLoadConfig(t *testing.T) {
 	if loadedCfg.VersionCode != originalCfg.VersionCode {
 		t.Errorf("VersionCode: got %q, want %q", loadedCfg.VersionCode, originalCfg.VersionCode)
 	}
+	if loadedCfg.AutoCompletePlans {
+		t.Error("AutoCompletePlans: got true, want false")
+	}
 }
 
 func TestConfigPersistence(t *testing.T) {
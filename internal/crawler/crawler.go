@@ -22,6 +22,7 @@ type Crawler struct {
 	baseURL     string
 	versionCode string
 	versionName string
+	versionLang string
 	onProgress  func(bookName string, chapter, totalChapters int)
 }
 
@@ -58,29 +59,46 @@ func New(database *db.DB, opts ...Option) *Crawler {
 		limiter:     rate.NewLimiter(rate.Limit(0.5), 1),
 		baseURL:     "https://www.bskorea.or.kr/bible/korbibReadpage.php",
 		versionCode: "GAE",
-		versionName: "개역개정",
+		versionLang: "ko",
 	}
 	for _, opt := range opts {
 		opt(c)
 	}
+	// Fill in the display name from the known version list unless set explicitly.
+	if info, ok := bible.GetVersionByCode(c.versionCode); ok {
+		if c.versionName == "" {
+			c.versionName = info.Name
+		}
+		c.versionLang = info.Lang
+	}
+	if c.versionName == "" {
+		c.versionName = c.versionCode
+	}
 	return c
 }
 
-func (c *Crawler) CrawlAll(ctx context.Context) error {
-	versionID, err := c.db.InsertVersion(c.versionCode, c.versionName, "ko")
+// ensureBooks inserts the version row and all 66 book rows if missing.
+func (c *Crawler) ensureBooks() error {
+	versionID, err := c.db.InsertVersion(c.versionCode, c.versionName, c.versionLang)
 	if err != nil {
 		return fmt.Errorf("insert version: %w", err)
 	}
 
-	books := bible.AllBooks()
-	for i, b := range books {
+	for i, b := range bible.AllBooks() {
 		_, err := c.db.InsertBook(versionID, b.Code, b.NameKo, b.AbbrevKo, b.Testament, b.ChapterCount, i)
 		if err != nil {
 			return fmt.Errorf("insert book %s: %w", b.Code, err)
 		}
 	}
+	return nil
+}
+
+func (c *Crawler) CrawlAll(ctx context.Context) error {
+	if err := c.ensureBooks(); err != nil {
+		return err
+	}
 
-	for _, b := range books {
+	for _, b := range bible.AllBooks() {
 		if err := c.crawlBookChapters(ctx, b.Code, b.NameKo, b.ChapterCount); err != nil {
 			return err
 		}
@@ -94,6 +112,9 @@ func (c *Crawler) CrawlBook(ctx context.Context, bookCode string) error {
 	if !ok {
 		return fmt.Errorf("unknown book code: %s", bookCode)
 	}
+	if err := c.ensureBooks(); err != nil {
+		return err
+	}
 	return c.crawlBookChapters(ctx, info.Code, info.NameKo, info.ChapterCount)
 }
 
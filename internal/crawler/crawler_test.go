@@ -298,3 +298,48 @@ func TestWithOptions(t *testing.T) {
 		t.Error("progress callback not invoked")
 	}
 }
+
+func TestNew_VersionNameFromCode(t *testing.T) {
+	d := setupTestDB(t)
+
+	c := New(d, WithVersionCode("HAN"))
+	if c.versionName != "개역한글" {
+		t.Errorf("versionName: expected '개역한글', got %q", c.versionName)
+	}
+
+	c = New(d, WithVersionCode("XYZ"))
+	if c.versionName != "XYZ" {
+		t.Errorf("versionName for unknown code: expected 'XYZ', got %q", c.versionName)
+	}
+}
+
+func TestCrawlBook_NewVersion(t *testing.T) {
+	d := setupTestDB(t)
+	srv := mockServer(t, loadFixture(t))
+
+	c := New(d,
+		WithBaseURL(srv.URL),
+		WithVersionCode("HAN"),
+		WithRateLimit(1000),
+	)
+
+	if err := c.CrawlBook(context.Background(), "oba"); err != nil {
+		t.Fatalf("CrawlBook(oba): %v", err)
+	}
+
+	v, err := d.GetVersionByCode("HAN")
+	if err != nil {
+		t.Fatalf("GetVersionByCode: %v", err)
+	}
+	if v.Name != "개역한글" {
+		t.Errorf("expected version name '개역한글', got %q", v.Name)
+	}
+
+	verses, err := d.GetVerses("HAN", "oba", 1)
+	if err != nil {
+		t.Fatalf("GetVerses: %v", err)
+	}
+	if len(verses) == 0 {
+		t.Error("expected verses for HAN oba 1 without pre-seeded books")
+	}
+}
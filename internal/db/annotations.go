@@ -0,0 +1,60 @@
+package db
+
+import "fmt"
+
+// VerseAnnotation is what the reading view shows for one verse: the
+// highlight covering it, if any, and whether a bookmark covers it.
+type VerseAnnotation struct {
+	HighlightID    int64  // 0 when not highlighted
+	HighlightColor string // "" when not highlighted
+	Bookmarked     bool
+}
+
+// ChapterAnnotations returns the annotations of every annotated verse of a
+// chapter, keyed by verse number, in one query. Ranges starting or ending
+// in another chapter count for the verses they cover here; where
+// highlights overlap the newest one wins, as in GetHighlightColor.
+func (d *DB) ChapterAnnotations(versionCode, bookCode string, chapter int) (map[int]VerseAnnotation, error) {
+	rows, err := d.conn.Query(
+		`SELECT v.verse_num, a.kind, a.id, a.color
+		 FROM verses v
+		 JOIN books b ON b.id = v.book_id
+		 JOIN versions ver ON ver.id = b.version_id
+		 JOIN (
+		   SELECT 'highlight' AS kind, id, color, version_code, book_code,
+		          chapter, verse_num, end_chapter, end_verse
+		   FROM highlights
+		   UNION ALL
+		   SELECT 'bookmark', id, '', version_code, book_code,
+		          chapter, verse_num, end_chapter, end_verse
+		   FROM bookmarks
+		 ) a ON a.version_code = ver.code AND a.book_code = b.code
+		    AND (a.chapter, a.verse_num) <= (v.chapter, v.verse_num)
+		    AND (a.end_chapter, a.end_verse) >= (v.chapter, v.verse_num)
+		 WHERE ver.code = ? AND b.code = ? AND v.chapter = ?
+		 ORDER BY a.id`,
+		versionCode, bookCode, chapter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("chapter annotations: %w", err)
+	}
+	defer rows.Close()
+
+	annotations := make(map[int]VerseAnnotation)
+	for rows.Next() {
+		var verseNum int
+		var kind, color string
+		var id int64
+		if err := rows.Scan(&verseNum, &kind, &id, &color); err != nil {
+			return nil, fmt.Errorf("scan chapter annotation: %w", err)
+		}
+		a := annotations[verseNum]
+		if kind == "bookmark" {
+			a.Bookmarked = true
+		} else {
+			a.HighlightID, a.HighlightColor = id, color
+		}
+		annotations[verseNum] = a
+	}
+	return annotations, rows.Err()
+}
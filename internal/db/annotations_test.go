@@ -0,0 +1,64 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChapterAnnotations(t *testing.T) {
+	db, gen11 := setupBookmarkDB(t)
+	ids := addRangeVerses(t, db, gen11)
+
+	// 1:3-2:1 crosses into chapter 2; 1:2 is highlighted twice.
+	if err := db.AddHighlightRange(ids["창1:2"], ids["창1:3"], "green"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AddHighlightRange(ids["창1:3"], ids["창2:1"], "pink"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.AddBookmarkRange(ids["창1:1"], ids["창1:2"], ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.AddBookmark(ids["출1:1"], ""); err != nil {
+		t.Fatal(err)
+	}
+	highlights, err := db.ListHighlights(10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hlID := map[string]int64{}
+	for _, h := range highlights {
+		hlID[h.Color] = h.ID
+	}
+
+	got, err := db.ChapterAnnotations("GAE", "gen", 1)
+	if err != nil {
+		t.Fatalf("ChapterAnnotations: %v", err)
+	}
+	want := map[int]VerseAnnotation{
+		1: {Bookmarked: true},
+		2: {HighlightID: hlID["green"], HighlightColor: "green", Bookmarked: true},
+		3: {HighlightID: hlID["pink"], HighlightColor: "pink"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("chapter 1:\n got %+v\nwant %+v", got, want)
+	}
+
+	got, err = db.ChapterAnnotations("GAE", "gen", 2)
+	if err != nil {
+		t.Fatalf("ChapterAnnotations: %v", err)
+	}
+	if want := map[int]VerseAnnotation{1: {HighlightID: hlID["pink"], HighlightColor: "pink"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("chapter 2: got %+v, want %+v", got, want)
+	}
+
+	if err := db.UpdateHighlightColor(hlID["pink"], "blue"); err != nil {
+		t.Fatalf("UpdateHighlightColor: %v", err)
+	}
+	if color, _ := db.GetHighlightColor(ids["창2:1"]); color != "blue" {
+		t.Errorf("expected the whole range recolored, got %q", color)
+	}
+	if err := db.UpdateHighlightColor(999, "blue"); err == nil {
+		t.Error("expected error for a missing highlight")
+	}
+}
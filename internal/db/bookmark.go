@@ -1,60 +1,180 @@
 package db
 
 import (
+	"database/sql"
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
 )
 
+// BookmarkWithVerse is a bookmark with its book and text. A bookmark
+// covers the verses from Chapter:VerseNum to EndChapter:EndVerse of one
+// book; VerseID is the first of them and VerseText joins them all.
 type BookmarkWithVerse struct {
 	Bookmark
-	VerseText string
-	BookName  string
-	BookCode  string
-	Chapter   int
-	VerseNum  int
+	VerseText   string   `json:"text"`
+	VersionCode string   `json:"version"`
+	BookName    string   `json:"book_name"`
+	BookCode    string   `json:"book_code"`
+	Chapter     int      `json:"chapter"`
+	VerseNum    int      `json:"verse"`
+	EndChapter  int      `json:"end_chapter"`
+	EndVerse    int      `json:"end_verse"`
+	Tags        []string `json:"tags"` // sorted by name; never nil
+}
+
+// Range returns the verses the bookmark covers.
+func (bm BookmarkWithVerse) Range() bible.Range {
+	return bible.Range{
+		Start: bible.Location{BookCode: bm.BookCode, Chapter: bm.Chapter, Verse: bm.VerseNum},
+		End:   bible.Location{BookCode: bm.BookCode, Chapter: bm.EndChapter, Verse: bm.EndVerse},
+	}
 }
 
 // AddBookmark adds a bookmark for a verse with an optional note.
 // Returns the bookmark ID.
 func (d *DB) AddBookmark(verseID int64, note string) (int64, error) {
+	return d.AddBookmarkRange(verseID, verseID, note)
+}
+
+// AddBookmarkRange adds one bookmark covering the verses from startVerseID
+// to endVerseID, which may be in different chapters of the same book.
+// Returns the bookmark ID.
+func (d *DB) AddBookmarkRange(startVerseID, endVerseID int64, note string) (int64, error) {
+	ids, err := d.AddBookmarkRanges([][2]int64{{startVerseID, endVerseID}}, note)
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// AddBookmarkRanges adds a bookmark for each span of start and end verse
+// IDs, all with the same note and tags. Either every bookmark is added or,
+// on an error, none is. Returns the bookmark IDs in the order of spans.
+func (d *DB) AddBookmarkRanges(spans [][2]int64, note string, tags ...string) ([]int64, error) {
+	resolved := make([]annotationSpan, len(spans))
+	for i, span := range spans {
+		sp, err := d.resolveSpan(span[0], span[1])
+		if err != nil {
+			return nil, fmt.Errorf("add bookmark: %w", err)
+		}
+		resolved[i] = sp
+	}
 	var notePtr interface{}
 	if note != "" {
 		notePtr = note
 	}
-	res, err := d.conn.Exec(
-		"INSERT INTO bookmarks (verse_id, note) VALUES (?, ?)",
-		verseID, notePtr,
-	)
+
+	tx, err := d.conn.Begin()
 	if err != nil {
-		return 0, fmt.Errorf("add bookmark: %w", err)
+		return nil, fmt.Errorf("begin tx: %w", err)
 	}
-	id, err := res.LastInsertId()
-	if err != nil {
-		return 0, fmt.Errorf("add bookmark last id: %w", err)
+	defer tx.Rollback()
+
+	ids := make([]int64, len(resolved))
+	for i, sp := range resolved {
+		res, err := tx.Exec(
+			`INSERT INTO bookmarks (version_code, book_code, chapter, verse_num, end_chapter, end_verse, note)
+			 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			sp.version, sp.book, sp.chapter, sp.verse, sp.endChapter, sp.endVerse, notePtr,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("add bookmark: %w", err)
+		}
+		if ids[i], err = res.LastInsertId(); err != nil {
+			return nil, fmt.Errorf("add bookmark last id: %w", err)
+		}
+		if err := tagBookmark(tx, ids[i], tags); err != nil {
+			return nil, err
+		}
 	}
-	return id, nil
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit: %w", err)
+	}
+	return ids, nil
 }
 
-// RemoveBookmark removes a bookmark by its ID.
+// RemoveBookmark removes a bookmark by its ID, together with its tag
+// links. Tags no bookmark uses any more are dropped.
 func (d *DB) RemoveBookmark(id int64) error {
-	_, err := d.conn.Exec("DELETE FROM bookmarks WHERE id = ?", id)
+	tx, err := d.conn.Begin()
 	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range []string{
+		"DELETE FROM bookmark_tags WHERE bookmark_id = ?",
+		"DELETE FROM bookmarks WHERE id = ?",
+	} {
+		if _, err := tx.Exec(stmt, id); err != nil {
+			return fmt.Errorf("remove bookmark: %w", err)
+		}
+	}
+	if err := deleteUnusedTags(tx); err != nil {
 		return fmt.Errorf("remove bookmark: %w", err)
 	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
 	return nil
 }
 
+// tagSeparator joins tag names in the bookmarkColumns subquery. NormalizeTag
+// drops control characters, so it cannot occur in a tag name.
+const tagSeparator = "\x1f"
+
+// bookmarkColumns are the BookmarkWithVerse columns scanned by scanBookmark.
+const bookmarkColumns = `a.id, COALESCE(v.id, 0), COALESCE(a.note, ''), a.created_at,
+		        ` + annotationRangeText + `, a.version_code, b.name_ko, a.book_code,
+		        a.chapter, a.verse_num, a.end_chapter, a.end_verse,
+		        COALESCE((SELECT group_concat(t.name, char(31)) FROM bookmark_tags bt
+		                  JOIN tags t ON t.id = bt.tag_id WHERE bt.bookmark_id = a.id), '')`
+
+func scanBookmark(row interface{ Scan(...any) error }) (BookmarkWithVerse, error) {
+	var bm BookmarkWithVerse
+	var tags string
+	err := row.Scan(
+		&bm.ID, &bm.VerseID, &bm.Note, &bm.CreatedAt,
+		&bm.VerseText, &bm.VersionCode, &bm.BookName, &bm.BookCode,
+		&bm.Chapter, &bm.VerseNum, &bm.EndChapter, &bm.EndVerse,
+		&tags,
+	)
+	bm.Tags = []string{}
+	if tags != "" {
+		bm.Tags = strings.Split(tags, tagSeparator)
+		sort.Strings(bm.Tags)
+	}
+	return bm, err
+}
+
 // ListBookmarks returns bookmarks ordered by newest first.
-// Includes verse text and book info via joins.
+// Includes verse text and book info via joins. VerseID and VerseText are
+// empty while the bookmarked chapter is not crawled (e.g. after a reset).
 func (d *DB) ListBookmarks(limit, offset int) ([]BookmarkWithVerse, error) {
+	return d.listBookmarks("", nil, limit, offset)
+}
+
+// ListBookmarksByTag is ListBookmarks restricted to bookmarks carrying tag.
+func (d *DB) ListBookmarksByTag(tag string, limit, offset int) ([]BookmarkWithVerse, error) {
+	return d.listBookmarks(
+		`WHERE a.id IN (SELECT bt.bookmark_id FROM bookmark_tags bt
+		                JOIN tags t ON t.id = bt.tag_id WHERE t.name = ?)`,
+		[]interface{}{NormalizeTag(tag)}, limit, offset,
+	)
+}
+
+func (d *DB) listBookmarks(where string, args []interface{}, limit, offset int) ([]BookmarkWithVerse, error) {
 	rows, err := d.conn.Query(
-		`SELECT bm.id, bm.verse_id, COALESCE(bm.note, ''), bm.created_at,
-		        v.text, b.name_ko, b.code, v.chapter, v.verse_num
-		 FROM bookmarks bm
-		 JOIN verses v ON v.id = bm.verse_id
-		 JOIN books b ON b.id = v.book_id
-		 ORDER BY bm.created_at DESC
+		`SELECT `+bookmarkColumns+`
+		 FROM bookmarks a
+		 `+annotationVerseJoin+`
+		 `+where+`
+		 ORDER BY a.created_at DESC, a.id DESC
 		 LIMIT ? OFFSET ?`,
-		limit, offset,
+		append(args, limit, offset)...,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("list bookmarks: %w", err)
@@ -63,11 +183,8 @@ func (d *DB) ListBookmarks(limit, offset int) ([]BookmarkWithVerse, error) {
 
 	var bookmarks []BookmarkWithVerse
 	for rows.Next() {
-		var bm BookmarkWithVerse
-		if err := rows.Scan(
-			&bm.ID, &bm.VerseID, &bm.Note, &bm.CreatedAt,
-			&bm.VerseText, &bm.BookName, &bm.BookCode, &bm.Chapter, &bm.VerseNum,
-		); err != nil {
+		bm, err := scanBookmark(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scan bookmark: %w", err)
 		}
 		bookmarks = append(bookmarks, bm)
@@ -75,11 +192,47 @@ func (d *DB) ListBookmarks(limit, offset int) ([]BookmarkWithVerse, error) {
 	return bookmarks, rows.Err()
 }
 
-// IsBookmarked checks if a verse is bookmarked.
+// GetBookmark returns a bookmark by its ID, or nil if there is none.
+func (d *DB) GetBookmark(id int64) (*BookmarkWithVerse, error) {
+	bm, err := scanBookmark(d.conn.QueryRow(
+		`SELECT `+bookmarkColumns+`
+		 FROM bookmarks a
+		 `+annotationVerseJoin+`
+		 WHERE a.id = ?`,
+		id,
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get bookmark: %w", err)
+	}
+	return &bm, nil
+}
+
+// UpdateBookmarkNote replaces the note of a bookmark; an empty note
+// clears it.
+func (d *DB) UpdateBookmarkNote(id int64, note string) error {
+	var notePtr interface{}
+	if note != "" {
+		notePtr = note
+	}
+	res, err := d.conn.Exec("UPDATE bookmarks SET note = ? WHERE id = ?", notePtr, id)
+	if err != nil {
+		return fmt.Errorf("update bookmark note: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("update bookmark note: bookmark %d not found", id)
+	}
+	return nil
+}
+
+// IsBookmarked checks if a verse is bookmarked, on its own or as part of
+// a range.
 func (d *DB) IsBookmarked(verseID int64) (bool, error) {
 	var count int
 	err := d.conn.QueryRow(
-		"SELECT COUNT(*) FROM bookmarks WHERE verse_id = ?",
+		`SELECT COUNT(*) FROM bookmarks a WHERE `+annotationCoversVerse,
 		verseID,
 	).Scan(&count)
 	if err != nil {
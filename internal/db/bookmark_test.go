@@ -172,3 +172,147 @@ func TestBookmarkWithNote(t *testing.T) {
 		t.Errorf("expected note %q, got %q", note, bm.Note)
 	}
 }
+
+func TestGetAndUpdateBookmarkNote(t *testing.T) {
+	db, verseID := setupBookmarkDB(t)
+
+	id, err := db.AddBookmark(verseID, "처음")
+	if err != nil {
+		t.Fatalf("AddBookmark: %v", err)
+	}
+
+	if err := db.UpdateBookmarkNote(id, "고친 메모\n둘째 줄"); err != nil {
+		t.Fatalf("UpdateBookmarkNote: %v", err)
+	}
+	bm, err := db.GetBookmark(id)
+	if err != nil {
+		t.Fatalf("GetBookmark: %v", err)
+	}
+	if bm == nil || bm.Note != "고친 메모\n둘째 줄" || bm.VerseID != verseID || bm.BookCode != "gen" {
+		t.Errorf("unexpected bookmark: %+v", bm)
+	}
+
+	if err := db.UpdateBookmarkNote(id, ""); err != nil {
+		t.Fatalf("UpdateBookmarkNote: %v", err)
+	}
+	if bm, _ := db.GetBookmark(id); bm.Note != "" {
+		t.Errorf("expected note cleared, got %q", bm.Note)
+	}
+
+	if err := db.UpdateBookmarkNote(id+100, "없음"); err == nil {
+		t.Error("expected error updating a missing bookmark")
+	}
+	if bm, err := db.GetBookmark(id + 100); err != nil || bm != nil {
+		t.Errorf("expected nil for a missing bookmark, got %+v, %v", bm, err)
+	}
+}
+
+// addRangeVerses adds 창 1:2, 1:3 and 2:1 and 출 1:1 next to the 창 1:1 of
+// setupBookmarkDB or setupHighlightDB, keyed by reference.
+func addRangeVerses(t *testing.T, db *DB, gen11 int64) map[string]int64 {
+	t.Helper()
+	var bookID, versionID int64
+	if err := db.conn.QueryRow("SELECT v.book_id, b.version_id FROM verses v JOIN books b ON b.id = v.book_id WHERE v.id = ?", gen11).Scan(&bookID, &versionID); err != nil {
+		t.Fatalf("lookup book: %v", err)
+	}
+	exodus, err := db.InsertBook(versionID, "exo", "출애굽기", "출", "old", 40, 1)
+	if err != nil {
+		t.Fatalf("InsertBook: %v", err)
+	}
+	ids := map[string]int64{"창1:1": gen11}
+	for _, v := range []struct {
+		ref         string
+		book        int64
+		chapter, vn int
+		text        string
+	}{
+		{"창1:2", bookID, 1, 2, "땅이 혼돈하고"},
+		{"창1:3", bookID, 1, 3, "빛이 있으라"},
+		{"창2:1", bookID, 2, 1, "천지와 만물이 다 이루어지니라"},
+		{"출1:1", exodus, 1, 1, "야곱과 함께"},
+	} {
+		id, err := db.InsertVerse(v.book, v.chapter, v.vn, v.text, "", false)
+		if err != nil {
+			t.Fatalf("InsertVerse %s: %v", v.ref, err)
+		}
+		ids[v.ref] = id
+	}
+	return ids
+}
+
+func TestAddBookmarkRange(t *testing.T) {
+	db, gen11 := setupBookmarkDB(t)
+	ids := addRangeVerses(t, db, gen11)
+
+	// Reversed ends are swapped; the range may cross chapters.
+	id, err := db.AddBookmarkRange(ids["창2:1"], ids["창1:2"], "범위")
+	if err != nil {
+		t.Fatalf("AddBookmarkRange: %v", err)
+	}
+	bm, err := db.GetBookmark(id)
+	if err != nil {
+		t.Fatalf("GetBookmark: %v", err)
+	}
+	if bm.Chapter != 1 || bm.VerseNum != 2 || bm.EndChapter != 2 || bm.EndVerse != 1 || bm.VerseID != ids["창1:2"] {
+		t.Errorf("unexpected range: %+v", bm)
+	}
+	if want := "땅이 혼돈하고 빛이 있으라 천지와 만물이 다 이루어지니라"; bm.VerseText != want {
+		t.Errorf("expected range text %q, got %q", want, bm.VerseText)
+	}
+	if got := bm.Range().String(); got != "창세기 1:2-2:1" {
+		t.Errorf("expected 창세기 1:2-2:1, got %q", got)
+	}
+
+	for ref, want := range map[string]bool{"창1:1": false, "창1:3": true, "창2:1": true, "출1:1": false} {
+		got, err := db.IsBookmarked(ids[ref])
+		if err != nil {
+			t.Fatalf("IsBookmarked: %v", err)
+		}
+		if got != want {
+			t.Errorf("IsBookmarked(%s) = %v, want %v", ref, got, want)
+		}
+	}
+
+	if _, err := db.AddBookmarkRange(ids["창1:1"], ids["출1:1"], ""); err == nil {
+		t.Error("expected error for a range across books")
+	}
+	if _, err := db.AddBookmarkRange(ids["창1:1"], 9999, ""); err == nil {
+		t.Error("expected error for an unknown verse")
+	}
+
+	// Single-verse bookmarks end where they start.
+	id, err = db.AddBookmark(gen11, "")
+	if err != nil {
+		t.Fatalf("AddBookmark: %v", err)
+	}
+	if bm, _ := db.GetBookmark(id); bm.EndChapter != 1 || bm.EndVerse != 1 || bm.Range().String() != "창세기 1:1" {
+		t.Errorf("unexpected single-verse bookmark: %+v", bm)
+	}
+}
+
+func TestAddBookmarkRanges(t *testing.T) {
+	db, gen11 := setupBookmarkDB(t)
+	ids := addRangeVerses(t, db, gen11)
+
+	// A bad span adds none of the bookmarks.
+	if _, err := db.AddBookmarkRanges([][2]int64{{ids["창1:1"], ids["창1:2"]}, {ids["창2:1"], 9999}}, ""); err == nil {
+		t.Fatal("expected error for an unknown verse")
+	}
+	if bms, _ := db.ListBookmarks(10, 0); len(bms) != 0 {
+		t.Fatalf("expected no bookmarks after a failed add, got %+v", bms)
+	}
+
+	added, err := db.AddBookmarkRanges([][2]int64{{ids["창1:1"], ids["창1:2"]}, {ids["창2:1"], ids["창2:1"]}}, "메모", "창조")
+	if err != nil {
+		t.Fatalf("AddBookmarkRanges: %v", err)
+	}
+	if len(added) != 2 {
+		t.Fatalf("expected 2 bookmarks, got %v", added)
+	}
+	for _, id := range added {
+		bm, err := db.GetBookmark(id)
+		if err != nil || bm == nil || bm.Note != "메모" || len(bm.Tags) != 1 || bm.Tags[0] != "창조" {
+			t.Errorf("unexpected bookmark %+v, %v", bm, err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"database/sql/driver"
+	"strings"
+
+	"modernc.org/sqlite"
+)
+
+// Initial-consonant (초성) search lets "ㅎㄴㄴㅇ" find 하나님의. The
+// verses_chosung table indexes each verse with every syllable replaced by
+// its initial consonant, using the same bigrams as verses_fts.
+
+// chosungInitials are the 19 initial consonants in Unicode syllable order.
+var chosungInitials = []rune("ㄱㄲㄴㄷㄸㄹㅁㅂㅃㅅㅆㅇㅈㅉㅊㅋㅌㅍㅎ")
+
+const (
+	hangulFirst    = 0xAC00 // 가
+	hangulLast     = 0xD7A3 // 힣
+	syllablesPerCh = 21 * 28
+)
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("chosung", 1, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		switch v := args[0].(type) {
+		case string:
+			return chosung(v), nil
+		case []byte:
+			return chosung(string(v)), nil
+		default:
+			return "", nil
+		}
+	})
+}
+
+// chosung replaces every Hangul syllable in text with its initial
+// consonant, leaving other characters alone. The result has as many runes
+// as text, so positions carry over.
+func chosung(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= hangulFirst && r <= hangulLast {
+			return chosungInitials[(r-hangulFirst)/syllablesPerCh]
+		}
+		return r
+	}, text)
+}
+
+// IsChosungQuery reports whether query consists only of Hangul consonant
+// letters (ㄱ-ㅎ) and spaces, and should be searched by initial consonant.
+func IsChosungQuery(query string) bool {
+	found := false
+	for _, r := range query {
+		switch {
+		case r >= 'ㄱ' && r <= 'ㅎ':
+			found = true
+		case r == ' ':
+		default:
+			return false
+		}
+	}
+	return found
+}
@@ -0,0 +1,41 @@
+package db
+
+import "testing"
+
+func TestChosung(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"하나님의", "ㅎㄴㄴㅇ"},
+		{"까닭에 빛이", "ㄲㄷㅇ ㅂㅇ"},
+		{"주 예수(그리스도)", "ㅈ ㅇㅅ(ㄱㄹㅅㄷ)"},
+		{"God 1:1", "God 1:1"},
+	}
+	for _, tt := range tests {
+		if got := chosung(tt.text); got != tt.want {
+			t.Errorf("chosung(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestIsChosungQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"ㅎㄴㄴㅇ", true},
+		{"ㅎㄴㄴ ㅅㄹ", true},
+		{"ㅎ", true},
+		{"하나님", false},
+		{"ㅎㄴ님", false},
+		{"ㅏㅑ", false},
+		{" ", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsChosungQuery(tt.query); got != tt.want {
+			t.Errorf("IsChosungQuery(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
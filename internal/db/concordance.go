@@ -0,0 +1,139 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Concordance and word statistics work on the words of verse text (see
+// searchWords). The FTS5 vocabulary of verses_fts cannot be used for
+// this: it holds character bigrams, not words (see ngram.go).
+
+// Concordance lists every occurrence of a word, grouped by book.
+type Concordance struct {
+	Word   string
+	Total  int               // occurrences in all books
+	Verses int               // verses containing the word
+	Books  []ConcordanceBook // in Bible order
+	Forms  []WordCount       // the verse words containing the word, most frequent first
+}
+
+// ConcordanceBook is a word's occurrences in one book.
+type ConcordanceBook struct {
+	BookCode string
+	BookName string
+	Count    int            // occurrences, possibly several per verse
+	Results  []SearchResult // in Bible order
+}
+
+// WordCount is how often a word occurs.
+type WordCount struct {
+	Word  string
+	Count int
+}
+
+// Concordance finds word in the verses of versionCode within scope (its
+// Text is ignored). Like search, the word also matches inside longer words,
+// so the concordance of "사랑" counts 사랑하사 and 사랑을, listed in Forms.
+func (d *DB) Concordance(versionCode, word string, scope SearchQuery) (*Concordance, error) {
+	word = strings.TrimSpace(strings.Trim(word, `"`))
+	if word == "" {
+		return nil, fmt.Errorf("concordance needs a word")
+	}
+	q := scope
+	q.Text = `"` + word + `"`
+	page, err := d.Search(versionCode, q, SearchOptions{Sort: SortCanonical})
+	if err != nil {
+		return nil, err
+	}
+
+	key := strings.ToLower
+	if q.IsChosung() {
+		key = chosung
+	}
+	needle := key(strings.Join(searchWords(word), " "))
+
+	c := &Concordance{Word: word, Verses: page.Total}
+	forms := make(map[string]int)
+	for _, r := range page.Results {
+		if n := len(c.Books); n == 0 || c.Books[n-1].BookCode != r.Verse.BookCode {
+			c.Books = append(c.Books, ConcordanceBook{BookCode: r.Verse.BookCode, BookName: r.Verse.BookName})
+		}
+		book := &c.Books[len(c.Books)-1]
+		book.Count += r.MatchCount
+		book.Results = append(book.Results, r)
+		c.Total += r.MatchCount
+
+		// Phrases span words, so only single words are broken down by form.
+		if !strings.Contains(needle, " ") {
+			for _, w := range searchWords(r.Verse.Text) {
+				if strings.Contains(key(w), needle) {
+					forms[w]++
+				}
+			}
+		}
+	}
+	c.Forms = sortWordCounts(forms, 0)
+	return c, nil
+}
+
+// WordFrequencies counts the words of the verses of versionCode within
+// scope (its Text is ignored) and returns the top most frequent, or all
+// of them when top is 0 or less. Words are lower-cased verse words as
+// written, particles included: 하나님이 and 하나님의 are counted apart.
+func (d *DB) WordFrequencies(versionCode string, scope SearchQuery, top int) ([]WordCount, error) {
+	where := "ver.code = ?"
+	args := []interface{}{versionCode}
+	if clause, scopeArgs := scope.scopeClause(); clause != "" {
+		where += " AND " + clause
+		args = append(args, scopeArgs...)
+	}
+
+	rows, err := d.conn.Query(
+		`SELECT v.text
+		 FROM verses v
+		 JOIN books b ON b.id = v.book_id
+		 JOIN versions ver ON ver.id = b.version_id
+		 WHERE `+where,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("count words: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var text string
+		if err := rows.Scan(&text); err != nil {
+			return nil, fmt.Errorf("scan verse text: %w", err)
+		}
+		for _, w := range searchWords(text) {
+			counts[w]++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("count words: %w", err)
+	}
+	return sortWordCounts(counts, top), nil
+}
+
+// sortWordCounts orders counts by frequency, then alphabetically, and
+// keeps the first top (all when top is 0 or less).
+func sortWordCounts(counts map[string]int, top int) []WordCount {
+	list := make([]WordCount, 0, len(counts))
+	for w, n := range counts {
+		list = append(list, WordCount{Word: w, Count: n})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Count != list[j].Count {
+			return list[i].Count > list[j].Count
+		}
+		return list[i].Word < list[j].Word
+	})
+	if top > 0 && len(list) > top {
+		list = list[:top]
+	}
+	return list
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConcordance(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	c, err := db.Concordance("GAE", "하나님", SearchQuery{})
+	if err != nil {
+		t.Fatalf("Concordance: %v", err)
+	}
+	if c.Total != 6 || c.Verses != 5 {
+		t.Errorf("expected 6 occurrences in 5 verses, got %d in %d", c.Total, c.Verses)
+	}
+	var books []BookCount
+	for _, b := range c.Books {
+		books = append(books, BookCount{BookCode: b.BookCode, BookName: b.BookName, Count: b.Count})
+	}
+	want := []BookCount{
+		{BookCode: "gen", BookName: "창세기", Count: 5},
+		{BookCode: "jhn", BookName: "요한복음", Count: 1},
+	}
+	if !reflect.DeepEqual(books, want) {
+		t.Errorf("books = %v, want %v", books, want)
+	}
+	if got := c.Books[0].Results[3].Verse.VerseNum; got != 4 {
+		t.Errorf("expected Genesis results in Bible order, 4th is verse %d", got)
+	}
+}
+
+func TestConcordance_Forms(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	c, err := db.Concordance("GAE", "사랑", SearchQuery{})
+	if err != nil {
+		t.Fatalf("Concordance: %v", err)
+	}
+	if c.Total != 7 {
+		t.Errorf("expected 7 occurrences, got %d", c.Total)
+	}
+	want := []WordCount{
+		{"사랑하라", 2}, {"사랑", 1}, {"사랑하사", 1}, {"사랑하신", 1}, {"사랑하였으니", 1}, {"사랑한", 1},
+	}
+	if !reflect.DeepEqual(c.Forms, want) {
+		t.Errorf("forms = %v, want %v", c.Forms, want)
+	}
+}
+
+func TestConcordance_Scope(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	c, err := db.Concordance("GAE", "하나님", SearchQuery{Books: []string{"jhn"}})
+	if err != nil {
+		t.Fatalf("Concordance: %v", err)
+	}
+	if len(c.Books) != 1 || c.Books[0].BookCode != "jhn" || c.Total != 1 {
+		t.Errorf("expected one occurrence in John, got %+v", c.Books)
+	}
+
+	if _, err := db.Concordance("GAE", " ", SearchQuery{}); err == nil {
+		t.Error("expected an error for an empty word")
+	}
+}
+
+func TestWordFrequencies(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	got, err := db.WordFrequencies("GAE", SearchQuery{Books: []string{"gen"}}, 2)
+	if err != nil {
+		t.Fatalf("WordFrequencies: %v", err)
+	}
+	want := []WordCount{{"하나님이", 4}, {"빛이", 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	all, err := db.WordFrequencies("GAE", SearchQuery{Testament: "new"}, 0)
+	if err != nil {
+		t.Fatalf("WordFrequencies: %v", err)
+	}
+	if len(all) == 0 || all[0] != (WordCount{"같이", 2}) {
+		t.Errorf("expected 같이 to come first in the New Testament, got %v", all)
+	}
+	for _, wc := range all {
+		if wc.Word == "하나님이" && wc.Count != 1 {
+			t.Errorf("expected 하나님이 once in the New Testament, got %d", wc.Count)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	_ "modernc.org/sqlite"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
 )
 
 type DB struct {
@@ -24,7 +26,12 @@ func Open(path string) (*DB, error) {
 		conn.Close()
 		return nil, fmt.Errorf("enable foreign keys: %w", err)
 	}
-	return &DB{conn: conn}, nil
+	d := &DB{conn: conn}
+	if err := d.checkSchemaVersion(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("open db: %w", err)
+	}
+	return d, nil
 }
 
 func OpenMemory() (*DB, error) {
@@ -35,111 +42,71 @@ func (d *DB) Close() error {
 	return d.conn.Close()
 }
 
-func (d *DB) Migrate() error {
-	statements := []string{
-		`CREATE TABLE IF NOT EXISTS versions (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			code TEXT UNIQUE NOT NULL,
-			name TEXT NOT NULL,
-			lang TEXT NOT NULL DEFAULT 'ko'
-		)`,
-		`CREATE TABLE IF NOT EXISTS books (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			version_id INTEGER NOT NULL REFERENCES versions(id),
-			code TEXT NOT NULL,
-			name_ko TEXT NOT NULL,
-			abbrev_ko TEXT NOT NULL,
-			testament TEXT NOT NULL,
-			chapter_count INTEGER NOT NULL,
-			sort_order INTEGER NOT NULL,
-			UNIQUE(version_id, code)
-		)`,
-		`CREATE TABLE IF NOT EXISTS verses (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			book_id INTEGER NOT NULL REFERENCES books(id),
-			chapter INTEGER NOT NULL,
-			verse_num INTEGER NOT NULL,
-			text TEXT NOT NULL,
-			section_title TEXT,
-			has_footnote BOOLEAN NOT NULL DEFAULT 0,
-			UNIQUE(book_id, chapter, verse_num)
-		)`,
-		`CREATE TABLE IF NOT EXISTS footnotes (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			verse_id INTEGER NOT NULL REFERENCES verses(id),
-			marker TEXT,
-			content TEXT NOT NULL
-		)`,
-		`CREATE VIRTUAL TABLE IF NOT EXISTS verses_fts USING fts5(
-			text,
-			content=verses,
-			content_rowid=id,
-			tokenize='unicode61'
-		)`,
-		`CREATE TRIGGER IF NOT EXISTS verses_ai AFTER INSERT ON verses BEGIN
-			INSERT INTO verses_fts(rowid, text) VALUES (new.id, new.text);
-		END`,
-		`CREATE TRIGGER IF NOT EXISTS verses_ad AFTER DELETE ON verses BEGIN
-			INSERT INTO verses_fts(verses_fts, rowid, text) VALUES('delete', old.id, old.text);
-		END`,
-		`CREATE TRIGGER IF NOT EXISTS verses_au AFTER UPDATE ON verses BEGIN
-			INSERT INTO verses_fts(verses_fts, rowid, text) VALUES('delete', old.id, old.text);
-			INSERT INTO verses_fts(rowid, text) VALUES (new.id, new.text);
-		END`,
-		`CREATE TABLE IF NOT EXISTS bookmarks (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			verse_id INTEGER NOT NULL REFERENCES verses(id),
-			note TEXT,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		)`,
-		`CREATE TABLE IF NOT EXISTS highlights (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			verse_id INTEGER NOT NULL REFERENCES verses(id),
-			color TEXT NOT NULL DEFAULT 'yellow',
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			UNIQUE(verse_id)
-		)`,
-		`CREATE TABLE IF NOT EXISTS reading_plans (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			name TEXT NOT NULL,
-			plan_type TEXT NOT NULL,
-			version_id INTEGER NOT NULL REFERENCES versions(id),
-			total_days INTEGER NOT NULL,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		)`,
-		`CREATE TABLE IF NOT EXISTS reading_plan_entries (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			plan_id INTEGER NOT NULL REFERENCES reading_plans(id),
-			day_number INTEGER NOT NULL,
-			book_code TEXT NOT NULL,
-			chapter_start INTEGER NOT NULL,
-			chapter_end INTEGER NOT NULL,
-			completed BOOLEAN NOT NULL DEFAULT 0,
-			completed_at DATETIME,
-			UNIQUE(plan_id, day_number, book_code, chapter_start)
-		)`,
-		`CREATE TABLE IF NOT EXISTS settings (
-			key TEXT PRIMARY KEY,
-			value TEXT NOT NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS crawl_status (
-			version_code TEXT NOT NULL,
-			book_code TEXT NOT NULL,
-			chapter INTEGER NOT NULL,
-			status TEXT NOT NULL DEFAULT 'pending',
-			verse_count INTEGER,
-			crawled_at DATETIME,
-			error_msg TEXT,
-			PRIMARY KEY(version_code, book_code, chapter)
-		)`,
-	}
-
-	for _, stmt := range statements {
-		if _, err := d.conn.Exec(stmt); err != nil {
-			return fmt.Errorf("migrate: %w\nSQL: %s", err, stmt)
-		}
+// verseKeyQuery selects the (version, book, chapter, verse) coordinate of
+// the verse with the given ID.
+const verseKeyQuery = `SELECT ver.code, b.code, v.chapter, v.verse_num
+	FROM verses v
+	JOIN books b ON b.id = v.book_id
+	JOIN versions ver ON ver.id = b.version_id
+	WHERE v.id = ?`
+
+// annotationVerseJoin joins an annotation table aliased "a" to its book and,
+// when the verse is currently crawled, to its first verse row.
+const annotationVerseJoin = `JOIN versions ver ON ver.code = a.version_code
+	JOIN books b ON b.version_id = ver.id AND b.code = a.book_code
+	LEFT JOIN verses v ON v.book_id = b.id AND v.chapter = a.chapter AND v.verse_num = a.verse_num`
+
+// annotationRangeText is the text of every crawled verse in the range of
+// an annotation joined with annotationVerseJoin, in order.
+const annotationRangeText = `COALESCE((SELECT group_concat(rv.text, ' ' ORDER BY rv.chapter, rv.verse_num)
+		FROM verses rv
+		WHERE rv.book_id = b.id
+		  AND (rv.chapter, rv.verse_num) >= (a.chapter, a.verse_num)
+		  AND (rv.chapter, rv.verse_num) <= (a.end_chapter, a.end_verse)), '')`
+
+// annotationCoversVerse restricts an annotation table aliased "a" to the
+// annotations whose range includes the verse with the given ID.
+const annotationCoversVerse = `EXISTS (SELECT 1
+	FROM verses v
+	JOIN books b ON b.id = v.book_id
+	JOIN versions ver ON ver.id = b.version_id
+	WHERE v.id = ? AND ver.code = a.version_code AND b.code = a.book_code
+	  AND (a.chapter, a.verse_num) <= (v.chapter, v.verse_num)
+	  AND (a.end_chapter, a.end_verse) >= (v.chapter, v.verse_num))`
+
+// annotationSpan is the coordinate range a bookmark or highlight covers.
+type annotationSpan struct {
+	version, book                        string
+	chapter, verse, endChapter, endVerse int
+}
+
+// resolveSpan returns the span from the verse startID to the verse endID,
+// swapping them when endID comes first. Both verses must be in the same
+// version and book.
+func (d *DB) resolveSpan(startID, endID int64) (annotationSpan, error) {
+	var sp annotationSpan
+	var endVersion, endBook string
+	err := d.conn.QueryRow(verseKeyQuery, startID).Scan(&sp.version, &sp.book, &sp.chapter, &sp.verse)
+	if err == sql.ErrNoRows {
+		return sp, fmt.Errorf("verse %d not found", startID)
 	}
-	return nil
+	if err != nil {
+		return sp, err
+	}
+	err = d.conn.QueryRow(verseKeyQuery, endID).Scan(&endVersion, &endBook, &sp.endChapter, &sp.endVerse)
+	if err == sql.ErrNoRows {
+		return sp, fmt.Errorf("verse %d not found", endID)
+	}
+	if err != nil {
+		return sp, err
+	}
+	if endVersion != sp.version || endBook != sp.book {
+		return sp, fmt.Errorf("verses %d and %d are not in the same book", startID, endID)
+	}
+	if sp.endChapter < sp.chapter || (sp.endChapter == sp.chapter && sp.endVerse < sp.verse) {
+		sp.chapter, sp.verse, sp.endChapter, sp.endVerse = sp.endChapter, sp.endVerse, sp.chapter, sp.verse
+	}
+	return sp, nil
 }
 
 func (d *DB) InsertVersion(code, name, lang string) (int64, error) {
@@ -150,13 +117,14 @@ func (d *DB) InsertVersion(code, name, lang string) (int64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("insert version: %w", err)
 	}
+	// LastInsertId is stale when the row already existed, so check RowsAffected.
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return d.getVersionID(code)
+	}
 	id, err := res.LastInsertId()
 	if err != nil {
 		return 0, fmt.Errorf("insert version last id: %w", err)
 	}
-	if id == 0 {
-		return d.getVersionID(code)
-	}
 	return id, nil
 }
 
@@ -180,6 +148,25 @@ func (d *DB) GetVersionByCode(code string) (*Version, error) {
 	return v, nil
 }
 
+// ListVersions returns every version present in the database, in insertion order.
+func (d *DB) ListVersions() ([]Version, error) {
+	rows, err := d.conn.Query("SELECT id, code, name, lang FROM versions ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("list versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []Version
+	for rows.Next() {
+		var v Version
+		if err := rows.Scan(&v.ID, &v.Code, &v.Name, &v.Lang); err != nil {
+			return nil, fmt.Errorf("scan version: %w", err)
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
 func (d *DB) InsertBook(versionID int64, code, nameKo, abbrevKo, testament string, chapterCount, sortOrder int) (int64, error) {
 	res, err := d.conn.Exec(
 		`INSERT OR IGNORE INTO books (version_id, code, name_ko, abbrev_ko, testament, chapter_count, sort_order)
@@ -211,6 +198,32 @@ func (d *DB) GetBookByCode(versionCode, bookCode string) (*Book, error) {
 	return b, nil
 }
 
+// ListBooks returns the books of a version in canonical order.
+func (d *DB) ListBooks(versionCode string) ([]Book, error) {
+	rows, err := d.conn.Query(
+		`SELECT b.id, b.version_id, b.code, b.name_ko, b.abbrev_ko, b.testament, b.chapter_count, b.sort_order
+		 FROM books b
+		 JOIN versions v ON v.id = b.version_id
+		 WHERE v.code = ?
+		 ORDER BY b.sort_order`,
+		versionCode,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list books: %w", err)
+	}
+	defer rows.Close()
+
+	var books []Book
+	for rows.Next() {
+		var b Book
+		if err := rows.Scan(&b.ID, &b.VersionID, &b.Code, &b.NameKo, &b.AbbrevKo, &b.Testament, &b.ChapterCount, &b.SortOrder); err != nil {
+			return nil, fmt.Errorf("scan book: %w", err)
+		}
+		books = append(books, b)
+	}
+	return books, rows.Err()
+}
+
 func (d *DB) InsertVerse(bookID int64, chapter, verseNum int, text, sectionTitle string, hasFootnote bool) (int64, error) {
 	var secTitle interface{}
 	if sectionTitle != "" {
@@ -242,6 +255,35 @@ func (d *DB) InsertFootnote(verseID int64, marker, content string) error {
 	return nil
 }
 
+// GetFootnotes returns all footnotes for a chapter, ordered by verse number
+// and then by the order in which they appear in the verse.
+func (d *DB) GetFootnotes(versionCode, bookCode string, chapter int) ([]Footnote, error) {
+	rows, err := d.conn.Query(
+		`SELECT f.id, f.verse_id, COALESCE(f.marker, ''), f.content
+		 FROM footnotes f
+		 JOIN verses v ON v.id = f.verse_id
+		 JOIN books b ON b.id = v.book_id
+		 JOIN versions ver ON ver.id = b.version_id
+		 WHERE ver.code = ? AND b.code = ? AND v.chapter = ?
+		 ORDER BY v.verse_num, f.id`,
+		versionCode, bookCode, chapter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("get footnotes: %w", err)
+	}
+	defer rows.Close()
+
+	var footnotes []Footnote
+	for rows.Next() {
+		var f Footnote
+		if err := rows.Scan(&f.ID, &f.VerseID, &f.Marker, &f.Content); err != nil {
+			return nil, fmt.Errorf("scan footnote: %w", err)
+		}
+		footnotes = append(footnotes, f)
+	}
+	return footnotes, rows.Err()
+}
+
 func (d *DB) GetVerses(versionCode, bookCode string, chapter int) ([]Verse, error) {
 	rows, err := d.conn.Query(
 		`SELECT v.id, v.book_id, v.chapter, v.verse_num, v.text,
@@ -274,6 +316,25 @@ func (d *DB) GetVerses(versionCode, bookCode string, chapter int) ([]Verse, erro
 	return verses, rows.Err()
 }
 
+// GetPassage loads every verse covered by the ranges, in order.
+func (d *DB) GetPassage(versionCode string, ranges []bible.Range) ([]Verse, error) {
+	var result []Verse
+	for _, r := range ranges {
+		for _, ch := range r.Chapters() {
+			verses, err := d.GetVerses(versionCode, ch.BookCode, ch.Chapter)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range verses {
+				if r.Contains(ch.BookCode, ch.Chapter, v.VerseNum) {
+					result = append(result, v)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
 func (d *DB) GetRandomVerse(versionCode string) (*Verse, error) {
 	v := &Verse{}
 	err := d.conn.QueryRow(
@@ -370,10 +431,10 @@ func (d *DB) ResetCrawlData(versionCode, bookCode string) (int64, error) {
 	bookIDQuery := `SELECT b.id FROM books b JOIN versions v ON v.id = b.version_id WHERE v.code = ?` + bookFilter
 	verseIDQuery := `SELECT id FROM verses WHERE book_id IN (` + bookIDQuery + `)`
 
-	for _, table := range []string{"footnotes", "bookmarks", "highlights"} {
-		if _, err := tx.Exec(`DELETE FROM `+table+` WHERE verse_id IN (`+verseIDQuery+`)`, bookArgs...); err != nil {
-			return 0, fmt.Errorf("delete %s: %w", table, err)
-		}
+	// Bookmarks and highlights are keyed by verse coordinate and survive
+	// the reset; they re-attach once the chapters are crawled again.
+	if _, err := tx.Exec(`DELETE FROM footnotes WHERE verse_id IN (`+verseIDQuery+`)`, bookArgs...); err != nil {
+		return 0, fmt.Errorf("delete footnotes: %w", err)
 	}
 
 	res, err := tx.Exec(`DELETE FROM verses WHERE book_id IN (`+bookIDQuery+`)`, bookArgs...)
@@ -1,7 +1,11 @@
 package db
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
 )
 
 func setupTestDB(t *testing.T) *DB {
@@ -36,7 +40,7 @@ func TestMigrate(t *testing.T) {
 	expectedTables := []string{
 		"versions", "books", "verses", "footnotes",
 		"bookmarks", "highlights", "reading_plans", "reading_plan_entries",
-		"settings", "crawl_status", "verses_fts",
+		"settings", "crawl_status", "verses_fts", "schema_migrations",
 	}
 
 	for _, table := range expectedTables {
@@ -102,6 +106,97 @@ func TestInsertAndGetVerses(t *testing.T) {
 	}
 }
 
+func TestListVersions(t *testing.T) {
+	d := setupTestDB(t)
+
+	versions, err := d.ListVersions()
+	if err != nil {
+		t.Fatalf("ListVersions empty: %v", err)
+	}
+	if len(versions) != 0 {
+		t.Errorf("expected 0 versions, got %d", len(versions))
+	}
+
+	if _, err := d.InsertVersion("GAE", "개역개정", "ko"); err != nil {
+		t.Fatalf("InsertVersion: %v", err)
+	}
+	if _, err := d.InsertVersion("HAN", "개역한글", "ko"); err != nil {
+		t.Fatalf("InsertVersion: %v", err)
+	}
+
+	versions, err = d.ListVersions()
+	if err != nil {
+		t.Fatalf("ListVersions: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	if versions[0].Code != "GAE" || versions[1].Code != "HAN" {
+		t.Errorf("expected [GAE HAN], got [%s %s]", versions[0].Code, versions[1].Code)
+	}
+	if versions[1].Name != "개역한글" {
+		t.Errorf("expected name '개역한글', got %q", versions[1].Name)
+	}
+}
+
+func TestListBooks(t *testing.T) {
+	d := setupTestDB(t)
+	versionID, _ := seedTestData(t, d)
+	if _, err := d.InsertBook(versionID, "mat", "마태복음", "마", "new", 28, 40); err != nil {
+		t.Fatalf("InsertBook: %v", err)
+	}
+	if _, err := d.InsertBook(versionID, "exo", "출애굽기", "출", "old", 40, 2); err != nil {
+		t.Fatalf("InsertBook: %v", err)
+	}
+
+	books, err := d.ListBooks("GAE")
+	if err != nil {
+		t.Fatalf("ListBooks: %v", err)
+	}
+	var codes []string
+	for _, b := range books {
+		codes = append(codes, b.Code)
+	}
+	if strings.Join(codes, ",") != "gen,exo,mat" {
+		t.Errorf("expected canonical order gen,exo,mat, got %v", codes)
+	}
+	if books[2].NameKo != "마태복음" || books[2].ChapterCount != 28 || books[2].Testament != "new" {
+		t.Errorf("unexpected book: %+v", books[2])
+	}
+
+	if books, err := d.ListBooks("HAN"); err != nil || len(books) != 0 {
+		t.Errorf("expected no books for a missing version, got %v, %v", books, err)
+	}
+}
+
+func TestGetPassage(t *testing.T) {
+	d := setupTestDB(t)
+	_, bookID := seedTestData(t, d)
+	for ch := 1; ch <= 2; ch++ {
+		for v := 1; v <= 3; v++ {
+			if _, err := d.InsertVerse(bookID, ch, v, fmt.Sprintf("%d장 %d절", ch, v), "", false); err != nil {
+				t.Fatalf("InsertVerse: %v", err)
+			}
+		}
+	}
+
+	ranges, err := bible.ParseReferences("창 1:3-2:1; 창 2:3")
+	if err != nil {
+		t.Fatalf("ParseReferences: %v", err)
+	}
+	verses, err := d.GetPassage("GAE", ranges)
+	if err != nil {
+		t.Fatalf("GetPassage: %v", err)
+	}
+	var texts []string
+	for _, v := range verses {
+		texts = append(texts, v.Text)
+	}
+	if got := strings.Join(texts, ","); got != "1장 3절,2장 1절,2장 3절" {
+		t.Errorf("unexpected passage: %s", got)
+	}
+}
+
 func TestGetRandomVerse(t *testing.T) {
 	d := setupTestDB(t)
 	_, bookID := seedTestData(t, d)
@@ -273,6 +368,71 @@ func TestResetCrawlData_WithFootnotes(t *testing.T) {
 	}
 }
 
+func TestResetCrawlData_KeepsAnnotations(t *testing.T) {
+	d := setupTestDB(t)
+	_, bookID := seedTestData(t, d)
+
+	v1, err := d.InsertVerse(bookID, 1, 1, "구절 1", "", false)
+	if err != nil {
+		t.Fatalf("InsertVerse: %v", err)
+	}
+	v2, err := d.InsertVerse(bookID, 1, 2, "구절 2", "", false)
+	if err != nil {
+		t.Fatalf("InsertVerse: %v", err)
+	}
+	if _, err := d.AddBookmark(v1, "메모"); err != nil {
+		t.Fatalf("AddBookmark: %v", err)
+	}
+	if err := d.AddHighlight(v2, "blue"); err != nil {
+		t.Fatalf("AddHighlight: %v", err)
+	}
+
+	if _, err := d.ResetCrawlData("GAE", "gen"); err != nil {
+		t.Fatalf("ResetCrawlData: %v", err)
+	}
+
+	// Annotations survive the reset even though their verses are gone.
+	bookmarks, err := d.ListBookmarks(10, 0)
+	if err != nil {
+		t.Fatalf("ListBookmarks: %v", err)
+	}
+	if len(bookmarks) != 1 || bookmarks[0].VerseID != 0 || bookmarks[0].VerseNum != 1 {
+		t.Fatalf("expected detached bookmark on 1:1, got %+v", bookmarks)
+	}
+
+	// Re-crawl: new verse rows get new IDs and the annotations re-attach.
+	n1, err := d.InsertVerse(bookID, 1, 1, "새 구절 1", "", false)
+	if err != nil {
+		t.Fatalf("InsertVerse: %v", err)
+	}
+	n2, err := d.InsertVerse(bookID, 1, 2, "새 구절 2", "", false)
+	if err != nil {
+		t.Fatalf("InsertVerse: %v", err)
+	}
+	if n1 == v1 {
+		t.Fatalf("expected new verse ID after re-crawl")
+	}
+
+	bookmarks, err = d.ListBookmarks(10, 0)
+	if err != nil {
+		t.Fatalf("ListBookmarks: %v", err)
+	}
+	if len(bookmarks) != 1 || bookmarks[0].VerseID != n1 || bookmarks[0].VerseText != "새 구절 1" || bookmarks[0].Note != "메모" {
+		t.Errorf("expected bookmark re-attached to new verse, got %+v", bookmarks)
+	}
+	ok, err := d.IsBookmarked(n1)
+	if err != nil || !ok {
+		t.Errorf("IsBookmarked(new verse) = %v, %v", ok, err)
+	}
+	color, err := d.GetHighlightColor(n2)
+	if err != nil {
+		t.Fatalf("GetHighlightColor: %v", err)
+	}
+	if color != "blue" {
+		t.Errorf("expected highlight 'blue' on re-crawled verse, got %q", color)
+	}
+}
+
 func TestInsertFootnote(t *testing.T) {
 	d := setupTestDB(t)
 	_, bookID := seedTestData(t, d)
@@ -304,3 +464,54 @@ func TestInsertFootnote(t *testing.T) {
 		t.Errorf("expected content '히브리어 원문 해석', got %q", fn.Content)
 	}
 }
+
+func TestGetFootnotes(t *testing.T) {
+	d := setupTestDB(t)
+	_, bookID := seedTestData(t, d)
+
+	v1, err := d.InsertVerse(bookID, 1, 1, "첫째 구절", "", false)
+	if err != nil {
+		t.Fatalf("InsertVerse: %v", err)
+	}
+	v2, err := d.InsertVerse(bookID, 1, 2, "둘째 구절", "", true)
+	if err != nil {
+		t.Fatalf("InsertVerse: %v", err)
+	}
+	other, err := d.InsertVerse(bookID, 2, 1, "다른 장", "", true)
+	if err != nil {
+		t.Fatalf("InsertVerse: %v", err)
+	}
+	if err := d.InsertFootnote(v2, "1)", "또는 형체가 없는"); err != nil {
+		t.Fatalf("InsertFootnote: %v", err)
+	}
+	if err := d.InsertFootnote(v2, "2)", "히, 또는 발광체"); err != nil {
+		t.Fatalf("InsertFootnote: %v", err)
+	}
+	if err := d.InsertFootnote(other, "3)", "다른 장 각주"); err != nil {
+		t.Fatalf("InsertFootnote: %v", err)
+	}
+
+	footnotes, err := d.GetFootnotes("GAE", "gen", 1)
+	if err != nil {
+		t.Fatalf("GetFootnotes: %v", err)
+	}
+	if len(footnotes) != 2 {
+		t.Fatalf("expected 2 footnotes, got %d", len(footnotes))
+	}
+	for _, fn := range footnotes {
+		if fn.VerseID != v2 {
+			t.Errorf("expected footnote on verse %d, got %d (v1=%d)", v2, fn.VerseID, v1)
+		}
+	}
+	if footnotes[0].Marker != "1)" || footnotes[1].Marker != "2)" {
+		t.Errorf("expected markers in order 1), 2), got %q, %q", footnotes[0].Marker, footnotes[1].Marker)
+	}
+
+	empty, err := d.GetFootnotes("GAE", "gen", 3)
+	if err != nil {
+		t.Fatalf("GetFootnotes empty: %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("expected 0 footnotes for chapter without verses, got %d", len(empty))
+	}
+}
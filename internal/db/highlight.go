@@ -3,33 +3,105 @@ package db
 import (
 	"database/sql"
 	"fmt"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
 )
 
+// HighlightWithVerse is a highlight with its book and text. Like a
+// bookmark it covers Chapter:VerseNum to EndChapter:EndVerse of one book.
 type HighlightWithVerse struct {
 	Highlight
-	VerseText string
-	BookName  string
-	BookCode  string
-	Chapter   int
-	VerseNum  int
+	VerseText   string `json:"text"`
+	VersionCode string `json:"version"`
+	BookName    string `json:"book_name"`
+	BookCode    string `json:"book_code"`
+	Chapter     int    `json:"chapter"`
+	VerseNum    int    `json:"verse"`
+	EndChapter  int    `json:"end_chapter"`
+	EndVerse    int    `json:"end_verse"`
+}
+
+// Range returns the verses the highlight covers.
+func (h HighlightWithVerse) Range() bible.Range {
+	return bible.Range{
+		Start: bible.Location{BookCode: h.BookCode, Chapter: h.Chapter, Verse: h.VerseNum},
+		End:   bible.Location{BookCode: h.BookCode, Chapter: h.EndChapter, Verse: h.EndVerse},
+	}
 }
 
 // AddHighlight adds or updates a highlight for a verse.
-// Uses INSERT OR REPLACE since verse_id is UNIQUE.
 func (d *DB) AddHighlight(verseID int64, color string) error {
-	_, err := d.conn.Exec(
-		"INSERT OR REPLACE INTO highlights (verse_id, color) VALUES (?, ?)",
-		verseID, color,
+	return d.AddHighlightRange(verseID, verseID, color)
+}
+
+// AddHighlightRange highlights the verses from startVerseID to endVerseID
+// of one book. Highlights inside the range, and one starting at the same
+// verse, are replaced.
+func (d *DB) AddHighlightRange(startVerseID, endVerseID int64, color string) error {
+	sp, err := d.resolveSpan(startVerseID, endVerseID)
+	if err != nil {
+		return fmt.Errorf("add highlight: %w", err)
+	}
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		`DELETE FROM highlights
+		 WHERE version_code = ? AND book_code = ?
+		   AND (chapter, verse_num) >= (?, ?) AND (end_chapter, end_verse) <= (?, ?)`,
+		sp.version, sp.book, sp.chapter, sp.verse, sp.endChapter, sp.endVerse,
+	)
+	if err != nil {
+		return fmt.Errorf("add highlight: %w", err)
+	}
+	// The start coordinate is UNIQUE, so this replaces a longer highlight
+	// starting at the same verse too.
+	_, err = tx.Exec(
+		`INSERT OR REPLACE INTO highlights (version_code, book_code, chapter, verse_num, end_chapter, end_verse, color)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		sp.version, sp.book, sp.chapter, sp.verse, sp.endChapter, sp.endVerse, color,
 	)
 	if err != nil {
 		return fmt.Errorf("add highlight: %w", err)
 	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
 	return nil
 }
 
-// RemoveHighlight removes a highlight by verse ID.
+// RemoveHighlight removes the highlights covering a verse, including
+// ranges that start or end elsewhere.
 func (d *DB) RemoveHighlight(verseID int64) error {
-	_, err := d.conn.Exec("DELETE FROM highlights WHERE verse_id = ?", verseID)
+	_, err := d.conn.Exec(
+		`DELETE FROM highlights AS a WHERE `+annotationCoversVerse,
+		verseID,
+	)
+	if err != nil {
+		return fmt.Errorf("remove highlight: %w", err)
+	}
+	return nil
+}
+
+// UpdateHighlightColor changes the color of a highlight, keeping its range.
+func (d *DB) UpdateHighlightColor(id int64, color string) error {
+	res, err := d.conn.Exec("UPDATE highlights SET color = ? WHERE id = ?", color, id)
+	if err != nil {
+		return fmt.Errorf("update highlight color: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("update highlight color: highlight %d not found", id)
+	}
+	return nil
+}
+
+// RemoveHighlightByID removes a highlight by its own ID. Unlike
+// RemoveHighlight it works while the highlighted verse is not crawled.
+func (d *DB) RemoveHighlightByID(id int64) error {
+	_, err := d.conn.Exec("DELETE FROM highlights WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("remove highlight: %w", err)
 	}
@@ -37,14 +109,15 @@ func (d *DB) RemoveHighlight(verseID int64) error {
 }
 
 // ListHighlights returns highlights ordered by newest first.
+// VerseID and VerseText are empty while the chapter is not crawled.
 func (d *DB) ListHighlights(limit, offset int) ([]HighlightWithVerse, error) {
 	rows, err := d.conn.Query(
-		`SELECT h.id, h.verse_id, h.color, h.created_at,
-		        v.text, b.name_ko, b.code, v.chapter, v.verse_num
-		 FROM highlights h
-		 JOIN verses v ON v.id = h.verse_id
-		 JOIN books b ON b.id = v.book_id
-		 ORDER BY h.created_at DESC
+		`SELECT a.id, COALESCE(v.id, 0), a.color, a.created_at,
+		        `+annotationRangeText+`, a.version_code, b.name_ko, a.book_code,
+		        a.chapter, a.verse_num, a.end_chapter, a.end_verse
+		 FROM highlights a
+		 `+annotationVerseJoin+`
+		 ORDER BY a.created_at DESC
 		 LIMIT ? OFFSET ?`,
 		limit, offset,
 	)
@@ -58,7 +131,8 @@ func (d *DB) ListHighlights(limit, offset int) ([]HighlightWithVerse, error) {
 		var h HighlightWithVerse
 		if err := rows.Scan(
 			&h.ID, &h.VerseID, &h.Color, &h.CreatedAt,
-			&h.VerseText, &h.BookName, &h.BookCode, &h.Chapter, &h.VerseNum,
+			&h.VerseText, &h.VersionCode, &h.BookName, &h.BookCode,
+			&h.Chapter, &h.VerseNum, &h.EndChapter, &h.EndVerse,
 		); err != nil {
 			return nil, fmt.Errorf("scan highlight: %w", err)
 		}
@@ -67,11 +141,13 @@ func (d *DB) ListHighlights(limit, offset int) ([]HighlightWithVerse, error) {
 	return highlights, rows.Err()
 }
 
-// GetHighlightColor returns the highlight color for a verse, or "" if not highlighted.
+// GetHighlightColor returns the highlight color for a verse, or "" if not
+// highlighted. Where highlights overlap the newest one wins.
 func (d *DB) GetHighlightColor(verseID int64) (string, error) {
 	var color string
 	err := d.conn.QueryRow(
-		"SELECT color FROM highlights WHERE verse_id = ?",
+		`SELECT a.color FROM highlights a WHERE `+annotationCoversVerse+`
+		 ORDER BY a.id DESC LIMIT 1`,
 		verseID,
 	).Scan(&color)
 	if err == sql.ErrNoRows {
@@ -160,3 +160,67 @@ func TestGetHighlightColor_NoHighlight(t *testing.T) {
 		t.Errorf("expected empty color for non-highlighted verse, got %q", color)
 	}
 }
+
+func TestRemoveHighlightByID(t *testing.T) {
+	db, verseID := setupHighlightDB(t)
+
+	if err := db.AddHighlight(verseID, "green"); err != nil {
+		t.Fatalf("AddHighlight: %v", err)
+	}
+	highlights, err := db.ListHighlights(10, 0)
+	if err != nil || len(highlights) != 1 {
+		t.Fatalf("ListHighlights: %v (%d rows)", err, len(highlights))
+	}
+
+	if err := db.RemoveHighlightByID(highlights[0].ID); err != nil {
+		t.Fatalf("RemoveHighlightByID: %v", err)
+	}
+	color, _ := db.GetHighlightColor(verseID)
+	if color != "" {
+		t.Errorf("expected empty color after remove, got %q", color)
+	}
+}
+
+func TestAddHighlight_UnknownVerse(t *testing.T) {
+	db, _ := setupHighlightDB(t)
+	if err := db.AddHighlight(9999, "yellow"); err == nil {
+		t.Error("expected error for unknown verse")
+	}
+}
+
+func TestAddHighlightRange(t *testing.T) {
+	db, gen11 := setupHighlightDB(t)
+	ids := addRangeVerses(t, db, gen11)
+
+	if err := db.AddHighlight(ids["창1:2"], "green"); err != nil {
+		t.Fatalf("AddHighlight: %v", err)
+	}
+	if err := db.AddHighlight(ids["창2:1"], "pink"); err != nil {
+		t.Fatalf("AddHighlight: %v", err)
+	}
+	// 1:1-3 replaces the 1:2 highlight inside it and leaves 2:1 alone.
+	if err := db.AddHighlightRange(ids["창1:1"], ids["창1:3"], "blue"); err != nil {
+		t.Fatalf("AddHighlightRange: %v", err)
+	}
+	highlights, err := db.ListHighlights(10, 0)
+	if err != nil {
+		t.Fatalf("ListHighlights: %v", err)
+	}
+	if len(highlights) != 2 {
+		t.Fatalf("expected 2 highlights, got %+v", highlights)
+	}
+	for ref, want := range map[string]string{"창1:1": "blue", "창1:2": "blue", "창1:3": "blue", "창2:1": "pink", "출1:1": ""} {
+		if got, _ := db.GetHighlightColor(ids[ref]); got != want {
+			t.Errorf("color of %s = %q, want %q", ref, got, want)
+		}
+	}
+
+	// Removing by any verse of a range removes the whole range.
+	if err := db.RemoveHighlight(ids["창1:2"]); err != nil {
+		t.Fatalf("RemoveHighlight: %v", err)
+	}
+	highlights, _ = db.ListHighlights(10, 0)
+	if len(highlights) != 1 || highlights[0].Color != "pink" || highlights[0].EndChapter != 2 {
+		t.Errorf("expected only the 2:1 highlight left, got %+v", highlights)
+	}
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// searchHistoryLimit is how many past searches are kept.
+const searchHistoryLimit = 100
+
+// SearchHistoryEntry is a past search. A query searched again moves to the
+// top instead of being listed twice.
+type SearchHistoryEntry struct {
+	ID         int64     `json:"id"`
+	Query      string    `json:"query"`
+	Regex      bool      `json:"regex"`
+	SearchedAt time.Time `json:"searched_at"`
+}
+
+// SavedSearch is a search kept under a name, e.g. "믿음 in Romans" for
+// "믿음 book:롬".
+type SavedSearch struct {
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	Regex     bool      `json:"regex"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AddSearchHistory records a search as the most recent one and drops the
+// oldest beyond searchHistoryLimit.
+func (d *DB) AddSearchHistory(query string, regex bool) error {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Delete and insert again so a repeated query gets the newest id.
+	stmts := []struct {
+		query string
+		args  []interface{}
+	}{
+		{"DELETE FROM search_history WHERE query = ? AND regex = ?", []interface{}{query, regex}},
+		{"INSERT INTO search_history (query, regex) VALUES (?, ?)", []interface{}{query, regex}},
+		{`DELETE FROM search_history WHERE id NOT IN (
+			SELECT id FROM search_history ORDER BY id DESC LIMIT ?)`, []interface{}{searchHistoryLimit}},
+	}
+	for _, s := range stmts {
+		if _, err := tx.Exec(s.query, s.args...); err != nil {
+			return fmt.Errorf("add search history: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// ListSearchHistory returns up to limit past searches, newest first.
+func (d *DB) ListSearchHistory(limit int) ([]SearchHistoryEntry, error) {
+	rows, err := d.conn.Query(
+		"SELECT id, query, regex, searched_at FROM search_history ORDER BY id DESC LIMIT ?",
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list search history: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []SearchHistoryEntry
+	for rows.Next() {
+		var e SearchHistoryEntry
+		if err := rows.Scan(&e.ID, &e.Query, &e.Regex, &e.SearchedAt); err != nil {
+			return nil, fmt.Errorf("scan search history: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// ClearSearchHistory forgets all past searches. Saved searches are kept.
+func (d *DB) ClearSearchHistory() error {
+	if _, err := d.conn.Exec("DELETE FROM search_history"); err != nil {
+		return fmt.Errorf("clear search history: %w", err)
+	}
+	return nil
+}
+
+// SaveSearch stores a search under name, replacing any search saved under
+// the same name.
+func (d *DB) SaveSearch(name, query string, regex bool) error {
+	name, query = strings.TrimSpace(name), strings.TrimSpace(query)
+	if name == "" || query == "" {
+		return fmt.Errorf("save search: name and query must not be empty")
+	}
+	_, err := d.conn.Exec(
+		"INSERT OR REPLACE INTO saved_searches (name, query, regex) VALUES (?, ?, ?)",
+		name, query, regex,
+	)
+	if err != nil {
+		return fmt.Errorf("save search: %w", err)
+	}
+	return nil
+}
+
+// GetSavedSearch returns the search saved under name, or nil if there is
+// none.
+func (d *DB) GetSavedSearch(name string) (*SavedSearch, error) {
+	s := &SavedSearch{}
+	err := d.conn.QueryRow(
+		"SELECT name, query, regex, created_at FROM saved_searches WHERE name = ?",
+		strings.TrimSpace(name),
+	).Scan(&s.Name, &s.Query, &s.Regex, &s.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get saved search: %w", err)
+	}
+	return s, nil
+}
+
+// ListSavedSearches returns all saved searches ordered by name.
+func (d *DB) ListSavedSearches() ([]SavedSearch, error) {
+	rows, err := d.conn.Query("SELECT name, query, regex, created_at FROM saved_searches ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("list saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	var searches []SavedSearch
+	for rows.Next() {
+		var s SavedSearch
+		if err := rows.Scan(&s.Name, &s.Query, &s.Regex, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan saved search: %w", err)
+		}
+		searches = append(searches, s)
+	}
+	return searches, rows.Err()
+}
+
+// DeleteSavedSearch removes the search saved under name.
+func (d *DB) DeleteSavedSearch(name string) error {
+	res, err := d.conn.Exec("DELETE FROM saved_searches WHERE name = ?", strings.TrimSpace(name))
+	if err != nil {
+		return fmt.Errorf("delete saved search: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("delete saved search: no search named %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestSearchHistory(t *testing.T) {
+	d := setupTestDB(t)
+
+	for _, q := range []string{"사랑", "믿음", " 사랑 ", ""} {
+		if err := d.AddSearchHistory(q, false); err != nil {
+			t.Fatalf("AddSearchHistory(%q): %v", q, err)
+		}
+	}
+	if err := d.AddSearchHistory("사랑", true); err != nil {
+		t.Fatalf("AddSearchHistory regex: %v", err)
+	}
+
+	entries, err := d.ListSearchHistory(10)
+	if err != nil {
+		t.Fatalf("ListSearchHistory: %v", err)
+	}
+	var got []string
+	for _, e := range entries {
+		if e.Regex {
+			got = append(got, "re:"+e.Query)
+		} else {
+			got = append(got, e.Query)
+		}
+	}
+	want := []string{"re:사랑", "사랑", "믿음"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("entry %d: got %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if err := d.ClearSearchHistory(); err != nil {
+		t.Fatalf("ClearSearchHistory: %v", err)
+	}
+	if entries, _ := d.ListSearchHistory(10); len(entries) != 0 {
+		t.Errorf("expected empty history, got %v", entries)
+	}
+}
+
+func TestSearchHistory_Limit(t *testing.T) {
+	d := setupTestDB(t)
+	for i := 0; i < searchHistoryLimit+5; i++ {
+		if err := d.AddSearchHistory(string(rune('가'+i)), false); err != nil {
+			t.Fatalf("AddSearchHistory: %v", err)
+		}
+	}
+	entries, err := d.ListSearchHistory(searchHistoryLimit * 2)
+	if err != nil {
+		t.Fatalf("ListSearchHistory: %v", err)
+	}
+	if len(entries) != searchHistoryLimit {
+		t.Errorf("expected %d entries kept, got %d", searchHistoryLimit, len(entries))
+	}
+	if want := string(rune('가' + searchHistoryLimit + 4)); entries[0].Query != want {
+		t.Errorf("expected newest %q first, got %q", want, entries[0].Query)
+	}
+}
+
+func TestSavedSearches(t *testing.T) {
+	d := setupTestDB(t)
+
+	if err := d.SaveSearch("로마서 믿음", "믿음 book:롬", false); err != nil {
+		t.Fatalf("SaveSearch: %v", err)
+	}
+	if err := d.SaveSearch("여호와", "여호와(의|께서)", true); err != nil {
+		t.Fatalf("SaveSearch: %v", err)
+	}
+	if err := d.SaveSearch("로마서 믿음", "믿음 OR 소망 book:롬", false); err != nil {
+		t.Fatalf("SaveSearch replace: %v", err)
+	}
+	if err := d.SaveSearch("", "x", false); err == nil {
+		t.Error("expected error for an empty name")
+	}
+
+	s, err := d.GetSavedSearch("로마서 믿음")
+	if err != nil || s == nil {
+		t.Fatalf("GetSavedSearch: %v, %v", s, err)
+	}
+	if s.Query != "믿음 OR 소망 book:롬" || s.Regex {
+		t.Errorf("expected the replaced search, got %+v", s)
+	}
+	if s, err := d.GetSavedSearch("없음"); s != nil || err != nil {
+		t.Errorf("expected nil for a missing name, got %v, %v", s, err)
+	}
+
+	list, err := d.ListSavedSearches()
+	if err != nil {
+		t.Fatalf("ListSavedSearches: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "로마서 믿음" || !list[1].Regex {
+		t.Errorf("unexpected saved searches: %+v", list)
+	}
+
+	if err := d.DeleteSavedSearch("여호와"); err != nil {
+		t.Fatalf("DeleteSavedSearch: %v", err)
+	}
+	if err := d.DeleteSavedSearch("여호와"); err == nil {
+		t.Error("expected error deleting a missing search")
+	}
+}
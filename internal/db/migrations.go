@@ -0,0 +1,403 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// ErrSchemaTooNew is returned by Open when the database was migrated by a
+// newer release than this one.
+var ErrSchemaTooNew = errors.New("database schema is newer than this version of bible-tui")
+
+// migration is one forward-only schema change. Each migration runs in its
+// own transaction together with its schema_migrations row.
+type migration struct {
+	version int
+	name    string
+	up      func(tx *sql.Tx) error
+}
+
+// migrations is the ordered list of schema changes. Append new entries with
+// the next version number; never edit or reorder released ones.
+var migrations = []migration{
+	{1, "baseline", execAll(
+		`CREATE TABLE IF NOT EXISTS versions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			code TEXT UNIQUE NOT NULL,
+			name TEXT NOT NULL,
+			lang TEXT NOT NULL DEFAULT 'ko'
+		)`,
+		`CREATE TABLE IF NOT EXISTS books (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			version_id INTEGER NOT NULL REFERENCES versions(id),
+			code TEXT NOT NULL,
+			name_ko TEXT NOT NULL,
+			abbrev_ko TEXT NOT NULL,
+			testament TEXT NOT NULL,
+			chapter_count INTEGER NOT NULL,
+			sort_order INTEGER NOT NULL,
+			UNIQUE(version_id, code)
+		)`,
+		`CREATE TABLE IF NOT EXISTS verses (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			book_id INTEGER NOT NULL REFERENCES books(id),
+			chapter INTEGER NOT NULL,
+			verse_num INTEGER NOT NULL,
+			text TEXT NOT NULL,
+			section_title TEXT,
+			has_footnote BOOLEAN NOT NULL DEFAULT 0,
+			UNIQUE(book_id, chapter, verse_num)
+		)`,
+		`CREATE TABLE IF NOT EXISTS footnotes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			verse_id INTEGER NOT NULL REFERENCES verses(id),
+			marker TEXT,
+			content TEXT NOT NULL
+		)`,
+		`CREATE VIRTUAL TABLE IF NOT EXISTS verses_fts USING fts5(
+			text,
+			content=verses,
+			content_rowid=id,
+			tokenize='unicode61'
+		)`,
+		`CREATE TRIGGER IF NOT EXISTS verses_ai AFTER INSERT ON verses BEGIN
+			INSERT INTO verses_fts(rowid, text) VALUES (new.id, new.text);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS verses_ad AFTER DELETE ON verses BEGIN
+			INSERT INTO verses_fts(verses_fts, rowid, text) VALUES('delete', old.id, old.text);
+		END`,
+		`CREATE TRIGGER IF NOT EXISTS verses_au AFTER UPDATE ON verses BEGIN
+			INSERT INTO verses_fts(verses_fts, rowid, text) VALUES('delete', old.id, old.text);
+			INSERT INTO verses_fts(rowid, text) VALUES (new.id, new.text);
+		END`,
+		`CREATE TABLE IF NOT EXISTS bookmarks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			verse_id INTEGER NOT NULL REFERENCES verses(id),
+			note TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS highlights (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			verse_id INTEGER NOT NULL REFERENCES verses(id),
+			color TEXT NOT NULL DEFAULT 'yellow',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(verse_id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS reading_plans (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			plan_type TEXT NOT NULL,
+			version_id INTEGER NOT NULL REFERENCES versions(id),
+			total_days INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS reading_plan_entries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			plan_id INTEGER NOT NULL REFERENCES reading_plans(id),
+			day_number INTEGER NOT NULL,
+			book_code TEXT NOT NULL,
+			chapter_start INTEGER NOT NULL,
+			chapter_end INTEGER NOT NULL,
+			completed BOOLEAN NOT NULL DEFAULT 0,
+			completed_at DATETIME,
+			UNIQUE(plan_id, day_number, book_code, chapter_start)
+		)`,
+		`CREATE TABLE IF NOT EXISTS settings (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS crawl_status (
+			version_code TEXT NOT NULL,
+			book_code TEXT NOT NULL,
+			chapter INTEGER NOT NULL,
+			status TEXT NOT NULL DEFAULT 'pending',
+			verse_count INTEGER,
+			crawled_at DATETIME,
+			error_msg TEXT,
+			PRIMARY KEY(version_code, book_code, chapter)
+		)`,
+	)},
+	{2, "annotation verse keys", migrateAnnotationKeys},
+	// Reindex search on character bigrams (see ngram.go); the unicode61
+	// word index missed every word with a particle or ending attached.
+	{3, "bigram search index", execAll(
+		`DROP TRIGGER IF EXISTS verses_ai`,
+		`DROP TRIGGER IF EXISTS verses_ad`,
+		`DROP TRIGGER IF EXISTS verses_au`,
+		`DROP TABLE IF EXISTS verses_fts`,
+		`CREATE VIRTUAL TABLE verses_fts USING fts5(
+			grams,
+			tokenize='unicode61'
+		)`,
+		`INSERT INTO verses_fts(rowid, grams) SELECT id, ngrams(text) FROM verses`,
+		`CREATE TRIGGER verses_ai AFTER INSERT ON verses BEGIN
+			INSERT INTO verses_fts(rowid, grams) VALUES (new.id, ngrams(new.text));
+		END`,
+		`CREATE TRIGGER verses_ad AFTER DELETE ON verses BEGIN
+			DELETE FROM verses_fts WHERE rowid = old.id;
+		END`,
+		`CREATE TRIGGER verses_au AFTER UPDATE OF text ON verses BEGIN
+			DELETE FROM verses_fts WHERE rowid = old.id;
+			INSERT INTO verses_fts(rowid, grams) VALUES (new.id, ngrams(new.text));
+		END`,
+	)},
+	{4, "chosung search index", execAll(
+		`CREATE VIRTUAL TABLE verses_chosung USING fts5(
+			grams,
+			tokenize='unicode61'
+		)`,
+		`INSERT INTO verses_chosung(rowid, grams) SELECT id, ngrams(chosung(text)) FROM verses`,
+		`CREATE TRIGGER verses_chosung_ai AFTER INSERT ON verses BEGIN
+			INSERT INTO verses_chosung(rowid, grams) VALUES (new.id, ngrams(chosung(new.text)));
+		END`,
+		`CREATE TRIGGER verses_chosung_ad AFTER DELETE ON verses BEGIN
+			DELETE FROM verses_chosung WHERE rowid = old.id;
+		END`,
+		`CREATE TRIGGER verses_chosung_au AFTER UPDATE OF text ON verses BEGIN
+			DELETE FROM verses_chosung WHERE rowid = old.id;
+			INSERT INTO verses_chosung(rowid, grams) VALUES (new.id, ngrams(chosung(new.text)));
+		END`,
+	)},
+	{5, "search history", execAll(
+		`CREATE TABLE search_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			query TEXT NOT NULL,
+			regex BOOLEAN NOT NULL DEFAULT 0,
+			searched_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(query, regex)
+		)`,
+		`CREATE TABLE saved_searches (
+			name TEXT PRIMARY KEY,
+			query TEXT NOT NULL,
+			regex BOOLEAN NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+	)},
+	{6, "bookmark tags", execAll(
+		`CREATE TABLE tags (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT UNIQUE NOT NULL
+		)`,
+		`CREATE TABLE bookmark_tags (
+			bookmark_id INTEGER NOT NULL REFERENCES bookmarks(id) ON DELETE CASCADE,
+			tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+			PRIMARY KEY(bookmark_id, tag_id)
+		)`,
+		`CREATE INDEX idx_bookmark_tags_tag ON bookmark_tags(tag_id)`,
+	)},
+	// Annotations span from (chapter, verse_num) to (end_chapter,
+	// end_verse) within one book; existing ones cover a single verse.
+	{7, "annotation ranges", execAll(
+		`ALTER TABLE bookmarks ADD COLUMN end_chapter INTEGER NOT NULL DEFAULT 0`,
+		`ALTER TABLE bookmarks ADD COLUMN end_verse INTEGER NOT NULL DEFAULT 0`,
+		`UPDATE bookmarks SET end_chapter = chapter, end_verse = verse_num`,
+		`ALTER TABLE highlights ADD COLUMN end_chapter INTEGER NOT NULL DEFAULT 0`,
+		`ALTER TABLE highlights ADD COLUMN end_verse INTEGER NOT NULL DEFAULT 0`,
+		`UPDATE highlights SET end_chapter = chapter, end_verse = verse_num`,
+	)},
+	// Plans count days from an explicit local start date (YYYY-MM-DD)
+	// instead of created_at, so they can be shifted and start later.
+	{8, "plan start dates", execAll(
+		`ALTER TABLE reading_plans ADD COLUMN start_date TEXT NOT NULL DEFAULT ''`,
+		`UPDATE reading_plans SET start_date = date(created_at, 'localtime')`,
+	)},
+	// One row per chapter opened in the reader, which auto-completes
+	// plan readings once their chapters have been read to the end.
+	{9, "reading log", execAll(
+		`CREATE TABLE reading_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			book_code TEXT NOT NULL,
+			chapter INTEGER NOT NULL,
+			opened_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			seconds INTEGER NOT NULL DEFAULT 0,
+			scrolled_to_end BOOLEAN NOT NULL DEFAULT 0
+		)`,
+		`CREATE INDEX idx_reading_log_chapter ON reading_log(book_code, chapter)`,
+	)},
+}
+
+// SchemaVersion is the schema version this build migrates databases to.
+func SchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+func execAll(stmts ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, stmt := range stmts {
+			if _, err := tx.Exec(stmt); err != nil {
+				return fmt.Errorf("%w\nSQL: %s", err, stmt)
+			}
+		}
+		return nil
+	}
+}
+
+// Migrate applies all pending migrations in order.
+func (d *DB) Migrate() error {
+	return d.migrate(migrations)
+}
+
+func (d *DB) migrate(list []migration) error {
+	if _, err := d.conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("migrate: create schema_migrations: %w", err)
+	}
+
+	current, err := d.CurrentSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if latest := list[len(list)-1].version; current > latest {
+		return fmt.Errorf("migrate: %w (database %d, supported %d)", ErrSchemaTooNew, current, latest)
+	}
+
+	for _, m := range list {
+		if m.version <= current {
+			continue
+		}
+		if err := d.applyMigration(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *DB) applyMigration(m migration) error {
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("migrate %d (%s): begin tx: %w", m.version, m.name, err)
+	}
+	defer tx.Rollback()
+
+	if err := m.up(tx); err != nil {
+		return fmt.Errorf("migrate %d (%s): %w", m.version, m.name, err)
+	}
+	if _, err := tx.Exec(
+		"INSERT INTO schema_migrations (version, name) VALUES (?, ?)",
+		m.version, m.name,
+	); err != nil {
+		return fmt.Errorf("migrate %d (%s): record version: %w", m.version, m.name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("migrate %d (%s): commit: %w", m.version, m.name, err)
+	}
+	return nil
+}
+
+// CurrentSchemaVersion returns the highest applied migration, or 0 for a
+// database that has never been migrated.
+func (d *DB) CurrentSchemaVersion() (int, error) {
+	var exists int
+	if err := d.conn.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'",
+	).Scan(&exists); err != nil {
+		return 0, fmt.Errorf("schema version: %w", err)
+	}
+	if exists == 0 {
+		return 0, nil
+	}
+	var version int
+	if err := d.conn.QueryRow(
+		"SELECT COALESCE(MAX(version), 0) FROM schema_migrations",
+	).Scan(&version); err != nil {
+		return 0, fmt.Errorf("schema version: %w", err)
+	}
+	return version, nil
+}
+
+// checkSchemaVersion refuses databases written by a newer release, whose
+// schema this build does not know how to read.
+func (d *DB) checkSchemaVersion() error {
+	current, err := d.CurrentSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if current > SchemaVersion() {
+		return fmt.Errorf("%w (database %d, supported %d)", ErrSchemaTooNew, current, SchemaVersion())
+	}
+	return nil
+}
+
+// Bookmarks and highlights are keyed by the verse coordinate rather than
+// verses.id, so that re-crawling a book (which recreates its verse rows)
+// leaves user annotations attached to the same verses.
+const (
+	bookmarksColumns = `(
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			version_code TEXT NOT NULL,
+			book_code TEXT NOT NULL,
+			chapter INTEGER NOT NULL,
+			verse_num INTEGER NOT NULL,
+			note TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`
+	highlightsColumns = `(
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			version_code TEXT NOT NULL,
+			book_code TEXT NOT NULL,
+			chapter INTEGER NOT NULL,
+			verse_num INTEGER NOT NULL,
+			color TEXT NOT NULL DEFAULT 'yellow',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(version_code, book_code, chapter, verse_num)
+		)`
+)
+
+// migrateAnnotationKeys rebuilds bookmarks and highlights from the
+// verse_id layout to the coordinate-keyed one. Rows pointing at verses
+// that no longer exist cannot be resolved and are dropped. Tables that
+// already use the new layout are left alone.
+func migrateAnnotationKeys(tx *sql.Tx) error {
+	tables := []struct {
+		name, columns, extra string // extra: columns copied as-is
+	}{
+		{"bookmarks", bookmarksColumns, "note, created_at"},
+		{"highlights", highlightsColumns, "color, created_at"},
+	}
+	for _, t := range tables {
+		legacy, err := hasColumn(tx, t.name, "verse_id")
+		if err != nil {
+			return err
+		}
+		if !legacy {
+			continue
+		}
+		err = execAll(
+			`CREATE TABLE `+t.name+`_new `+t.columns,
+			`INSERT INTO `+t.name+`_new (id, version_code, book_code, chapter, verse_num, `+t.extra+`)
+			 SELECT a.id, ver.code, b.code, v.chapter, v.verse_num, `+t.extra+`
+			 FROM `+t.name+` a
+			 JOIN verses v ON v.id = a.verse_id
+			 JOIN books b ON b.id = v.book_id
+			 JOIN versions ver ON ver.id = b.version_id`,
+			`DROP TABLE `+t.name,
+			`ALTER TABLE `+t.name+`_new RENAME TO `+t.name,
+		)(tx)
+		if err != nil {
+			return fmt.Errorf("rebuild %s: %w", t.name, err)
+		}
+	}
+	return nil
+}
+
+func hasColumn(tx *sql.Tx, table, column string) (bool, error) {
+	rows, err := tx.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return false, fmt.Errorf("table info %s: %w", table, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return false, fmt.Errorf("scan table info: %w", err)
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
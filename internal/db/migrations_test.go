@@ -0,0 +1,170 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// openBaselineFixture creates a file database from the pre-versioning
+// schema fixture, as an existing user's database would look.
+func openBaselineFixture(t *testing.T) *DB {
+	t.Helper()
+	script, err := os.ReadFile("../../testdata/schema_baseline.sql")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "bible.db")
+	d, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { d.Close() })
+	if _, err := d.conn.Exec(string(script)); err != nil {
+		t.Fatalf("load fixture: %v", err)
+	}
+	return d
+}
+
+func TestMigrate_FreshDatabase(t *testing.T) {
+	d := setupTestDB(t)
+
+	version, err := d.CurrentSchemaVersion()
+	if err != nil {
+		t.Fatalf("CurrentSchemaVersion: %v", err)
+	}
+	if version != SchemaVersion() {
+		t.Errorf("expected schema version %d, got %d", SchemaVersion(), version)
+	}
+
+	var count int
+	d.conn.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count)
+	if count != len(migrations) {
+		t.Errorf("expected %d recorded migrations, got %d", len(migrations), count)
+	}
+}
+
+func TestMigrate_UpgradeBaselineFixture(t *testing.T) {
+	d := openBaselineFixture(t)
+
+	if v, _ := d.CurrentSchemaVersion(); v != 0 {
+		t.Fatalf("expected unversioned fixture, got version %d", v)
+	}
+	if err := d.Migrate(); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if v, _ := d.CurrentSchemaVersion(); v != SchemaVersion() {
+		t.Errorf("expected version %d after upgrade, got %d", SchemaVersion(), v)
+	}
+
+	verses, err := d.GetVerses("GAE", "gen", 1)
+	if err != nil || len(verses) != 3 {
+		t.Fatalf("GetVerses: %v (%d verses)", err, len(verses))
+	}
+	footnotes, err := d.GetFootnotes("GAE", "gen", 1)
+	if err != nil || len(footnotes) != 1 {
+		t.Errorf("GetFootnotes: %v (%d footnotes)", err, len(footnotes))
+	}
+
+	bookmarks, err := d.ListBookmarks(10, 0)
+	if err != nil {
+		t.Fatalf("ListBookmarks: %v", err)
+	}
+	if len(bookmarks) != 2 {
+		t.Fatalf("expected 2 bookmarks, got %d", len(bookmarks))
+	}
+	notes := map[int]string{}
+	for _, bm := range bookmarks {
+		notes[bm.VerseNum] = bm.Note
+		if bm.EndChapter != bm.Chapter || bm.EndVerse != bm.VerseNum {
+			t.Errorf("expected a single-verse range, got %+v", bm)
+		}
+	}
+	if notes[1] != "시작" {
+		t.Errorf("expected note on 1:1 preserved, got %v", notes)
+	}
+	if color, _ := d.GetHighlightColor(verses[2].ID); color != "green" {
+		t.Errorf("expected highlight on 1:3 preserved, got %q", color)
+	}
+
+	// Existing verses are reindexed for bigram and chosung search.
+	for _, query := range []string{"창조", "ㅊㅈ"} {
+		if results, err := d.SearchVerses("GAE", query, 10); err != nil || len(results) != 1 {
+			t.Errorf("SearchVerses(%q) after upgrade: %v (%d results)", query, err, len(results))
+		}
+	}
+
+	if theme, _ := d.GetSetting("theme"); theme != "light" {
+		t.Errorf("expected setting preserved, got %q", theme)
+	}
+	if status, _ := d.GetCrawlStatus("GAE", "gen", 1); status != "done" {
+		t.Errorf("expected crawl status preserved, got %q", status)
+	}
+	if done, total, err := d.GetPlanProgress(1); err != nil || done != 0 || total != 1 {
+		t.Errorf("GetPlanProgress: %d/%d, %v", done, total, err)
+	}
+	if plan, err := d.GetPlan(1); err != nil || plan == nil {
+		t.Errorf("GetPlan: %v, %v", plan, err)
+	} else if got, want := plan.StartDate.Format("2006-01-02"), plan.CreatedAt.Local().Format("2006-01-02"); got != want {
+		// start_date is a local date, created_at a UTC timestamp.
+		t.Errorf("expected start date backfilled from the local date of created_at %s, got %s", want, got)
+	}
+
+	// Upgrading again is a no-op.
+	if err := d.Migrate(); err != nil {
+		t.Fatalf("second Migrate: %v", err)
+	}
+}
+
+func TestOpen_RefusesNewerSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bible.db")
+	d, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := d.Migrate(); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if _, err := d.conn.Exec(
+		"INSERT INTO schema_migrations (version, name) VALUES (?, 'from the future')",
+		SchemaVersion()+1,
+	); err != nil {
+		t.Fatalf("insert version: %v", err)
+	}
+	d.Close()
+
+	_, err = Open(path)
+	if !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("expected ErrSchemaTooNew, got %v", err)
+	}
+}
+
+func TestMigrate_FailedMigrationRollsBack(t *testing.T) {
+	d := setupTestDB(t)
+
+	failing := append(append([]migration{}, migrations...), migration{
+		version: SchemaVersion() + 1,
+		name:    "broken",
+		up: func(tx *sql.Tx) error {
+			if _, err := tx.Exec("CREATE TABLE half_done (id INTEGER)"); err != nil {
+				return err
+			}
+			_, err := tx.Exec("INSERT INTO no_such_table VALUES (1)")
+			return err
+		},
+	})
+	if err := d.migrate(failing); err == nil {
+		t.Fatal("expected migration error")
+	}
+
+	if v, _ := d.CurrentSchemaVersion(); v != SchemaVersion() {
+		t.Errorf("expected version to stay %d, got %d", SchemaVersion(), v)
+	}
+	var count int
+	d.conn.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'half_done'").Scan(&count)
+	if count != 0 {
+		t.Error("expected partial migration to be rolled back")
+	}
+}
@@ -2,55 +2,59 @@ package db
 
 import "time"
 
+// The JSON names of these types (and of the types embedding them) are
+// the schema of `--format json` CLI output and of the `bible serve` API;
+// keep them stable.
+
 type Version struct {
-	ID   int64
-	Code string // e.g. "GAE", "HAN"
-	Name string // e.g. "개역개정", "개역한글"
-	Lang string // e.g. "ko"
+	ID   int64  `json:"-"`
+	Code string `json:"code"` // e.g. "GAE", "HAN"
+	Name string `json:"name"` // e.g. "개역개정", "개역한글"
+	Lang string `json:"lang"` // e.g. "ko"
 }
 
 type Book struct {
-	ID           int64
-	VersionID    int64
-	Code         string // e.g. "gen", "exo"
-	NameKo       string // e.g. "창세기"
-	AbbrevKo     string // e.g. "창"
-	Testament    string // "old" or "new"
-	ChapterCount int
-	SortOrder    int
+	ID           int64  `json:"-"`
+	VersionID    int64  `json:"-"`
+	Code         string `json:"code"`      // e.g. "gen", "exo"
+	NameKo       string `json:"name"`      // e.g. "창세기"
+	AbbrevKo     string `json:"abbrev"`    // e.g. "창"
+	Testament    string `json:"testament"` // "old" or "new"
+	ChapterCount int    `json:"chapters"`
+	SortOrder    int    `json:"-"`
 }
 
 type Verse struct {
-	ID           int64
-	BookID       int64
-	Chapter      int
-	VerseNum     int
-	Text         string
-	SectionTitle string // nullable
-	HasFootnote  bool
+	ID           int64  `json:"id"`
+	BookID       int64  `json:"-"`
+	Chapter      int    `json:"chapter"`
+	VerseNum     int    `json:"verse"`
+	Text         string `json:"text"`
+	SectionTitle string `json:"section_title,omitempty"` // nullable
+	HasFootnote  bool   `json:"has_footnote"`
 
 	// joined fields populated by queries
-	BookName string
-	BookCode string
+	BookName string `json:"book_name"`
+	BookCode string `json:"book_code"`
 }
 
 type Footnote struct {
-	ID      int64
-	VerseID int64
-	Marker  string // e.g. "1)", "2)"
-	Content string
+	ID      int64  `json:"-"`
+	VerseID int64  `json:"verse_id"`
+	Marker  string `json:"marker"` // e.g. "1)", "2)"
+	Content string `json:"content"`
 }
 
 type Bookmark struct {
-	ID        int64
-	VerseID   int64
-	Note      string
-	CreatedAt time.Time
+	ID        int64     `json:"id"`
+	VerseID   int64     `json:"verse_id"` // 0 while the chapter is not crawled
+	Note      string    `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Highlight struct {
-	ID        int64
-	VerseID   int64
-	Color     string
-	CreatedAt time.Time
+	ID        int64     `json:"id"`
+	VerseID   int64     `json:"verse_id"` // 0 while the chapter is not crawled
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
 }
@@ -0,0 +1,57 @@
+package db
+
+import (
+	"database/sql/driver"
+	"strings"
+	"unicode"
+
+	"modernc.org/sqlite"
+)
+
+// Korean attaches particles and endings directly to words (사랑 → 사랑하사,
+// 사랑을), so whole-word tokens miss most matches. verses_fts therefore
+// indexes overlapping character bigrams instead of words: each word of a
+// verse becomes its bigrams followed by its last character, e.g.
+//
+//	사랑하사 → 사랑 랑하 하사 사
+//
+// A search word is looked up as the phrase of its own bigrams, which only
+// matches consecutive bigrams inside one verse word, i.e. a substring. A
+// single character is looked up as a token prefix; every character of a
+// word starts one of its tokens. See termNode.fts in query.go.
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("ngrams", 1, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		switch v := args[0].(type) {
+		case string:
+			return ngramTokens(v), nil
+		case []byte:
+			return ngramTokens(string(v)), nil
+		default:
+			return "", nil
+		}
+	})
+}
+
+// searchWords splits text into lower-cased runs of letters and digits.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// ngramTokens returns the indexed form of text: the bigrams of every word
+// followed by the word's last character, space separated.
+func ngramTokens(text string) string {
+	var b strings.Builder
+	for _, word := range searchWords(text) {
+		runes := []rune(word)
+		for i := 0; i+1 < len(runes); i++ {
+			b.WriteString(string(runes[i : i+2]))
+			b.WriteByte(' ')
+		}
+		b.WriteString(string(runes[len(runes)-1]))
+		b.WriteByte(' ')
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,23 @@
+package db
+
+import "testing"
+
+func TestNgramTokens(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"사랑하사", "사랑 랑하 하사 사"},
+		{"빛이 있으라", "빛이 이 있으 으라 라"},
+		{"새 계명을", "새 계명 명을 을"},
+		{"땅이, 혼돈하고!", "땅이 이 혼돈 돈하 하고 고"},
+		{"God is Love", "go od d is s lo ov ve e"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ngramTokens(tt.text); got != tt.want {
+			t.Errorf("ngramTokens(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
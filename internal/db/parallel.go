@@ -0,0 +1,55 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+)
+
+// VersePair is one aligned row of a parallel reading. Either side is nil
+// when the verse number does not exist in that version.
+type VersePair struct {
+	VerseNum  int
+	Primary   *Verse
+	Secondary *Verse
+}
+
+// AlignVerses aligns two chapters by verse number, ordered by verse number.
+func AlignVerses(primary, secondary []Verse) []VersePair {
+	byNum := make(map[int]*VersePair)
+	var nums []int
+	pairFor := func(num int) *VersePair {
+		p, ok := byNum[num]
+		if !ok {
+			p = &VersePair{VerseNum: num}
+			byNum[num] = p
+			nums = append(nums, num)
+		}
+		return p
+	}
+	for i := range primary {
+		pairFor(primary[i].VerseNum).Primary = &primary[i]
+	}
+	for i := range secondary {
+		pairFor(secondary[i].VerseNum).Secondary = &secondary[i]
+	}
+
+	sort.Ints(nums)
+	pairs := make([]VersePair, len(nums))
+	for i, num := range nums {
+		pairs[i] = *byNum[num]
+	}
+	return pairs
+}
+
+// GetParallelVerses loads a chapter in two versions and aligns them by verse number.
+func (d *DB) GetParallelVerses(primaryCode, secondaryCode, bookCode string, chapter int) ([]VersePair, error) {
+	primary, err := d.GetVerses(primaryCode, bookCode, chapter)
+	if err != nil {
+		return nil, fmt.Errorf("get parallel verses (%s): %w", primaryCode, err)
+	}
+	secondary, err := d.GetVerses(secondaryCode, bookCode, chapter)
+	if err != nil {
+		return nil, fmt.Errorf("get parallel verses (%s): %w", secondaryCode, err)
+	}
+	return AlignVerses(primary, secondary), nil
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestAlignVerses(t *testing.T) {
+	primary := []Verse{
+		{VerseNum: 1, Text: "a1"},
+		{VerseNum: 2, Text: "a2"},
+		{VerseNum: 4, Text: "a4"},
+	}
+	secondary := []Verse{
+		{VerseNum: 1, Text: "b1"},
+		{VerseNum: 3, Text: "b3"},
+		{VerseNum: 4, Text: "b4"},
+	}
+
+	pairs := AlignVerses(primary, secondary)
+	if len(pairs) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(pairs))
+	}
+
+	for i, want := range []int{1, 2, 3, 4} {
+		if pairs[i].VerseNum != want {
+			t.Errorf("row %d: expected verse %d, got %d", i, want, pairs[i].VerseNum)
+		}
+	}
+	if pairs[1].Secondary != nil {
+		t.Error("verse 2: expected missing secondary")
+	}
+	if pairs[2].Primary != nil {
+		t.Error("verse 3: expected missing primary")
+	}
+	if pairs[3].Primary.Text != "a4" || pairs[3].Secondary.Text != "b4" {
+		t.Errorf("verse 4: got %q / %q", pairs[3].Primary.Text, pairs[3].Secondary.Text)
+	}
+}
+
+func TestGetParallelVerses(t *testing.T) {
+	d := setupTestDB(t)
+	_, gaeBook := seedTestData(t, d)
+
+	hanID, err := d.InsertVersion("HAN", "개역한글", "ko")
+	if err != nil {
+		t.Fatalf("InsertVersion: %v", err)
+	}
+	hanBook, err := d.InsertBook(hanID, "gen", "창세기", "창", "old", 50, 1)
+	if err != nil {
+		t.Fatalf("InsertBook: %v", err)
+	}
+
+	for n := 1; n <= 3; n++ {
+		if _, err := d.InsertVerse(gaeBook, 1, n, "개정", "", false); err != nil {
+			t.Fatalf("InsertVerse: %v", err)
+		}
+	}
+	for n := 1; n <= 2; n++ {
+		if _, err := d.InsertVerse(hanBook, 1, n, "한글", "", false); err != nil {
+			t.Fatalf("InsertVerse: %v", err)
+		}
+	}
+
+	pairs, err := d.GetParallelVerses("GAE", "HAN", "gen", 1)
+	if err != nil {
+		t.Fatalf("GetParallelVerses: %v", err)
+	}
+	if len(pairs) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(pairs))
+	}
+	if pairs[0].Primary.Text != "개정" || pairs[0].Secondary.Text != "한글" {
+		t.Errorf("row 1: got %q / %q", pairs[0].Primary.Text, pairs[0].Secondary.Text)
+	}
+	if pairs[2].Secondary != nil {
+		t.Error("row 3: expected missing HAN verse")
+	}
+}
@@ -0,0 +1,256 @@
+package db
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
+)
+
+// PlanFile is the file form of a custom reading plan, read by ImportPlan
+// and written by ExportPlan:
+//
+//	{
+//	  "name": "사복음서 40일",
+//	  "start_date": "2026-01-01",
+//	  "days": [
+//	    "마 1-3",
+//	    "마 4-6; 시 1",
+//	    ""
+//	  ]
+//	}
+//
+// The Nth element of days lists what is read on day N, in any form
+// bible.ParseReferences accepts; an empty string is a day off. start_date
+// is optional; without it the plan starts on the day it is imported.
+type PlanFile struct {
+	Name      string   `json:"name"`
+	StartDate string   `json:"start_date,omitempty"`
+	Days      []string `json:"days"`
+}
+
+// PlanFileError is a problem in a plan file, located by line.
+type PlanFileError struct {
+	Line int // 1-based; 0 when the problem is not on a particular line
+	Err  error
+}
+
+func (e *PlanFileError) Error() string {
+	if e.Line == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *PlanFileError) Unwrap() error { return e.Err }
+
+// ParsePlanFile reads and validates a plan file. Every reference is
+// checked, so a plan that parses can be imported; errors are
+// *PlanFileError pointing at the offending line.
+func ParsePlanFile(data []byte) (*PlanFile, error) {
+	p := planFileParser{data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	f, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(f.Name) == "" {
+		return nil, &PlanFileError{Err: errors.New(`missing "name"`)}
+	}
+	if _, err := f.Entries(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Entries returns the plan entries of every day of the file.
+func (f *PlanFile) Entries() ([]PlanEntry, error) {
+	var entries []PlanEntry
+	for i, ref := range f.Days {
+		if strings.TrimSpace(ref) == "" {
+			continue
+		}
+		day, err := PlanEntriesFromReferences(i+1, ref)
+		if err != nil {
+			return nil, fmt.Errorf("day %d: %w", i+1, err)
+		}
+		entries = append(entries, day...)
+	}
+	if len(entries) == 0 {
+		return nil, &PlanFileError{Err: errors.New("plan has no readings")}
+	}
+	return entries, nil
+}
+
+// planFileParser walks a plan file token by token, so that errors can be
+// reported with the line they were found on.
+type planFileParser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+func (p *planFileParser) parse() (*PlanFile, error) {
+	f := &PlanFile{}
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+	for p.dec.More() {
+		tok, err := p.dec.Token()
+		if err != nil {
+			return nil, p.fail(err)
+		}
+		switch key := tok.(string); key {
+		case "name":
+			if err := p.dec.Decode(&f.Name); err != nil {
+				return nil, p.fail(err)
+			}
+		case "start_date":
+			if err := p.dec.Decode(&f.StartDate); err != nil {
+				return nil, p.fail(err)
+			}
+			if _, err := time.Parse(dateLayout, f.StartDate); err != nil {
+				return nil, p.errorf("start_date must be YYYY-MM-DD, got %q", f.StartDate)
+			}
+		case "days":
+			if err := p.parseDays(f); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, p.errorf("unknown field %q", key)
+		}
+	}
+	if err := p.expect('}'); err != nil {
+		return nil, err
+	}
+	if _, err := p.dec.Token(); err != io.EOF {
+		return nil, p.errorf("unexpected data after the plan")
+	}
+	return f, nil
+}
+
+func (p *planFileParser) parseDays(f *PlanFile) error {
+	if err := p.expect('['); err != nil {
+		return err
+	}
+	for p.dec.More() {
+		var ref string
+		if err := p.dec.Decode(&ref); err != nil {
+			return p.fail(err)
+		}
+		day := len(f.Days) + 1
+		if strings.TrimSpace(ref) != "" {
+			if _, err := PlanEntriesFromReferences(day, ref); err != nil {
+				return p.errorf("day %d: %v", day, err)
+			}
+			// The entries merge overlapping ranges, so look for a chapter
+			// in two of the references themselves.
+			ranges, _ := bible.ParseReferences(ref)
+			seen := make(map[string]bool)
+			for _, r := range ranges {
+				for _, ch := range r.Chapters() {
+					key := fmt.Sprintf("%s %d", ch.BookCode, ch.Chapter)
+					if seen[key] {
+						return p.errorf("day %d: %s %d장 is read twice", day, bible.GetBookName(ch.BookCode), ch.Chapter)
+					}
+					seen[key] = true
+				}
+			}
+		}
+		f.Days = append(f.Days, ref)
+	}
+	return p.expect(']')
+}
+
+func (p *planFileParser) expect(delim json.Delim) error {
+	tok, err := p.dec.Token()
+	if err != nil {
+		return p.fail(err)
+	}
+	if tok != delim {
+		return p.errorf("expected %q, got %v", delim, tok)
+	}
+	return nil
+}
+
+// errorf reports a problem at the token the decoder has just read.
+func (p *planFileParser) errorf(format string, args ...any) error {
+	return &PlanFileError{Line: p.lineAt(p.dec.InputOffset()), Err: fmt.Errorf(format, args...)}
+}
+
+// fail turns a decoding error into a PlanFileError on the line it points at.
+func (p *planFileParser) fail(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return &PlanFileError{Line: p.lineAt(syntaxErr.Offset), Err: err}
+	case errors.As(err, &typeErr):
+		// Decode has consumed the mistyped value, which ends here.
+		return &PlanFileError{Line: p.lineAt(p.dec.InputOffset()), Err: fmt.Errorf("expected %s, got %s", typeErr.Type, typeErr.Value)}
+	case err == io.EOF || errors.Is(err, io.ErrUnexpectedEOF):
+		return &PlanFileError{Line: p.lineAt(int64(len(p.data))), Err: errors.New("unexpected end of file")}
+	}
+	return &PlanFileError{Line: p.lineAt(p.dec.InputOffset()), Err: err}
+}
+
+func (p *planFileParser) lineAt(offset int64) int {
+	offset = min(max(offset, 0), int64(len(p.data)))
+	// The offset is just past the token; a token ending a line still
+	// belongs to it.
+	return bytes.Count(bytes.TrimRight(p.data[:offset], " \t\r\n,"), []byte("\n")) + 1
+}
+
+// ImportPlan creates a custom plan from a parsed plan file.
+func (d *DB) ImportPlan(versionID int64, f *PlanFile) (int64, error) {
+	entries, err := f.Entries()
+	if err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	if f.StartDate != "" {
+		if start, err = time.ParseInLocation(dateLayout, f.StartDate, time.Local); err != nil {
+			return 0, fmt.Errorf("import plan: %w", err)
+		}
+	}
+	return d.createCustomPlan(versionID, strings.TrimSpace(f.Name), entries, start)
+}
+
+// ExportPlan returns a plan as a plan file that ImportPlan reads back to
+// the same schedule. Completion is not part of the file.
+func (d *DB) ExportPlan(planID int64) (*PlanFile, error) {
+	plan, err := d.GetPlan(planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, fmt.Errorf("export plan: plan %d not found", planID)
+	}
+	entries, err := d.queryPlanEntries("plan_id = ?", planID)
+	if err != nil {
+		return nil, fmt.Errorf("export plan: %w", err)
+	}
+
+	days := plan.TotalDays
+	for _, e := range entries {
+		days = max(days, e.DayNumber)
+	}
+	ranges := make([][]bible.Range, days)
+	for _, e := range entries {
+		ranges[e.DayNumber-1] = append(ranges[e.DayNumber-1], e.Range())
+	}
+	f := &PlanFile{
+		Name:      plan.Name,
+		StartDate: plan.StartDate.Format(dateLayout),
+		Days:      make([]string, days),
+	}
+	for i, day := range ranges {
+		if len(day) > 0 {
+			f.Days[i] = bible.FormatReferences(day)
+		}
+	}
+	return f, nil
+}
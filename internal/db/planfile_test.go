@@ -0,0 +1,119 @@
+package db
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const samplePlanFile = `{
+  "name": "사복음서 맛보기",
+  "start_date": "2026-01-01",
+  "days": [
+    "마 1-3",
+    "",
+    "마 4; 시 1-2"
+  ]
+}
+`
+
+func TestParsePlanFile(t *testing.T) {
+	f, err := ParsePlanFile([]byte(samplePlanFile))
+	if err != nil {
+		t.Fatalf("ParsePlanFile: %v", err)
+	}
+	if f.Name != "사복음서 맛보기" || f.StartDate != "2026-01-01" || len(f.Days) != 3 {
+		t.Errorf("unexpected plan file %+v", f)
+	}
+	entries, err := f.Entries()
+	if err != nil {
+		t.Fatalf("Entries: %v", err)
+	}
+	want := [][4]any{
+		{1, "mat", 1, 3},
+		{3, "mat", 4, 4},
+		{3, "psa", 1, 2},
+	}
+	if got := entryRefs(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected entries %v, got %v", want, got)
+	}
+}
+
+func TestParsePlanFile_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		line int
+		want string
+	}{
+		{"bad reference", "{\n  \"name\": \"x\",\n  \"days\": [\n    \"창 1\",\n    \"없는책 3\"\n  ]\n}", 5, "day 2:"},
+		{"syntax", "{\n  \"name\": \"x\"\n  \"days\": []\n}", 3, "invalid character"},
+		{"wrong type", "{\n  \"name\": \"x\",\n  \"days\": [\n    3\n  ]\n}", 4, "expected string"},
+		{"unknown field", "{\n  \"name\": \"x\",\n  \"day\": []\n}", 3, `unknown field "day"`},
+		{"start date", "{\n  \"name\": \"x\",\n  \"start_date\": \"1/1\",\n  \"days\": [\"창 1\"]\n}", 3, "start_date"},
+		{"repeated chapter", "{\n  \"name\": \"x\",\n  \"days\": [\n    \"창 1; 마 1; 창 1\"\n  ]\n}", 4, "read twice"},
+		{"overlapping ranges", "{\n  \"name\": \"x\",\n  \"days\": [\n    \"창 1-3; 창 2-4\"\n  ]\n}", 4, "창세기 2장 is read twice"},
+		{"truncated", "{\n  \"name\": \"x\",\n  \"days\": [\n", 3, "unexpected end"},
+		{"empty", "", 1, "unexpected end of file"},
+		{"not an object", "[\"창 1\"]", 1, "expected"},
+		{"missing name", "{\"days\": [\"창 1\"]}", 0, `missing "name"`},
+		{"no readings", "{\"name\": \"x\", \"days\": [\"\", \" \"]}", 0, "no readings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePlanFile([]byte(tt.data))
+			var fileErr *PlanFileError
+			if !errors.As(err, &fileErr) {
+				t.Fatalf("expected *PlanFileError, got %v", err)
+			}
+			if fileErr.Line != tt.line {
+				t.Errorf("expected line %d, got %d (%v)", tt.line, fileErr.Line, err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected %q in %q", tt.want, err.Error())
+			}
+		})
+	}
+}
+
+func TestImportExportPlan(t *testing.T) {
+	d, vID := setupPlanDB(t)
+
+	f, err := ParsePlanFile([]byte(samplePlanFile))
+	if err != nil {
+		t.Fatalf("ParsePlanFile: %v", err)
+	}
+	planID, err := d.ImportPlan(vID, f)
+	if err != nil {
+		t.Fatalf("ImportPlan: %v", err)
+	}
+	plan, err := d.GetPlan(planID)
+	if err != nil || plan == nil {
+		t.Fatalf("GetPlan: %v, %v", plan, err)
+	}
+	if plan.PlanType != "custom" || plan.TotalDays != 3 {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+	if !plan.StartDate.Equal(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("expected start 2026-01-01, got %v", plan.StartDate)
+	}
+
+	exported, err := d.ExportPlan(planID)
+	if err != nil {
+		t.Fatalf("ExportPlan: %v", err)
+	}
+	want := &PlanFile{
+		Name:      "사복음서 맛보기",
+		StartDate: "2026-01-01",
+		Days:      []string{"마태복음 1-3", "", "마태복음 4; 시편 1-2"},
+	}
+	if !reflect.DeepEqual(exported, want) {
+		t.Errorf("expected %+v, got %+v", want, exported)
+	}
+
+	if _, err := d.ExportPlan(999); err == nil {
+		t.Error("expected error exporting a missing plan")
+	}
+}
@@ -9,36 +9,57 @@ import (
 )
 
 type ReadingPlan struct {
-	ID        int64
-	Name      string
-	PlanType  string // "sequential", "mcheyne", "custom"
-	VersionID int64
-	TotalDays int
-	CreatedAt time.Time
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	PlanType  string    `json:"type"` // "sequential", "mcheyne", "custom" or a bible.PlanPreset key
+	VersionID int64     `json:"-"`
+	TotalDays int       `json:"total_days"`
+	StartDate time.Time `json:"start_date"` // local midnight of day 1
+	CreatedAt time.Time `json:"created_at"`
+}
+
+const planColumns = "id, name, plan_type, version_id, total_days, start_date, created_at"
+
+func scanPlan(row interface{ Scan(...any) error }) (ReadingPlan, error) {
+	var p ReadingPlan
+	var startDate string
+	if err := row.Scan(&p.ID, &p.Name, &p.PlanType, &p.VersionID, &p.TotalDays, &startDate, &p.CreatedAt); err != nil {
+		return p, err
+	}
+	start, err := time.ParseInLocation(dateLayout, startDate, time.Local)
+	if err != nil {
+		return p, fmt.Errorf("plan %d start date: %w", p.ID, err)
+	}
+	p.StartDate = start
+	return p, nil
 }
 
 type PlanEntry struct {
-	ID           int64
-	PlanID       int64
-	DayNumber    int
-	BookCode     string
-	ChapterStart int
-	ChapterEnd   int
-	Completed    bool
-	CompletedAt  *time.Time
+	ID           int64      `json:"id"`
+	PlanID       int64      `json:"plan_id"`
+	DayNumber    int        `json:"day"`
+	BookCode     string     `json:"book_code"`
+	ChapterStart int        `json:"chapter_start"`
+	ChapterEnd   int        `json:"chapter_end"`
+	Completed    bool       `json:"completed"`
+	CompletedAt  *time.Time `json:"completed_at"`
+}
+
+// Range returns the chapters of the entry as a reference range.
+func (e PlanEntry) Range() bible.Range {
+	return bible.Range{
+		Start: bible.Location{BookCode: e.BookCode, Chapter: e.ChapterStart},
+		End:   bible.Location{BookCode: e.BookCode, Chapter: e.ChapterEnd},
+	}
 }
 
 func (d *DB) CreateSequentialPlan(versionID int64, name string) (int64, error) {
 	books := bible.AllBooks()
 
-	type chapRef struct {
-		bookCode string
-		chapter  int
-	}
-	var chapters []chapRef
+	var chapters []planChapter
 	for _, b := range books {
 		for ch := 1; ch <= b.ChapterCount; ch++ {
-			chapters = append(chapters, chapRef{b.Code, ch})
+			chapters = append(chapters, planChapter{b.Code, ch})
 		}
 	}
 
@@ -46,7 +67,7 @@ func (d *DB) CreateSequentialPlan(versionID int64, name string) (int64, error) {
 	totalDays := (len(chapters) + chaptersPerDay - 1) / chaptersPerDay
 
 	res, err := d.conn.Exec(
-		"INSERT INTO reading_plans (name, plan_type, version_id, total_days) VALUES (?, 'sequential', ?, ?)",
+		"INSERT INTO reading_plans (name, plan_type, version_id, total_days, start_date) VALUES (?, 'sequential', ?, ?, date('now', 'localtime'))",
 		name, versionID, totalDays,
 	)
 	if err != nil {
@@ -74,28 +95,8 @@ func (d *DB) CreateSequentialPlan(versionID int64, name string) (int64, error) {
 		if end > len(chapters) {
 			end = len(chapters)
 		}
-
-		var curBook string
-		var curStart, curEnd int
-		for i := start; i < end; i++ {
-			ref := chapters[i]
-			if ref.bookCode == curBook && ref.chapter == curEnd+1 {
-				curEnd = ref.chapter
-			} else {
-				if curBook != "" {
-					if _, err := stmt.Exec(planID, day+1, curBook, curStart, curEnd); err != nil {
-						return 0, fmt.Errorf("insert entry: %w", err)
-					}
-				}
-				curBook = ref.bookCode
-				curStart = ref.chapter
-				curEnd = ref.chapter
-			}
-		}
-		if curBook != "" {
-			if _, err := stmt.Exec(planID, day+1, curBook, curStart, curEnd); err != nil {
-				return 0, fmt.Errorf("insert entry: %w", err)
-			}
+		if err := insertDayChapters(stmt, planID, day+1, chapters[start:end]); err != nil {
+			return 0, err
 		}
 	}
 
@@ -109,7 +110,7 @@ func (d *DB) CreateMcCheynePlan(versionID int64, name string) (int64, error) {
 	schedule := bible.McCheyneSchedule()
 
 	res, err := d.conn.Exec(
-		"INSERT INTO reading_plans (name, plan_type, version_id, total_days) VALUES (?, 'mcheyne', ?, ?)",
+		"INSERT INTO reading_plans (name, plan_type, version_id, total_days, start_date) VALUES (?, 'mcheyne', ?, ?, date('now', 'localtime'))",
 		name, versionID, 365,
 	)
 	if err != nil {
@@ -146,6 +147,12 @@ func (d *DB) CreateMcCheynePlan(versionID int64, name string) (int64, error) {
 }
 
 func (d *DB) CreateCustomPlan(versionID int64, name string, entries []PlanEntry) (int64, error) {
+	return d.createCustomPlan(versionID, name, entries, time.Now())
+}
+
+// createCustomPlan creates a custom plan starting on start, with its
+// entries, in one transaction.
+func (d *DB) createCustomPlan(versionID int64, name string, entries []PlanEntry, start time.Time) (int64, error) {
 	maxDay := 0
 	for _, e := range entries {
 		if e.DayNumber > maxDay {
@@ -153,21 +160,67 @@ func (d *DB) CreateCustomPlan(versionID int64, name string, entries []PlanEntry)
 		}
 	}
 
-	res, err := d.conn.Exec(
-		"INSERT INTO reading_plans (name, plan_type, version_id, total_days) VALUES (?, 'custom', ?, ?)",
-		name, versionID, maxDay,
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		"INSERT INTO reading_plans (name, plan_type, version_id, total_days, start_date) VALUES (?, 'custom', ?, ?, ?)",
+		name, versionID, maxDay, start.Format(dateLayout),
 	)
 	if err != nil {
 		return 0, fmt.Errorf("create custom plan: %w", err)
 	}
 	planID, _ := res.LastInsertId()
 
+	stmt, err := tx.Prepare(
+		"INSERT INTO reading_plan_entries (plan_id, day_number, book_code, chapter_start, chapter_end) VALUES (?, ?, ?, ?, ?)",
+	)
+	if err != nil {
+		return 0, fmt.Errorf("prepare stmt: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, e := range entries {
+		if _, err := stmt.Exec(planID, e.DayNumber, e.BookCode, e.ChapterStart, e.ChapterEnd); err != nil {
+			return 0, fmt.Errorf("insert custom entry: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit: %w", err)
+	}
+	return planID, nil
+}
+
+// CreateGeneratedPlan creates a plan from a generator spec, with plan
+// type planType. The plan starts on spec.Start, or today when it is zero.
+func (d *DB) CreateGeneratedPlan(versionID int64, name, planType string, spec bible.PlanSpec) (int64, error) {
+	if spec.Start.IsZero() {
+		spec.Start = time.Now()
+	}
+	schedule, err := bible.GeneratePlan(spec)
+	if err != nil {
+		return 0, err
+	}
+
 	tx, err := d.conn.Begin()
 	if err != nil {
 		return 0, fmt.Errorf("begin tx: %w", err)
 	}
 	defer tx.Rollback()
 
+	res, err := tx.Exec(
+		"INSERT INTO reading_plans (name, plan_type, version_id, total_days, start_date) VALUES (?, ?, ?, ?, ?)",
+		name, planType, versionID, len(schedule), spec.Start.Format(dateLayout),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("create generated plan: %w", err)
+	}
+	planID, _ := res.LastInsertId()
+
 	stmt, err := tx.Prepare(
 		"INSERT INTO reading_plan_entries (plan_id, day_number, book_code, chapter_start, chapter_end) VALUES (?, ?, ?, ?, ?)",
 	)
@@ -176,9 +229,11 @@ func (d *DB) CreateCustomPlan(versionID int64, name string, entries []PlanEntry)
 	}
 	defer stmt.Close()
 
-	for _, e := range entries {
-		if _, err := stmt.Exec(planID, e.DayNumber, e.BookCode, e.ChapterStart, e.ChapterEnd); err != nil {
-			return 0, fmt.Errorf("insert custom entry: %w", err)
+	for day, portions := range schedule {
+		for _, p := range portions {
+			if _, err := stmt.Exec(planID, day+1, p.BookCode, p.ChapterStart, p.ChapterEnd); err != nil {
+				return 0, fmt.Errorf("insert generated entry: %w", err)
+			}
 		}
 	}
 
@@ -188,50 +243,106 @@ func (d *DB) CreateCustomPlan(versionID int64, name string, entries []PlanEntry)
 	return planID, nil
 }
 
+// VerseCounts returns the number of crawled verses of each chapter of a
+// version, as bible.PlanSpec.VerseCounts expects. Chapters not crawled
+// count 0.
+func (d *DB) VerseCounts(versionID int64) (func(bookCode string, chapter int) int, error) {
+	rows, err := d.conn.Query(
+		`SELECT b.code, v.chapter, COUNT(*)
+		 FROM verses v
+		 JOIN books b ON b.id = v.book_id
+		 WHERE b.version_id = ?
+		 GROUP BY b.code, v.chapter`,
+		versionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("verse counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[planChapter]int)
+	for rows.Next() {
+		var c planChapter
+		var n int
+		if err := rows.Scan(&c.bookCode, &c.chapter, &n); err != nil {
+			return nil, fmt.Errorf("scan verse count: %w", err)
+		}
+		counts[c] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return func(bookCode string, chapter int) int {
+		return counts[planChapter{bookCode, chapter}]
+	}, nil
+}
+
 func (d *DB) GetActivePlan(versionID int64) (*ReadingPlan, error) {
-	p := &ReadingPlan{}
-	err := d.conn.QueryRow(
-		"SELECT id, name, plan_type, version_id, total_days, created_at FROM reading_plans WHERE version_id = ? ORDER BY created_at DESC LIMIT 1",
+	p, err := scanPlan(d.conn.QueryRow(
+		"SELECT "+planColumns+" FROM reading_plans WHERE version_id = ? ORDER BY created_at DESC LIMIT 1",
 		versionID,
-	).Scan(&p.ID, &p.Name, &p.PlanType, &p.VersionID, &p.TotalDays, &p.CreatedAt)
+	))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("get active plan: %w", err)
 	}
-	return p, nil
+	return &p, nil
 }
 
-func (d *DB) GetTodayEntries(planID int64) ([]PlanEntry, error) {
-	var createdAt time.Time
-	var totalDays int
-	err := d.conn.QueryRow(
-		"SELECT created_at, total_days FROM reading_plans WHERE id = ?", planID,
-	).Scan(&createdAt, &totalDays)
+// GetPlan returns a plan by ID, or nil if there is none.
+func (d *DB) GetPlan(planID int64) (*ReadingPlan, error) {
+	p, err := scanPlan(d.conn.QueryRow("SELECT "+planColumns+" FROM reading_plans WHERE id = ?", planID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
 	if err != nil {
-		return nil, fmt.Errorf("get plan for today: %w", err)
+		return nil, fmt.Errorf("get plan: %w", err)
 	}
+	return &p, nil
+}
 
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	created := time.Date(createdAt.Year(), createdAt.Month(), createdAt.Day(), 0, 0, 0, 0, createdAt.Location())
-	dayNumber := int(today.Sub(created).Hours()/24) + 1
+// GetTodayEntries returns the readings scheduled for today; see
+// GetEntriesOn.
+func (d *DB) GetTodayEntries(planID int64) ([]PlanEntry, error) {
+	return d.GetEntriesOn(planID, time.Now())
+}
+
+// GetEntriesOn returns the readings scheduled for the plan day falling on
+// date. There are none before the start date; once the plan has ended the
+// last day's readings are returned.
+func (d *DB) GetEntriesOn(planID int64, date time.Time) ([]PlanEntry, error) {
+	plan, err := d.GetPlan(planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, fmt.Errorf("get plan for today: plan %d not found", planID)
+	}
+	dayNumber := plan.DayOn(date)
 	if dayNumber < 1 {
-		dayNumber = 1
+		return nil, nil
 	}
-	if dayNumber > totalDays {
-		dayNumber = totalDays
+	if dayNumber > plan.TotalDays {
+		dayNumber = plan.TotalDays
 	}
+	entries, err := d.queryPlanEntries("plan_id = ? AND day_number = ?", planID, dayNumber)
+	if err != nil {
+		return nil, fmt.Errorf("get today entries: %w", err)
+	}
+	return entries, nil
+}
 
+func (d *DB) queryPlanEntries(where string, args ...any) ([]PlanEntry, error) {
 	rows, err := d.conn.Query(
 		`SELECT id, plan_id, day_number, book_code, chapter_start, chapter_end, completed, completed_at
-		 FROM reading_plan_entries WHERE plan_id = ? AND day_number = ?
-		 ORDER BY id`,
-		planID, dayNumber,
+		 FROM reading_plan_entries WHERE `+where+`
+		 ORDER BY day_number, id`,
+		args...,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("get today entries: %w", err)
+		return nil, err
 	}
 	defer rows.Close()
 
@@ -247,9 +358,23 @@ func (d *DB) GetTodayEntries(planID int64) ([]PlanEntry, error) {
 	return entries, rows.Err()
 }
 
+// GetPlanEntry returns a plan entry by ID, or nil if there is none.
+func (d *DB) GetPlanEntry(entryID int64) (*PlanEntry, error) {
+	entries, err := d.queryPlanEntries("id = ?", entryID)
+	if err != nil {
+		return nil, fmt.Errorf("get plan entry: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[0], nil
+}
+
+// MarkEntryCompleted checks off a reading. An entry already completed
+// keeps the time it was first completed, which the streaks count.
 func (d *DB) MarkEntryCompleted(entryID int64) error {
 	_, err := d.conn.Exec(
-		"UPDATE reading_plan_entries SET completed = 1, completed_at = CURRENT_TIMESTAMP WHERE id = ?",
+		"UPDATE reading_plan_entries SET completed = 1, completed_at = CURRENT_TIMESTAMP WHERE id = ? AND completed = 0",
 		entryID,
 	)
 	if err != nil {
@@ -271,7 +396,7 @@ func (d *DB) GetPlanProgress(planID int64) (completed, total int, err error) {
 
 func (d *DB) ListPlans() ([]ReadingPlan, error) {
 	rows, err := d.conn.Query(
-		"SELECT id, name, plan_type, version_id, total_days, created_at FROM reading_plans ORDER BY created_at DESC, id DESC",
+		"SELECT " + planColumns + " FROM reading_plans ORDER BY created_at DESC, id DESC",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("list plans: %w", err)
@@ -280,8 +405,8 @@ func (d *DB) ListPlans() ([]ReadingPlan, error) {
 
 	var plans []ReadingPlan
 	for rows.Next() {
-		var p ReadingPlan
-		if err := rows.Scan(&p.ID, &p.Name, &p.PlanType, &p.VersionID, &p.TotalDays, &p.CreatedAt); err != nil {
+		p, err := scanPlan(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scan plan: %w", err)
 		}
 		plans = append(plans, p)
@@ -300,3 +425,35 @@ func (d *DB) DeletePlan(planID int64) error {
 	}
 	return nil
 }
+
+// PlanEntriesFromReferences converts a reference list such as
+// "창 1-3; 마 5-7" into the entries for one day of a custom plan, one entry
+// per book. Plan entries cover whole chapters, so verse references are
+// widened to the chapters they touch.
+func PlanEntriesFromReferences(day int, ref string) ([]PlanEntry, error) {
+	ranges, err := bible.ParseReferences(ref)
+	if err != nil {
+		return nil, err
+	}
+	var entries []PlanEntry
+	for _, r := range ranges {
+		for _, ch := range r.Chapters() {
+			if n := len(entries); n > 0 {
+				last := &entries[n-1]
+				if last.BookCode == ch.BookCode && last.ChapterEnd+1 >= ch.Chapter && last.ChapterStart <= ch.Chapter {
+					if ch.Chapter > last.ChapterEnd {
+						last.ChapterEnd = ch.Chapter
+					}
+					continue
+				}
+			}
+			entries = append(entries, PlanEntry{
+				DayNumber:    day,
+				BookCode:     ch.BookCode,
+				ChapterStart: ch.Chapter,
+				ChapterEnd:   ch.Chapter,
+			})
+		}
+	}
+	return entries, nil
+}
@@ -1,7 +1,11 @@
 package db
 
 import (
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
 )
 
 func setupPlanDB(t *testing.T) (*DB, int64) {
@@ -275,3 +279,87 @@ func TestPlanDeletePlan(t *testing.T) {
 		t.Errorf("expected 0 entries after delete, got %d", count)
 	}
 }
+
+func TestPlanCreateGenerated(t *testing.T) {
+	d, vID := setupPlanDB(t)
+
+	// 2026-01-02 is a Friday, so the four reading days take six calendar days.
+	start := time.Date(2026, time.January, 2, 0, 0, 0, 0, time.Local)
+	spec := bible.PlanSpec{Tracks: [][]string{{"php"}}, Days: 4, WeekdaysOnly: true, Start: start}
+	planID, err := d.CreateGeneratedPlan(vID, "빌립보서 4일", "custom", spec)
+	if err != nil {
+		t.Fatalf("CreateGeneratedPlan: %v", err)
+	}
+	plan, err := d.GetPlan(planID)
+	if err != nil || plan == nil {
+		t.Fatalf("GetPlan: %v, %v", plan, err)
+	}
+	if plan.TotalDays != 6 || !plan.StartDate.Equal(start) {
+		t.Errorf("expected 6 days from %v, got %+v", start, plan)
+	}
+	entries, err := d.queryPlanEntries("plan_id = ?", planID)
+	if err != nil {
+		t.Fatalf("queryPlanEntries: %v", err)
+	}
+	want := [][4]any{{1, "php", 1, 1}, {4, "php", 2, 2}, {5, "php", 3, 3}, {6, "php", 4, 4}}
+	if got := entryRefs(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected entries %v, got %v", want, got)
+	}
+
+	if _, err := d.CreateGeneratedPlan(vID, "빈 계획", "custom", bible.PlanSpec{Days: 3}); err == nil {
+		t.Error("expected error for a plan without books")
+	}
+	if plans, _ := d.ListPlans(); len(plans) != 1 {
+		t.Errorf("expected only the first plan saved, got %d", len(plans))
+	}
+}
+
+func TestPlanVerseCounts(t *testing.T) {
+	d, vID := setupPlanDB(t)
+	bookID, err := d.InsertBook(vID, "php", "빌립보서", "빌", "NT", 4, 50)
+	if err != nil {
+		t.Fatalf("InsertBook: %v", err)
+	}
+	for v := 1; v <= 3; v++ {
+		if _, err := d.InsertVerse(bookID, 2, v, "본문", "", false); err != nil {
+			t.Fatalf("InsertVerse: %v", err)
+		}
+	}
+
+	counts, err := d.VerseCounts(vID)
+	if err != nil {
+		t.Fatalf("VerseCounts: %v", err)
+	}
+	if n := counts("php", 2); n != 3 {
+		t.Errorf("expected 3 verses in Philippians 2, got %d", n)
+	}
+	if n := counts("php", 1); n != 0 {
+		t.Errorf("expected 0 verses for a chapter not crawled, got %d", n)
+	}
+}
+
+func TestPlanEntriesFromReferences(t *testing.T) {
+	entries, err := PlanEntriesFromReferences(3, "창 1:26-2:3; 3; 창 50-출 2; 마 5:1, 7")
+	if err != nil {
+		t.Fatalf("PlanEntriesFromReferences: %v", err)
+	}
+
+	expected := []PlanEntry{
+		{DayNumber: 3, BookCode: "gen", ChapterStart: 1, ChapterEnd: 3},
+		{DayNumber: 3, BookCode: "gen", ChapterStart: 50, ChapterEnd: 50},
+		{DayNumber: 3, BookCode: "exo", ChapterStart: 1, ChapterEnd: 2},
+		{DayNumber: 3, BookCode: "mat", ChapterStart: 5, ChapterEnd: 5},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d: %+v", len(expected), len(entries), entries)
+	}
+	for i := range expected {
+		if entries[i] != expected[i] {
+			t.Errorf("entry %d: expected %+v, got %+v", i, expected[i], entries[i])
+		}
+	}
+
+	if _, err := PlanEntriesFromReferences(1, "없는책 1"); err == nil {
+		t.Error("expected error for unknown book")
+	}
+}
@@ -0,0 +1,637 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
+)
+
+// Search query language:
+//
+//	사랑 믿음            both words (AND is implied; "사랑 AND 믿음" also works)
+//	사랑 OR 믿음         either word
+//	사랑 -미움           사랑 but not 미움 ("사랑 NOT 미움" also works)
+//	"세상을 이처럼"      the words in this order
+//	사랑 NEAR/5 믿음     at most 5 words apart (NEAR alone allows 10)
+//	(사랑 OR 긍휼) 믿음  grouping
+//
+// and filters that narrow the passages searched:
+//
+//	book:롬  book:롬,고전  range:마-요  range:롬1-8  chapter:롬8  testament:new
+//
+// Operators are upper case; lower-case "or" is an ordinary word.
+
+// SearchQuery is a search with its filters. Text is the expression to
+// match; Books, Ranges and Testament restrict where. A verse must lie in
+// one of the Books or Ranges (when any are given) and in the Testament.
+type SearchQuery struct {
+	Text      string
+	Books     []string      // book codes
+	Ranges    []bible.Range // passages, as parsed by bible.ParseReferences
+	Testament string        // "old" or "new"
+}
+
+var errOnlyExcluded = errors.New("query needs at least one word that is not excluded")
+
+var searchFilterPattern = regexp.MustCompile(`(?:^|\s)(book|testament|range|chapter):(\S+)`)
+
+const defaultNearDistance = 10
+
+// ParseSearchQuery splits input into its search expression and filters.
+// The expression itself is checked when the query is run.
+func ParseSearchQuery(input string) (SearchQuery, error) {
+	var q SearchQuery
+	var err error
+	text := searchFilterPattern.ReplaceAllStringFunc(input, func(m string) string {
+		sub := searchFilterPattern.FindStringSubmatch(m)
+		if ferr := q.addFilter(sub[1], sub[2]); ferr != nil && err == nil {
+			err = ferr
+		}
+		return " "
+	})
+	if err != nil {
+		return SearchQuery{}, err
+	}
+	q.Text = strings.TrimSpace(text)
+	return q, nil
+}
+
+func (q *SearchQuery) addFilter(key, value string) error {
+	switch key {
+	case "book":
+		for _, name := range strings.Split(value, ",") {
+			book, err := bible.FindBook(name)
+			if err != nil {
+				return fmt.Errorf("book:%s: %w", value, err)
+			}
+			q.Books = append(q.Books, book.Code)
+		}
+	case "testament":
+		t, err := ParseTestament(value)
+		if err != nil {
+			return err
+		}
+		q.Testament = t
+	case "range", "chapter":
+		ranges, err := parseScopeRange(value)
+		if err != nil {
+			return fmt.Errorf("%s:%s: %w", key, value, err)
+		}
+		q.Ranges = append(q.Ranges, ranges...)
+	}
+	return nil
+}
+
+// ParseTestament accepts old/new, ot/nt and 구약/신약.
+func ParseTestament(value string) (string, error) {
+	switch strings.ToLower(value) {
+	case "old", "ot", "구약":
+		return "old", nil
+	case "new", "nt", "신약":
+		return "new", nil
+	}
+	return "", fmt.Errorf("unknown testament %q (use old or new)", value)
+}
+
+// parseScopeRange reads a passage such as "롬8" or "롬1-8", or a span of
+// whole books such as "마-요".
+func parseScopeRange(value string) ([]bible.Range, error) {
+	if strings.ContainsAny(value, "0123456789") {
+		return bible.ParseReferences(value)
+	}
+	startName, endName, _ := strings.Cut(value, "-")
+	start, err := bible.FindBook(startName)
+	if err != nil {
+		return nil, err
+	}
+	end := start
+	if endName != "" {
+		if end, err = bible.FindBook(endName); err != nil {
+			return nil, err
+		}
+	}
+	r := bible.Range{
+		Start: bible.Location{BookCode: start.Code, Chapter: 1},
+		End:   bible.Location{BookCode: end.Code, Chapter: end.ChapterCount},
+	}
+	if len(r.Chapters()) == 0 {
+		return nil, fmt.Errorf("range ends before it starts: %s", value)
+	}
+	return []bible.Range{r}, nil
+}
+
+// scopeClause returns the SQL condition (on books b and verses v) for the
+// query's filters, or "" when there are none.
+func (q SearchQuery) scopeClause() (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+
+	var places []string
+	if len(q.Books) > 0 {
+		places = append(places, "b.code IN (?"+strings.Repeat(", ?", len(q.Books)-1)+")")
+		for _, code := range q.Books {
+			args = append(args, code)
+		}
+	}
+	for _, r := range q.Ranges {
+		// Positions are compared as chapter*1000 + verse; verse 0 at the
+		// start and 999 at the end stand for whole chapters.
+		inRange := false
+		for _, book := range bible.AllBooks() {
+			if book.Code == r.Start.BookCode {
+				inRange = true
+			}
+			if !inRange {
+				continue
+			}
+			from, to := 1000, book.ChapterCount*1000+999
+			if book.Code == r.Start.BookCode {
+				from = r.Start.Chapter*1000 + r.Start.Verse
+			}
+			if book.Code == r.End.BookCode {
+				to = r.End.Chapter*1000 + 999
+				if r.End.Verse != 0 {
+					to = r.End.Chapter*1000 + r.End.Verse
+				}
+			}
+			places = append(places, "(b.code = ? AND v.chapter * 1000 + v.verse_num BETWEEN ? AND ?)")
+			args = append(args, book.Code, from, to)
+			if book.Code == r.End.BookCode {
+				break
+			}
+		}
+	}
+	if len(places) > 0 {
+		conds = append(conds, "("+strings.Join(places, " OR ")+")")
+	}
+
+	if q.Testament != "" {
+		conds = append(conds, "b.testament = ?")
+		args = append(args, q.Testament)
+	}
+	return strings.Join(conds, " AND "), args
+}
+
+// queryNode is a node of a parsed search expression. The expression is
+// compiled to FTS5 to find candidate verses, and matched again against
+// each verse's words, which is exact where FTS5 over bigrams is not (NEAR
+// counts words, not bigrams).
+type queryNode interface {
+	fts() (string, error)
+	match(w verseWords) bool
+}
+
+// verseWords is a verse as the query sees it: its words (see searchWords)
+// joined by single spaces.
+type verseWords struct {
+	joined string
+}
+
+func newVerseWords(text string) verseWords {
+	return verseWords{joined: strings.Join(searchWords(text), " ")}
+}
+
+// termNode is a word, or a phrase of consecutive words. It matches inside
+// words the same way a single word does: "큰 사랑" matches 아주큰 사랑을.
+type termNode struct {
+	words []string
+}
+
+func (t termNode) text() string { return strings.Join(t.words, " ") }
+
+// fts returns the phrase of the term's bigram tokens (see ngramTokens).
+// Every word but the last contributes its final character token too, so
+// the next word must start right after it. A last word of one character is
+// matched as a prefix.
+func (t termNode) fts() (string, error) {
+	var tokens []string
+	last := []rune(t.words[len(t.words)-1])
+	for i, w := range t.words {
+		runes := []rune(w)
+		for j := 0; j+1 < len(runes); j++ {
+			tokens = append(tokens, string(runes[j:j+2]))
+		}
+		if i < len(t.words)-1 || len(runes) == 1 {
+			tokens = append(tokens, string(runes[len(runes)-1]))
+		}
+	}
+	phrase := `"` + strings.Join(tokens, " ") + `"`
+	if len(last) == 1 {
+		phrase += "*"
+	}
+	return phrase, nil
+}
+
+func (t termNode) match(w verseWords) bool {
+	return strings.Contains(w.joined, t.text())
+}
+
+// positions returns the index of the word each occurrence starts in.
+func (t termNode) positions(w verseWords) []int {
+	var pos []int
+	text := t.text()
+	for off := 0; ; {
+		i := strings.Index(w.joined[off:], text)
+		if i < 0 {
+			return pos
+		}
+		pos = append(pos, strings.Count(w.joined[:off+i], " "))
+		off += i + len(text)
+	}
+}
+
+type andNode struct{ children []queryNode }
+
+func (n andNode) fts() (string, error) {
+	var include, exclude []string
+	for _, c := range n.children {
+		target := &include
+		if not, ok := c.(notNode); ok {
+			c, target = not.child, &exclude
+		}
+		s, err := c.fts()
+		if err != nil {
+			return "", err
+		}
+		*target = append(*target, s)
+	}
+	if len(include) == 0 {
+		return "", errOnlyExcluded
+	}
+	expr := "(" + strings.Join(include, " AND ") + ")"
+	for _, s := range exclude {
+		expr += " NOT " + s
+	}
+	return "(" + expr + ")", nil
+}
+
+func (n andNode) match(w verseWords) bool {
+	for _, c := range n.children {
+		if !c.match(w) {
+			return false
+		}
+	}
+	return true
+}
+
+type orNode struct{ children []queryNode }
+
+func (n orNode) fts() (string, error) {
+	parts := make([]string, len(n.children))
+	for i, c := range n.children {
+		s, err := c.fts()
+		if err != nil {
+			return "", err
+		}
+		parts[i] = s
+	}
+	return "(" + strings.Join(parts, " OR ") + ")", nil
+}
+
+func (n orNode) match(w verseWords) bool {
+	for _, c := range n.children {
+		if c.match(w) {
+			return true
+		}
+	}
+	return false
+}
+
+// notNode excludes its child. FTS5 only has a binary NOT, so it is valid
+// only next to something to exclude from, inside an andNode.
+type notNode struct{ child queryNode }
+
+func (n notNode) fts() (string, error)    { return "", errOnlyExcluded }
+func (n notNode) match(w verseWords) bool { return !n.child.match(w) }
+
+// nearNode matches two terms at most distance words apart, in either order.
+type nearNode struct {
+	left, right termNode
+	distance    int
+}
+
+// fts finds verses with both terms; match then checks the distance.
+func (n nearNode) fts() (string, error) {
+	l, _ := n.left.fts()
+	r, _ := n.right.fts()
+	return "(" + l + " AND " + r + ")", nil
+}
+
+func (n nearNode) match(w verseWords) bool {
+	for _, i := range n.left.positions(w) {
+		for _, j := range n.right.positions(w) {
+			gap := j - (i + len(n.left.words))
+			if j < i {
+				gap = i - (j + len(n.right.words))
+			}
+			if gap <= n.distance {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// queryTerms returns the terms a match must contain (not the excluded ones),
+// for highlighting.
+func queryTerms(n queryNode) []termNode {
+	switch n := n.(type) {
+	case termNode:
+		return []termNode{n}
+	case andNode:
+		var terms []termNode
+		for _, c := range n.children {
+			terms = append(terms, queryTerms(c)...)
+		}
+		return terms
+	case orNode:
+		var terms []termNode
+		for _, c := range n.children {
+			terms = append(terms, queryTerms(c)...)
+		}
+		return terms
+	case nearNode:
+		return []termNode{n.left, n.right}
+	}
+	return nil
+}
+
+// allTerms returns every term, excluded ones included.
+func allTerms(n queryNode) []termNode {
+	switch n := n.(type) {
+	case notNode:
+		return allTerms(n.child)
+	case andNode:
+		var terms []termNode
+		for _, c := range n.children {
+			terms = append(terms, allTerms(c)...)
+		}
+		return terms
+	case orNode:
+		var terms []termNode
+		for _, c := range n.children {
+			terms = append(terms, allTerms(c)...)
+		}
+		return terms
+	}
+	return queryTerms(n)
+}
+
+type queryTokenKind int
+
+const (
+	tokWord queryTokenKind = iota
+	tokPhrase
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+	tokNear
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	n    int // NEAR distance
+}
+
+var nearPattern = regexp.MustCompile(`^NEAR(?:/(\d+))?$`)
+
+func tokenizeQuery(input string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t':
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokLParen})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokRParen})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated phrase: %s", string(runes[i:]))
+			}
+			tokens = append(tokens, queryToken{kind: tokPhrase, text: string(runes[i+1 : end])})
+			i = end + 1
+		case r == '-' && i+1 < len(runes) && !strings.ContainsRune(" \t)", runes[i+1]):
+			tokens = append(tokens, queryToken{kind: tokNot})
+			i++
+		default:
+			end := i
+			for end < len(runes) && !strings.ContainsRune(" \t()\"", runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+			tok := queryToken{kind: tokWord, text: word}
+			switch word {
+			case "AND":
+				tok.kind = tokAnd
+			case "OR":
+				tok.kind = tokOr
+			case "NOT":
+				tok.kind = tokNot
+			default:
+				if m := nearPattern.FindStringSubmatch(word); m != nil {
+					tok.kind, tok.n = tokNear, defaultNearDistance
+					if m[1] != "" {
+						tok.n, _ = strconv.Atoi(m[1])
+					}
+				}
+			}
+			tokens = append(tokens, tok)
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// parseSearchExpr parses a search expression. It returns nil when the
+// expression has no searchable words.
+func parseSearchExpr(input string) (queryNode, error) {
+	tokens, err := tokenizeQuery(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &queryParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %s", p.describe(p.tokens[p.pos]))
+	}
+	return node, nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos], true
+	}
+	return queryToken{}, false
+}
+
+func (p *queryParser) describe(t queryToken) string {
+	switch t.kind {
+	case tokLParen:
+		return `"("`
+	case tokRParen:
+		return `")"`
+	case tokAnd:
+		return "AND"
+	case tokOr:
+		return "OR"
+	case tokNot:
+		return "NOT"
+	case tokNear:
+		return "NEAR"
+	}
+	return strconv.Quote(t.text)
+}
+
+// parseOr: and ("OR" and)*
+func (p *queryParser) parseOr() (queryNode, error) {
+	var children []queryNode
+	for {
+		node, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			children = append(children, node)
+		}
+		if t, ok := p.peek(); !ok || t.kind != tokOr {
+			break
+		}
+		p.pos++
+		if _, ok := p.peek(); !ok {
+			return nil, fmt.Errorf("OR needs a word after it")
+		}
+	}
+	switch len(children) {
+	case 0:
+		return nil, nil
+	case 1:
+		return children[0], nil
+	}
+	return orNode{children}, nil
+}
+
+// parseAnd: unary (["AND"] unary)*
+func (p *queryParser) parseAnd() (queryNode, error) {
+	var children []queryNode
+	for {
+		t, ok := p.peek()
+		if !ok || t.kind == tokOr || t.kind == tokRParen {
+			break
+		}
+		if t.kind == tokAnd {
+			if len(children) == 0 {
+				return nil, fmt.Errorf("AND needs a word before it")
+			}
+			p.pos++
+			continue
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if node != nil {
+			children = append(children, node)
+		}
+	}
+	switch len(children) {
+	case 0:
+		return nil, nil
+	case 1:
+		return children[0], nil
+	}
+	return andNode{children}, nil
+}
+
+// parseUnary: ("NOT" | "-") unary | near
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if t, _ := p.peek(); t.kind == tokNot {
+		p.pos++
+		if _, ok := p.peek(); !ok {
+			return nil, fmt.Errorf("NOT needs a word after it")
+		}
+		child, err := p.parseUnary()
+		if err != nil || child == nil {
+			return nil, err
+		}
+		return notNode{child}, nil
+	}
+	return p.parseNear()
+}
+
+// parseNear: primary ["NEAR" primary]
+func (p *queryParser) parseNear() (queryNode, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	t, ok := p.peek()
+	if !ok || t.kind != tokNear {
+		return left, nil
+	}
+	p.pos++
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	l, lok := left.(termNode)
+	r, rok := right.(termNode)
+	if !lok || !rok {
+		return nil, fmt.Errorf("NEAR needs a word or phrase on each side")
+	}
+	if next, ok := p.peek(); ok && next.kind == tokNear {
+		return nil, fmt.Errorf("NEAR joins only two words or phrases")
+	}
+	return nearNode{left: l, right: r, distance: t.n}, nil
+}
+
+// parsePrimary: word | phrase | "(" or ")"
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, fmt.Errorf("unexpected end of query")
+	}
+	p.pos++
+	switch t.kind {
+	case tokWord, tokPhrase:
+		words := searchWords(t.text)
+		if len(words) == 0 {
+			return nil, nil
+		}
+		return termNode{words}, nil
+	case tokLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if next, ok := p.peek(); !ok || next.kind != tokRParen {
+			return nil, fmt.Errorf(`missing ")"`)
+		}
+		p.pos++
+		return node, nil
+	}
+	return nil, fmt.Errorf("unexpected %s", p.describe(t))
+}
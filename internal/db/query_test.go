@@ -0,0 +1,185 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
+)
+
+// searchRefs runs query against setupSearchDB and returns "chapter:verse"
+// of each result, in order.
+func searchRefs(t *testing.T, d *DB, query string) []string {
+	t.Helper()
+	results, err := d.SearchVerses("GAE", query, 20)
+	if err != nil {
+		t.Fatalf("SearchVerses(%q): %v", query, err)
+	}
+	refs := make([]string, len(results))
+	for i, r := range results {
+		refs[i] = fmt.Sprintf("%d:%d", r.Verse.Chapter, r.Verse.VerseNum)
+	}
+	return refs
+}
+
+func assertRefs(t *testing.T, d *DB, query string, want ...string) {
+	t.Helper()
+	got := searchRefs(t, d, query)
+	gotSet := map[string]bool{}
+	for _, r := range got {
+		gotSet[r] = true
+	}
+	if len(got) != len(want) {
+		t.Errorf("%q: got %v, want %v", query, got, want)
+		return
+	}
+	for _, w := range want {
+		if !gotSet[w] {
+			t.Errorf("%q: got %v, want %v", query, got, want)
+			return
+		}
+	}
+}
+
+func TestSearchQuery_And(t *testing.T) {
+	d := setupSearchDB(t)
+	defer d.Close()
+
+	assertRefs(t, d, "하나님 빛", "1:3", "1:4")
+	assertRefs(t, d, "하나님 AND 빛", "1:3", "1:4")
+}
+
+func TestSearchQuery_Or(t *testing.T) {
+	d := setupSearchDB(t)
+	defer d.Close()
+
+	assertRefs(t, d, "창조하시니라 OR 독생자", "1:1", "3:16")
+	// Lower-case "or" is a word, not an operator.
+	assertRefs(t, d, "창조하시니라 or 독생자")
+}
+
+func TestSearchQuery_Exclude(t *testing.T) {
+	d := setupSearchDB(t)
+	defer d.Close()
+
+	assertRefs(t, d, "하나님 -빛", "1:1", "1:2", "3:16")
+	assertRefs(t, d, "하나님 NOT 빛", "1:1", "1:2", "3:16")
+	assertRefs(t, d, "-빛 하나님", "1:1", "1:2", "3:16")
+}
+
+func TestSearchQuery_Phrase(t *testing.T) {
+	d := setupSearchDB(t)
+	defer d.Close()
+
+	assertRefs(t, d, `"세상을 이처럼"`, "3:16")
+	assertRefs(t, d, `"이처럼 세상을"`)
+	// Phrases match inside words at their edges, like single words.
+	assertRefs(t, d, `"상을 이처"`, "3:16")
+	assertRefs(t, d, `"서로 사랑하라"`, "13:34")
+
+	results, err := d.SearchVerses("GAE", `"서로 사랑하라"`, 10)
+	if err != nil {
+		t.Fatalf("SearchVerses: %v", err)
+	}
+	if len(results) != 1 || results[0].MatchCount != 2 || results[0].Highlights[0] != "서로 사랑하라" {
+		t.Errorf("expected the phrase highlighted twice, got %+v", results)
+	}
+}
+
+func TestSearchQuery_Near(t *testing.T) {
+	d := setupSearchDB(t)
+	defer d.Close()
+
+	// 하나님이 세상을 이처럼 사랑하사: two words between.
+	assertRefs(t, d, "하나님 NEAR/2 사랑하사", "3:16")
+	assertRefs(t, d, "사랑하사 NEAR/2 하나님", "3:16")
+	assertRefs(t, d, "하나님 NEAR/1 사랑하사")
+	assertRefs(t, d, "하나님 NEAR 사랑하사", "3:16")
+}
+
+func TestSearchQuery_Grouping(t *testing.T) {
+	d := setupSearchDB(t)
+	defer d.Close()
+
+	assertRefs(t, d, "(빛 OR 사랑) 하나님", "1:3", "1:4", "3:16")
+	assertRefs(t, d, "하나님 -(빛 OR 땅)", "1:1", "3:16")
+}
+
+func TestSearchQuery_Scopes(t *testing.T) {
+	d := setupSearchDB(t)
+	defer d.Close()
+
+	assertRefs(t, d, "book:요 하나님", "3:16")
+	assertRefs(t, d, "book:창,요 사랑", "3:16", "13:34", "15:9")
+	assertRefs(t, d, "하나님 testament:old", "1:1", "1:2", "1:3", "1:4")
+	assertRefs(t, d, "range:마-요 하나님", "3:16")
+	assertRefs(t, d, "range:요3-13 사랑", "3:16", "13:34")
+	assertRefs(t, d, "range:창1:2-4 빛", "1:3", "1:4")
+	assertRefs(t, d, "chapter:요15 사랑", "15:9")
+
+	page, err := d.Search("GAE", SearchQuery{Text: "하나님", Books: []string{"jhn"}}, SearchOptions{Limit: 10})
+	if err != nil || page.Total != 1 {
+		t.Errorf("Search with Books: %v (%+v)", err, page)
+	}
+	page, err = d.Search("GAE", SearchQuery{Text: "사랑", Testament: "new", Books: []string{"gen"}}, SearchOptions{Limit: 10})
+	if err != nil || page.Total != 0 {
+		t.Errorf("Search with Testament and Books: %v (%+v)", err, page)
+	}
+}
+
+func TestSearchQuery_ChosungOperators(t *testing.T) {
+	d := setupSearchDB(t)
+	defer d.Close()
+
+	assertRefs(t, d, "ㅊㅈ OR ㄷㅅㅈ", "1:1", "3:16")
+	assertRefs(t, d, "ㅎㄴㄴ -ㅂ book:창", "1:1", "1:2")
+}
+
+func TestSearchQuery_Errors(t *testing.T) {
+	d := setupSearchDB(t)
+	defer d.Close()
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`"세상을 이처럼`, "unterminated phrase"},
+		{"(사랑 믿음", `missing ")"`},
+		{"사랑)", `unexpected ")"`},
+		{"-사랑", "not excluded"},
+		{"사랑 OR -믿음", "not excluded"},
+		{"사랑 OR", "OR needs a word"},
+		{"AND 사랑", "AND needs a word"},
+		{"NEAR 사랑", "unexpected NEAR"},
+		{"사랑 NEAR (믿음 OR 소망)", "NEAR needs a word or phrase"},
+		{"사랑 testament:middle", "unknown testament"},
+		{"사랑 book:없는책", "unknown book"},
+		{"사랑 range:요-마", "range ends before it starts"},
+	}
+	for _, tt := range tests {
+		_, err := d.SearchVerses("GAE", tt.query, 10)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%q: expected error containing %q, got %v", tt.query, tt.want, err)
+		}
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	q, err := ParseSearchQuery("book:롬 사랑 testament:new chapter:고전13 \"오래 참고\"")
+	if err != nil {
+		t.Fatalf("ParseSearchQuery: %v", err)
+	}
+	if strings.Join(strings.Fields(q.Text), " ") != `사랑 "오래 참고"` {
+		t.Errorf("unexpected text %q", q.Text)
+	}
+	if len(q.Books) != 1 || q.Books[0] != "rom" {
+		t.Errorf("unexpected books %v", q.Books)
+	}
+	if q.Testament != "new" {
+		t.Errorf("unexpected testament %q", q.Testament)
+	}
+	if len(q.Ranges) != 1 || q.Ranges[0].Start != (bible.Location{BookCode: "1co", Chapter: 13}) {
+		t.Errorf("unexpected ranges %v", q.Ranges)
+	}
+}
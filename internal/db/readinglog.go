@@ -0,0 +1,144 @@
+package db
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReadingSession is a chapter opened in the reader.
+type ReadingSession struct {
+	ID            int64     `json:"id"`
+	BookCode      string    `json:"book_code"`
+	Chapter       int       `json:"chapter"`
+	OpenedAt      time.Time `json:"opened_at"`
+	Seconds       int       `json:"seconds"`
+	ScrolledToEnd bool      `json:"scrolled_to_end"`
+}
+
+// timestampLayout is how opened_at is stored: in UTC, like
+// CURRENT_TIMESTAMP, so that date(opened_at, 'localtime') works.
+const timestampLayout = "2006-01-02 15:04:05"
+
+// StartReading logs a chapter opened in the reader at openedAt and
+// returns the ID of its session. The reader passes its own clock, which
+// also times the session.
+func (d *DB) StartReading(bookCode string, chapter int, openedAt time.Time) (int64, error) {
+	res, err := d.conn.Exec(
+		"INSERT INTO reading_log (book_code, chapter, opened_at) VALUES (?, ?, ?)",
+		bookCode, chapter, openedAt.UTC().Format(timestampLayout),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("start reading: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+// UpdateReading records the time spent in a reading session so far and
+// whether it reached the end of the chapter. A session that once reached
+// the end keeps it.
+func (d *DB) UpdateReading(sessionID int64, seconds int, scrolledToEnd bool) error {
+	res, err := d.conn.Exec(
+		"UPDATE reading_log SET seconds = ?, scrolled_to_end = scrolled_to_end OR ? WHERE id = ?",
+		seconds, scrolledToEnd, sessionID,
+	)
+	if err != nil {
+		return fmt.Errorf("update reading: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("reading session %d not found", sessionID)
+	}
+	return nil
+}
+
+// ListReadingLog returns the most recent reading sessions, newest first.
+func (d *DB) ListReadingLog(limit int) ([]ReadingSession, error) {
+	rows, err := d.conn.Query(
+		`SELECT id, book_code, chapter, opened_at, seconds, scrolled_to_end
+		 FROM reading_log ORDER BY opened_at DESC, id DESC LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list reading log: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []ReadingSession
+	for rows.Next() {
+		var s ReadingSession
+		if err := rows.Scan(&s.ID, &s.BookCode, &s.Chapter, &s.OpenedAt, &s.Seconds, &s.ScrolledToEnd); err != nil {
+			return nil, fmt.Errorf("scan reading session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+// CompleteReadEntries checks off the readings that cover a chapter once
+// every chapter of them has been read to the end since their plan
+// started, and returns them. Only readings due by date today are checked
+// off; reading ahead does not tick off later days.
+func (d *DB) CompleteReadEntries(bookCode string, chapter int, today time.Time) ([]PlanEntry, error) {
+	candidates, err := d.queryPlanEntries(
+		"completed = 0 AND book_code = ? AND chapter_start <= ? AND chapter_end >= ?",
+		bookCode, chapter, chapter,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("complete read entries: %w", err)
+	}
+
+	plans := map[int64]*ReadingPlan{}
+	var done []PlanEntry
+	for _, e := range candidates {
+		plan, ok := plans[e.PlanID]
+		if !ok {
+			if plan, err = d.GetPlan(e.PlanID); err != nil {
+				return nil, err
+			}
+			plans[e.PlanID] = plan
+		}
+		if plan == nil || e.DayNumber > plan.DayOn(today) {
+			continue
+		}
+
+		var read int
+		err := d.conn.QueryRow(
+			`SELECT COUNT(DISTINCT chapter) FROM reading_log
+			 WHERE book_code = ? AND chapter BETWEEN ? AND ? AND scrolled_to_end = 1
+			   AND date(opened_at, 'localtime') >= ?`,
+			e.BookCode, e.ChapterStart, e.ChapterEnd, plan.StartDate.Format(dateLayout),
+		).Scan(&read)
+		if err != nil {
+			return nil, fmt.Errorf("count read chapters: %w", err)
+		}
+		if read < e.ChapterEnd-e.ChapterStart+1 {
+			continue
+		}
+		if err := d.MarkEntryCompleted(e.ID); err != nil {
+			return nil, err
+		}
+		e.Completed = true
+		done = append(done, e)
+	}
+	return done, nil
+}
+
+// UncompleteEntries undoes checking off plan readings.
+func (d *DB) UncompleteEntries(entryIDs ...int64) error {
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, id := range entryIDs {
+		if _, err := tx.Exec(
+			"UPDATE reading_plan_entries SET completed = 0, completed_at = NULL WHERE id = ?", id,
+		); err != nil {
+			return fmt.Errorf("uncomplete entry: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReadingLog(t *testing.T) {
+	d, _ := setupPlanDB(t)
+
+	openedAt := time.Date(2026, time.March, 1, 7, 30, 15, 0, time.Local)
+	id, err := d.StartReading("gen", 1, openedAt)
+	if err != nil {
+		t.Fatalf("StartReading: %v", err)
+	}
+	if err := d.UpdateReading(id, 40, true); err != nil {
+		t.Fatalf("UpdateReading: %v", err)
+	}
+	// Leaving the chapter later records the time without losing the end.
+	if err := d.UpdateReading(id, 95, false); err != nil {
+		t.Fatalf("UpdateReading: %v", err)
+	}
+	if err := d.UpdateReading(999, 1, false); err == nil {
+		t.Error("expected error for a missing session")
+	}
+
+	sessions, err := d.ListReadingLog(10)
+	if err != nil {
+		t.Fatalf("ListReadingLog: %v", err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+	s := sessions[0]
+	if s.BookCode != "gen" || s.Chapter != 1 || s.Seconds != 95 || !s.ScrolledToEnd || !s.OpenedAt.Equal(openedAt) {
+		t.Errorf("unexpected session %+v", s)
+	}
+}
+
+// readChapter logs a chapter read to the end.
+func readChapter(t *testing.T, d *DB, bookCode string, chapter int) {
+	t.Helper()
+	id, err := d.StartReading(bookCode, chapter, time.Now())
+	if err != nil {
+		t.Fatalf("StartReading: %v", err)
+	}
+	if err := d.UpdateReading(id, 60, true); err != nil {
+		t.Fatalf("UpdateReading: %v", err)
+	}
+}
+
+func TestCompleteReadEntries(t *testing.T) {
+	// Two chapters of Genesis a day from today: 1-2, 3-4, ...
+	d, planID := setupSchedulePlan(t)
+	today := time.Now()
+	if err := d.SetPlanStartDate(planID, today); err != nil {
+		t.Fatalf("SetPlanStartDate: %v", err)
+	}
+
+	// Half of day 1 is not enough.
+	readChapter(t, d, "gen", 1)
+	done, err := d.CompleteReadEntries("gen", 1, today)
+	if err != nil || len(done) != 0 {
+		t.Fatalf("expected nothing completed after Genesis 1, got %+v, %v", done, err)
+	}
+
+	// A chapter opened without reaching its end does not count.
+	if _, err := d.StartReading("gen", 2, today); err != nil {
+		t.Fatal(err)
+	}
+	if done, _ := d.CompleteReadEntries("gen", 2, today); len(done) != 0 {
+		t.Fatalf("expected nothing completed for an unfinished chapter, got %+v", done)
+	}
+
+	readChapter(t, d, "gen", 2)
+	done, err = d.CompleteReadEntries("gen", 2, today)
+	if err != nil {
+		t.Fatalf("CompleteReadEntries: %v", err)
+	}
+	if got := entryRefs(done); len(got) != 1 || got[0] != [4]any{1, "gen", 1, 2} || !done[0].Completed {
+		t.Fatalf("expected day 1 completed, got %v", got)
+	}
+	if completed, _, _ := d.GetPlanProgress(planID); completed != 1 {
+		t.Errorf("expected 1 completed entry, got %d", completed)
+	}
+
+	// Reading ahead does not check off day 2.
+	readChapter(t, d, "gen", 3)
+	readChapter(t, d, "gen", 4)
+	if done, _ := d.CompleteReadEntries("gen", 4, today); len(done) != 0 {
+		t.Errorf("expected day 2 left for tomorrow, got %+v", done)
+	}
+	tomorrow := today.AddDate(0, 0, 1)
+	if done, _ := d.CompleteReadEntries("gen", 4, tomorrow); len(done) != 1 {
+		t.Errorf("expected day 2 completed once due, got %+v", done)
+	}
+
+	// Undo.
+	if err := d.UncompleteEntries(done[0].ID); err != nil {
+		t.Fatalf("UncompleteEntries: %v", err)
+	}
+	e, _ := d.GetPlanEntry(done[0].ID)
+	if e.Completed || e.CompletedAt != nil {
+		t.Errorf("expected entry unchecked, got %+v", e)
+	}
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"modernc.org/sqlite"
+)
+
+// Regex search matches verse text against a Go regular expression, for
+// patterns the query language cannot express: 여호와(의|께서), ^그러므로.
+// SQLite's REGEXP operator calls the regexp(pattern, text) function
+// registered here.
+
+// regexCache keeps compiled patterns, since REGEXP is called once per
+// verse with the same pattern. It is emptied when it grows past
+// regexCacheSize.
+var (
+	regexCacheMu sync.Mutex
+	regexCache   = make(map[string]*regexp.Regexp)
+)
+
+const regexCacheSize = 64
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction("regexp", 2, func(ctx *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		pattern, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("regexp: pattern must be text")
+		}
+		re, err := compileRegex(pattern)
+		if err != nil {
+			return nil, err
+		}
+		switch v := args[1].(type) {
+		case string:
+			return re.MatchString(v), nil
+		case []byte:
+			return re.Match(v), nil
+		default:
+			return false, nil
+		}
+	})
+}
+
+func compileRegex(pattern string) (*regexp.Regexp, error) {
+	regexCacheMu.Lock()
+	defer regexCacheMu.Unlock()
+	if re, ok := regexCache[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %w", err)
+	}
+	if len(regexCache) >= regexCacheSize {
+		clear(regexCache)
+	}
+	regexCache[pattern] = re
+	return re, nil
+}
+
+// searchRegex is Search for opts.Regex: q.Text is a regular expression.
+// By relevance, verses with more matches come first.
+func (d *DB) searchRegex(versionCode string, q SearchQuery, opts SearchOptions) (*SearchPage, error) {
+	if q.Text == "" {
+		return &SearchPage{Results: []SearchResult{}}, nil
+	}
+	re, err := compileRegex(q.Text)
+	if err != nil {
+		return nil, fmt.Errorf("search query: %w", err)
+	}
+
+	where := "ver.code = ? AND v.text REGEXP ?"
+	args := []interface{}{versionCode, q.Text}
+	if scope, scopeArgs := q.scopeClause(); scope != "" {
+		where += " AND " + scope
+		args = append(args, scopeArgs...)
+	}
+
+	rows, err := d.conn.Query(
+		`SELECT `+searchColumns+`
+		 FROM verses v
+		 JOIN books b ON b.id = v.book_id
+		 JOIN versions ver ON ver.id = b.version_id
+		 WHERE `+where+`
+		 ORDER BY b.sort_order, v.chapter, v.verse_num`,
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("search verses: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []searchHit
+	matches := make(map[int64]int)
+	for rows.Next() {
+		h, err := scanSearchHit(rows)
+		if err != nil {
+			return nil, err
+		}
+		hits = append(hits, h)
+		matches[h.verse.ID] = len(regexSpans(re, h.verse.Text))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search verses: %w", err)
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return matches[hits[i].verse.ID] > matches[hits[j].verse.ID]
+	})
+
+	return pageOf(hits, opts, func(v Verse) SearchResult {
+		return newRegexResult(v, re)
+	}), nil
+}
+
+// regexSpans returns the non-empty matches of re in text.
+func regexSpans(re *regexp.Regexp, text string) []string {
+	var spans []string
+	for _, m := range re.FindAllString(text, -1) {
+		if m != "" {
+			spans = append(spans, m)
+		}
+	}
+	return spans
+}
+
+// newRegexResult is newSearchResult for a regular expression.
+func newRegexResult(v Verse, re *regexp.Regexp) SearchResult {
+	spans := regexSpans(re, v.Text)
+	var highlights []string
+	seen := make(map[string]bool)
+	for _, span := range spans {
+		if !seen[span] {
+			seen[span] = true
+			highlights = append(highlights, span)
+		}
+	}
+	snippetTerm := ""
+	if len(spans) > 0 {
+		snippetTerm = spans[0]
+	}
+	return SearchResult{
+		Verse:      v,
+		Snippet:    generateSnippet(v.Text, snippetTerm, 30),
+		MatchCount: len(spans),
+		Highlights: highlights,
+	}
+}
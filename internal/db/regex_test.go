@@ -0,0 +1,82 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSearch_Regex(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	page, err := db.Search("GAE", SearchQuery{Text: "^하나님이"}, SearchOptions{Regex: true, Sort: SortCanonical})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	var refs []string
+	for _, r := range page.Results {
+		refs = append(refs, fmt.Sprintf("%s %d:%d", r.Verse.BookCode, r.Verse.Chapter, r.Verse.VerseNum))
+	}
+	if want := []string{"gen 1:3", "gen 1:4", "jhn 3:16"}; !reflect.DeepEqual(refs, want) {
+		t.Errorf("got %v, want %v", refs, want)
+	}
+
+	page, err = db.Search("GAE", SearchQuery{Text: "사랑(하라|한)"}, SearchOptions{Regex: true})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if page.Total != 1 {
+		t.Fatalf("expected 1 verse, got %d", page.Total)
+	}
+	r := page.Results[0]
+	if r.MatchCount != 3 || !reflect.DeepEqual(r.Highlights, []string{"사랑하라", "사랑한"}) {
+		t.Errorf("expected 3 matches highlighting 사랑하라 and 사랑한, got %d %v", r.MatchCount, r.Highlights)
+	}
+}
+
+func TestSearch_RegexRelevanceAndScope(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	page, err := db.Search("GAE", SearchQuery{Text: "하나님"}, SearchOptions{Regex: true})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if page.Total != 5 || page.Results[0].Verse.VerseNum != 4 || page.Results[1].Verse.VerseNum != 1 {
+		t.Errorf("expected gen 1:4 (two matches) first, then Bible order, got %+v", page.Results)
+	}
+
+	page, err = db.Search("GAE", SearchQuery{Text: "하나님", Books: []string{"jhn"}}, SearchOptions{Regex: true})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if page.Total != 1 || page.Results[0].Verse.BookCode != "jhn" {
+		t.Errorf("expected one result in John, got %+v", page.Results)
+	}
+}
+
+func TestSearch_RegexInvalid(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	_, err := db.Search("GAE", SearchQuery{Text: "사랑("}, SearchOptions{Regex: true})
+	if err == nil || !strings.Contains(err.Error(), "invalid regex") {
+		t.Errorf("expected invalid regex error, got %v", err)
+	}
+}
+
+func TestRegexpFunction(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	var n int
+	err := db.conn.QueryRow(`SELECT COUNT(*) FROM verses WHERE text REGEXP '(하나님|사랑)하'`).Scan(&n)
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 verses, got %d", n)
+	}
+}
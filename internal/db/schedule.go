@@ -0,0 +1,269 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// dateLayout is how plan start dates are stored.
+const dateLayout = "2006-01-02"
+
+// planChapter is one chapter of a reading plan before it is grouped into
+// entries.
+type planChapter struct {
+	bookCode string
+	chapter  int
+}
+
+// PlanStatus is where a reader stands in a plan on a given date.
+type PlanStatus struct {
+	// Day is the plan day falling on the date: 1 on the start date, less
+	// before it and more than TotalDays once the plan has ended.
+	Day int `json:"day"`
+	// BehindDays is how far the earliest unfinished reading is behind
+	// Day, which is also how far ShiftPlan must move the schedule to
+	// catch up. It is 0 when nothing before Day is left unread.
+	BehindDays int `json:"behind_days"`
+	// Overdue lists the unfinished readings of the days before Day.
+	Overdue []PlanEntry `json:"overdue"`
+}
+
+// PlanStreak counts the days in a row on which readings of a plan were
+// checked off.
+type PlanStreak struct {
+	// Current is the streak that is still going: it ends today, or
+	// yesterday while nothing has been checked off today yet.
+	Current int `json:"current"`
+	Longest int `json:"longest"`
+}
+
+// DayOn returns the plan day falling on date t, counted in calendar days
+// from StartDate so that daylight saving changes do not skip a day.
+func (p ReadingPlan) DayOn(t time.Time) int {
+	return daysBetween(p.StartDate, t) + 1
+}
+
+func daysBetween(from, to time.Time) int {
+	a := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	b := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(b.Sub(a).Hours() / 24)
+}
+
+// GetPlanStatus reports how far behind schedule a plan is on date today.
+func (d *DB) GetPlanStatus(planID int64, today time.Time) (*PlanStatus, error) {
+	plan, err := d.GetPlan(planID)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, fmt.Errorf("get plan status: plan %d not found", planID)
+	}
+	status := &PlanStatus{Day: plan.DayOn(today)}
+	status.Overdue, err = d.queryPlanEntries("plan_id = ? AND completed = 0 AND day_number < ?", planID, status.Day)
+	if err != nil {
+		return nil, fmt.Errorf("get overdue entries: %w", err)
+	}
+	if len(status.Overdue) > 0 {
+		status.BehindDays = status.Day - status.Overdue[0].DayNumber
+	}
+	return status, nil
+}
+
+// SetPlanStartDate makes date day 1 of a plan.
+func (d *DB) SetPlanStartDate(planID int64, date time.Time) error {
+	res, err := d.conn.Exec(
+		"UPDATE reading_plans SET start_date = ? WHERE id = ?",
+		date.Format(dateLayout), planID,
+	)
+	if err != nil {
+		return fmt.Errorf("set plan start date: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("set plan start date: plan %d not found", planID)
+	}
+	return nil
+}
+
+// ShiftPlan moves the whole schedule of a plan forward by days, or back
+// for a negative count, by moving its start date.
+func (d *DB) ShiftPlan(planID int64, days int) error {
+	res, err := d.conn.Exec(
+		"UPDATE reading_plans SET start_date = date(start_date, ?) WHERE id = ?",
+		fmt.Sprintf("%+d days", days), planID,
+	)
+	if err != nil {
+		return fmt.Errorf("shift plan: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("shift plan: plan %d not found", planID)
+	}
+	return nil
+}
+
+// RedistributePlan spreads the chapters of every unfinished reading, in
+// order, evenly over the reading days from today to the plan's last day,
+// so the plan still ends on time. Only days that already have readings
+// get any, which keeps the days off of a weekday-only plan free.
+// Completed readings stay where they are.
+func (d *DB) RedistributePlan(planID int64, today time.Time) error {
+	plan, err := d.GetPlan(planID)
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		return fmt.Errorf("redistribute plan: plan %d not found", planID)
+	}
+	firstDay := max(plan.DayOn(today), 1)
+	if firstDay > plan.TotalDays {
+		return fmt.Errorf("redistribute plan: plan %d has already ended", planID)
+	}
+
+	remaining, err := d.queryPlanEntries("plan_id = ? AND completed = 0", planID)
+	if err != nil {
+		return fmt.Errorf("redistribute plan: %w", err)
+	}
+	var chapters []planChapter
+	for _, e := range remaining {
+		for ch := e.ChapterStart; ch <= e.ChapterEnd; ch++ {
+			chapters = append(chapters, planChapter{e.BookCode, ch})
+		}
+	}
+
+	// The reading days left, with the chapters already checked off on
+	// each, which must not be scheduled on it again.
+	ahead, err := d.queryPlanEntries("plan_id = ? AND day_number >= ?", planID, firstDay)
+	if err != nil {
+		return fmt.Errorf("redistribute plan: %w", err)
+	}
+	var days []int
+	read := make(map[int]map[planChapter]bool)
+	for _, e := range ahead {
+		if read[e.DayNumber] == nil {
+			days = append(days, e.DayNumber)
+			read[e.DayNumber] = make(map[planChapter]bool)
+		}
+		if e.Completed {
+			for ch := e.ChapterStart; ch <= e.ChapterEnd; ch++ {
+				read[e.DayNumber][planChapter{e.BookCode, ch}] = true
+			}
+		}
+	}
+	if len(days) == 0 {
+		return fmt.Errorf("redistribute plan: plan %d has no reading days left", planID)
+	}
+
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM reading_plan_entries WHERE plan_id = ? AND completed = 0", planID); err != nil {
+		return fmt.Errorf("redistribute plan: %w", err)
+	}
+	stmt, err := tx.Prepare(
+		"INSERT INTO reading_plan_entries (plan_id, day_number, book_code, chapter_start, chapter_end) VALUES (?, ?, ?, ?, ?)",
+	)
+	if err != nil {
+		return fmt.Errorf("prepare stmt: %w", err)
+	}
+	defer stmt.Close()
+
+	next := 0
+	for i, day := range days {
+		end := (i + 1) * len(chapters) / len(days)
+		last := i == len(days)-1
+		var dayChapters []planChapter
+		for ; next < end; next++ {
+			c := chapters[next]
+			if read[day][c] {
+				// A chapter read twice goes to the next day, or on
+				// the last day is merged into the reading already
+				// on it.
+				if !last {
+					break
+				}
+				continue
+			}
+			read[day][c] = true
+			dayChapters = append(dayChapters, c)
+		}
+		if err := insertDayChapters(stmt, planID, day, dayChapters); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// insertDayChapters inserts the chapters read on one plan day, one entry
+// per run of consecutive chapters of a book.
+func insertDayChapters(stmt *sql.Stmt, planID int64, day int, chapters []planChapter) error {
+	var curBook string
+	var curStart, curEnd int
+	for _, ref := range chapters {
+		if ref.bookCode == curBook && ref.chapter == curEnd+1 {
+			curEnd = ref.chapter
+			continue
+		}
+		if curBook != "" {
+			if _, err := stmt.Exec(planID, day, curBook, curStart, curEnd); err != nil {
+				return fmt.Errorf("insert entry: %w", err)
+			}
+		}
+		curBook, curStart, curEnd = ref.bookCode, ref.chapter, ref.chapter
+	}
+	if curBook != "" {
+		if _, err := stmt.Exec(planID, day, curBook, curStart, curEnd); err != nil {
+			return fmt.Errorf("insert entry: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetPlanStreak returns the reading streaks of a plan on date today,
+// counting the local dates its entries were completed on.
+func (d *DB) GetPlanStreak(planID int64, today time.Time) (PlanStreak, error) {
+	rows, err := d.conn.Query(
+		`SELECT DISTINCT date(completed_at, 'localtime') FROM reading_plan_entries
+		 WHERE plan_id = ? AND completed = 1 AND completed_at IS NOT NULL
+		 ORDER BY 1`,
+		planID,
+	)
+	if err != nil {
+		return PlanStreak{}, fmt.Errorf("get plan streak: %w", err)
+	}
+	defer rows.Close()
+
+	var streak PlanStreak
+	var last time.Time
+	run := 0
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return PlanStreak{}, fmt.Errorf("scan completion date: %w", err)
+		}
+		day, err := time.ParseInLocation(dateLayout, value, time.Local)
+		if err != nil {
+			return PlanStreak{}, fmt.Errorf("parse completion date %q: %w", value, err)
+		}
+		if run > 0 && daysBetween(last, day) == 1 {
+			run++
+		} else {
+			run = 1
+		}
+		last = day
+		streak.Longest = max(streak.Longest, run)
+	}
+	if err := rows.Err(); err != nil {
+		return PlanStreak{}, err
+	}
+	if run > 0 && daysBetween(last, today) <= 1 {
+		streak.Current = run
+	}
+	return streak, nil
+}
@@ -0,0 +1,305 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// setupSchedulePlan creates a five-day plan starting on 2025-03-01 with
+// two chapters of Genesis a day.
+func setupSchedulePlan(t *testing.T) (*DB, int64) {
+	t.Helper()
+	d, vID := setupPlanDB(t)
+	var entries []PlanEntry
+	for day := 1; day <= 5; day++ {
+		entries = append(entries, PlanEntry{DayNumber: day, BookCode: "gen", ChapterStart: day*2 - 1, ChapterEnd: day * 2})
+	}
+	planID, err := d.CreateCustomPlan(vID, "schedule", entries)
+	if err != nil {
+		t.Fatalf("CreateCustomPlan: %v", err)
+	}
+	if err := d.SetPlanStartDate(planID, date(2025, time.March, 1)); err != nil {
+		t.Fatalf("SetPlanStartDate: %v", err)
+	}
+	return d, planID
+}
+
+func entryRefs(entries []PlanEntry) [][4]any {
+	var refs [][4]any
+	for _, e := range entries {
+		refs = append(refs, [4]any{e.DayNumber, e.BookCode, e.ChapterStart, e.ChapterEnd})
+	}
+	return refs
+}
+
+func TestPlanStartDate(t *testing.T) {
+	d, planID := setupSchedulePlan(t)
+
+	plan, err := d.GetPlan(planID)
+	if err != nil || plan == nil {
+		t.Fatalf("GetPlan: %v, %v", plan, err)
+	}
+	if !plan.StartDate.Equal(date(2025, time.March, 1)) {
+		t.Errorf("expected start date 2025-03-01, got %v", plan.StartDate)
+	}
+
+	tests := []struct {
+		on   time.Time
+		want int
+	}{
+		{date(2025, time.February, 27), -1},
+		{date(2025, time.March, 1), 1},
+		{date(2025, time.March, 1).Add(23 * time.Hour), 1},
+		{date(2025, time.March, 31), 31},
+	}
+	for _, tt := range tests {
+		if got := plan.DayOn(tt.on); got != tt.want {
+			t.Errorf("DayOn(%v) = %d, want %d", tt.on, got, tt.want)
+		}
+	}
+
+	// Nothing is due before the plan starts; the last day stays due after
+	// it ends.
+	if entries, err := d.GetEntriesOn(planID, date(2025, time.February, 28)); err != nil || len(entries) != 0 {
+		t.Errorf("expected no entries before the start, got %v, %v", entries, err)
+	}
+	entries, err := d.GetEntriesOn(planID, date(2025, time.March, 3))
+	if err != nil || len(entries) != 1 || entries[0].DayNumber != 3 {
+		t.Errorf("expected day 3 on 03-03, got %+v, %v", entries, err)
+	}
+	entries, err = d.GetEntriesOn(planID, date(2025, time.April, 1))
+	if err != nil || len(entries) != 1 || entries[0].DayNumber != 5 {
+		t.Errorf("expected day 5 after the end, got %+v, %v", entries, err)
+	}
+
+	if err := d.SetPlanStartDate(999, date(2025, time.March, 1)); err == nil {
+		t.Error("expected error for a missing plan")
+	}
+}
+
+func TestPlanStatusBehind(t *testing.T) {
+	d, planID := setupSchedulePlan(t)
+
+	status, err := d.GetPlanStatus(planID, date(2025, time.March, 1))
+	if err != nil {
+		t.Fatalf("GetPlanStatus: %v", err)
+	}
+	if status.Day != 1 || status.BehindDays != 0 || len(status.Overdue) != 0 {
+		t.Errorf("expected on schedule on day 1, got %+v", status)
+	}
+
+	// Day 1 and 3 read, day 2 skipped: on day 4 the reader is two days
+	// behind, counted from the earliest unread day.
+	entries, _ := d.queryPlanEntries("plan_id = ?", planID)
+	d.MarkEntryCompleted(entries[0].ID)
+	d.MarkEntryCompleted(entries[2].ID)
+
+	status, err = d.GetPlanStatus(planID, date(2025, time.March, 4))
+	if err != nil {
+		t.Fatalf("GetPlanStatus: %v", err)
+	}
+	if status.Day != 4 || status.BehindDays != 2 {
+		t.Errorf("expected day 4, 2 days behind, got %+v", status)
+	}
+	if len(status.Overdue) != 1 || status.Overdue[0].DayNumber != 2 {
+		t.Errorf("expected day 2 overdue, got %+v", status.Overdue)
+	}
+
+	// Shifting by the lag puts the earliest unread day on today.
+	if err := d.ShiftPlan(planID, status.BehindDays); err != nil {
+		t.Fatalf("ShiftPlan: %v", err)
+	}
+	plan, _ := d.GetPlan(planID)
+	if !plan.StartDate.Equal(date(2025, time.March, 3)) {
+		t.Errorf("expected start date 2025-03-03, got %v", plan.StartDate)
+	}
+	status, err = d.GetPlanStatus(planID, date(2025, time.March, 4))
+	if err != nil {
+		t.Fatalf("GetPlanStatus: %v", err)
+	}
+	if status.Day != 2 || status.BehindDays != 0 || len(status.Overdue) != 0 {
+		t.Errorf("expected caught up on day 2 after the shift, got %+v", status)
+	}
+
+	if err := d.ShiftPlan(planID, -2); err != nil {
+		t.Fatalf("ShiftPlan back: %v", err)
+	}
+	if plan, _ := d.GetPlan(planID); !plan.StartDate.Equal(date(2025, time.March, 1)) {
+		t.Errorf("expected start date back on 2025-03-01, got %v", plan.StartDate)
+	}
+	if err := d.ShiftPlan(999, 1); err == nil {
+		t.Error("expected error for a missing plan")
+	}
+}
+
+func TestPlanRedistribute(t *testing.T) {
+	d, planID := setupSchedulePlan(t)
+
+	entries, _ := d.queryPlanEntries("plan_id = ?", planID)
+	d.MarkEntryCompleted(entries[0].ID)
+
+	// On day 3 the unread chapters 3-10 are spread over days 3-5; the
+	// completed day 1 stays.
+	if err := d.RedistributePlan(planID, date(2025, time.March, 3)); err != nil {
+		t.Fatalf("RedistributePlan: %v", err)
+	}
+	entries, err := d.queryPlanEntries("plan_id = ?", planID)
+	if err != nil {
+		t.Fatalf("queryPlanEntries: %v", err)
+	}
+	want := [][4]any{
+		{1, "gen", 1, 2},
+		{3, "gen", 3, 4},
+		{4, "gen", 5, 7},
+		{5, "gen", 8, 10},
+	}
+	if got := entryRefs(entries); len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	} else {
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("entry %d: expected %v, got %v", i, want[i], got[i])
+			}
+		}
+	}
+	if !entries[0].Completed {
+		t.Error("expected the completed entry to stay completed")
+	}
+	if status, _ := d.GetPlanStatus(planID, date(2025, time.March, 3)); status.BehindDays != 0 {
+		t.Errorf("expected no lag after redistributing, got %+v", status)
+	}
+
+	if err := d.RedistributePlan(planID, date(2025, time.March, 6)); err == nil {
+		t.Error("expected error redistributing an ended plan")
+	}
+}
+
+func TestPlanRedistribute_RepeatedChapters(t *testing.T) {
+	d, vID := setupPlanDB(t)
+	tests := []struct {
+		days []string
+		on   time.Time
+		want [][4]any
+	}{
+		// A repeat that would share a day with its first reading waits
+		// for the next day...
+		{
+			[]string{"시 1", "시 1", "시 2", "시 3"},
+			date(2025, time.March, 3),
+			[][4]any{{3, "psa", 1, 1}, {4, "psa", 1, 3}},
+		},
+		// ...and on the last day is merged into it.
+		{
+			[]string{"시 1", "시 2", "시 1", "시 3"},
+			date(2025, time.March, 4),
+			[][4]any{{4, "psa", 1, 3}},
+		},
+	}
+	for _, tt := range tests {
+		planID, err := d.ImportPlan(vID, &PlanFile{Name: "시편", StartDate: "2025-03-01", Days: tt.days})
+		if err != nil {
+			t.Fatalf("ImportPlan: %v", err)
+		}
+		if err := d.RedistributePlan(planID, tt.on); err != nil {
+			t.Fatalf("RedistributePlan %v: %v", tt.days, err)
+		}
+		entries, err := d.queryPlanEntries("plan_id = ?", planID)
+		if err != nil {
+			t.Fatalf("queryPlanEntries: %v", err)
+		}
+		if got := entryRefs(entries); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v: expected %v, got %v", tt.days, tt.want, got)
+		}
+	}
+}
+
+func TestPlanRedistribute_WeekdaysOnly(t *testing.T) {
+	d, vID := setupPlanDB(t)
+	// Thursday 2025-03-06: Philippians 1-2 on Thursday and Friday, the
+	// weekend off, and 3-4 on Monday and Tuesday.
+	planID, err := d.CreateGeneratedPlan(vID, "빌립보서", "custom", bible.PlanSpec{
+		Tracks:       [][]string{{"php"}},
+		Days:         4,
+		WeekdaysOnly: true,
+		Start:        date(2025, time.March, 6),
+	})
+	if err != nil {
+		t.Fatalf("CreateGeneratedPlan: %v", err)
+	}
+
+	// Redistributing on Friday keeps the weekend free.
+	if err := d.RedistributePlan(planID, date(2025, time.March, 7)); err != nil {
+		t.Fatalf("RedistributePlan: %v", err)
+	}
+	entries, err := d.queryPlanEntries("plan_id = ?", planID)
+	if err != nil {
+		t.Fatalf("queryPlanEntries: %v", err)
+	}
+	want := [][4]any{
+		{2, "php", 1, 1},
+		{5, "php", 2, 2},
+		{6, "php", 3, 4},
+	}
+	if got := entryRefs(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestPlanStreak(t *testing.T) {
+	d, planID := setupSchedulePlan(t)
+	entries, _ := d.queryPlanEntries("plan_id = ?", planID)
+
+	streak, err := d.GetPlanStreak(planID, date(2025, time.March, 5))
+	if err != nil {
+		t.Fatalf("GetPlanStreak: %v", err)
+	}
+	if streak != (PlanStreak{}) {
+		t.Errorf("expected no streak before any reading, got %+v", streak)
+	}
+
+	// Read on March 1-3, then on March 5.
+	for i, completed := range []string{"2025-03-01 12:00:00", "2025-03-02 12:00:00", "2025-03-03 12:00:00", "2025-03-05 12:00:00"} {
+		if _, err := d.conn.Exec("UPDATE reading_plan_entries SET completed = 1, completed_at = ? WHERE id = ?", completed, entries[i].ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		today time.Time
+		want  PlanStreak
+	}{
+		{date(2025, time.March, 5), PlanStreak{Current: 1, Longest: 3}},
+		{date(2025, time.March, 6), PlanStreak{Current: 1, Longest: 3}},
+		{date(2025, time.March, 7), PlanStreak{Current: 0, Longest: 3}},
+	}
+	for _, tt := range tests {
+		streak, err := d.GetPlanStreak(planID, tt.today)
+		if err != nil {
+			t.Fatalf("GetPlanStreak: %v", err)
+		}
+		if streak != tt.want {
+			t.Errorf("on %s: expected %+v, got %+v", tt.today.Format(dateLayout), tt.want, streak)
+		}
+	}
+
+	// Checking an entry again keeps the day it was first read.
+	if err := d.MarkEntryCompleted(entries[0].ID); err != nil {
+		t.Fatalf("MarkEntryCompleted: %v", err)
+	}
+	if streak, _ := d.GetPlanStreak(planID, date(2025, time.March, 5)); streak.Longest != 3 {
+		t.Errorf("expected the first completion date kept, got %+v", streak)
+	}
+	e, err := d.GetPlanEntry(entries[0].ID)
+	if err != nil || e == nil || e.CompletedAt == nil || e.CompletedAt.Day() != 1 {
+		t.Errorf("GetPlanEntry: %+v, %v", e, err)
+	}
+	if e, err := d.GetPlanEntry(999); e != nil || err != nil {
+		t.Errorf("expected nil for a missing entry, got %+v, %v", e, err)
+	}
+}
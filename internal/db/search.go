@@ -1,163 +1,307 @@
 package db
 
 import (
+	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
+	"unicode/utf8"
 )
 
 // SearchResult represents a single search result with context.
 type SearchResult struct {
-	Verse      Verse
-	Snippet    string // text with search term context (surrounding text)
-	MatchCount int    // number of matches in this verse
+	Verse      Verse    `json:"verse"`
+	Snippet    string   `json:"snippet"`     // text around the first search word
+	MatchCount int      `json:"match_count"` // number of matches in this verse
+	Highlights []string `json:"highlights"`  // distinct matched text, as written in the verse
 }
 
-// SearchVerses searches for verses matching the query.
-// Strategy: Try FTS5 MATCH first. If results < minResults, supplement with LIKE fallback.
-// Results are deduplicated (FTS5 and LIKE may return same verses).
-func (d *DB) SearchVerses(versionCode, query string, limit int) ([]SearchResult, error) {
-	if query == "" {
-		return []SearchResult{}, nil
+// SearchSort orders search results.
+type SearchSort int
+
+const (
+	SortRelevance SearchSort = iota // best matches first
+	SortCanonical                   // Bible order
+	SortByBook                      // books with the most hits first, each in Bible order
+)
+
+// ParseSearchSort accepts relevance, canonical and book.
+func ParseSearchSort(value string) (SearchSort, error) {
+	switch value {
+	case "relevance", "rank":
+		return SortRelevance, nil
+	case "canonical", "bible":
+		return SortCanonical, nil
+	case "book":
+		return SortByBook, nil
 	}
+	return 0, fmt.Errorf("unknown sort %q (use relevance, canonical or book)", value)
+}
 
-	seen := make(map[int64]bool)
-	results := []SearchResult{}
+// SearchOptions selects the order and page of search results.
+type SearchOptions struct {
+	Sort   SearchSort
+	Offset int
+	Limit  int
+	Regex  bool // match the query text as a regular expression
+}
+
+// SearchPage is one page of search results.
+type SearchPage struct {
+	Results    []SearchResult `json:"results"`
+	Total      int            `json:"total"`       // matching verses on all pages
+	BookCounts []BookCount    `json:"book_counts"` // matching verses per book, in Bible order
+}
+
+// BookCount is the number of matching verses in one book.
+type BookCount struct {
+	BookCode string `json:"book_code"`
+	BookName string `json:"book_name"`
+	Count    int    `json:"count"`
+}
 
-	// Try FTS5 search first
-	ftsResults, err := d.searchFTS(versionCode, query, limit, seen)
+// SearchVerses parses query (see ParseSearchQuery) and returns its first
+// limit results by relevance.
+func (d *DB) SearchVerses(versionCode, query string, limit int) ([]SearchResult, error) {
+	q, err := ParseSearchQuery(query)
 	if err != nil {
-		// FTS5 might fail on special characters, fall back to LIKE only
-		likeResults, likeErr := d.searchLIKE(versionCode, query, limit, seen)
-		if likeErr != nil {
-			return []SearchResult{}, nil
-		}
-		return likeResults, nil
+		return nil, err
+	}
+	page, err := d.Search(versionCode, q, SearchOptions{Limit: limit})
+	if err != nil {
+		return nil, err
 	}
-	results = append(results, ftsResults...)
+	return page.Results, nil
+}
 
-	// If we have fewer than 5 results, supplement with LIKE
-	if len(results) < 5 && len(results) < limit {
-		remaining := limit - len(results)
-		likeResults, err := d.searchLIKE(versionCode, query, remaining, seen)
-		if err != nil {
-			return results, nil // return what we have from FTS
-		}
-		results = append(results, likeResults...)
+// Search returns a page of the verses matching q. Words match inside
+// longer words, so "사랑" also finds 사랑하사 and 사랑을 (see ngram.go). A
+// query whose words are all bare consonants, such as "ㅎㄴㄴ", is matched
+// against initial consonants (see chosung.go). With opts.Regex the text
+// is a regular expression instead (see regex.go).
+func (d *DB) Search(versionCode string, q SearchQuery, opts SearchOptions) (*SearchPage, error) {
+	if opts.Regex {
+		return d.searchRegex(versionCode, q, opts)
+	}
+	page := &SearchPage{Results: []SearchResult{}}
+	expr, err := parseSearchExpr(q.Text)
+	if err != nil {
+		return nil, fmt.Errorf("search query: %w", err)
+	}
+	if expr == nil {
+		return page, nil
+	}
+	match, err := expr.fts()
+	if err != nil {
+		return nil, fmt.Errorf("search query: %w", err)
 	}
 
-	return results, nil
-}
+	table, key := "verses_fts", strings.ToLower
+	if isChosungExpr(expr) {
+		table, key = "verses_chosung", chosung
+	}
+
+	where := "ver.code = ? AND " + table + " MATCH ?"
+	args := []interface{}{versionCode, match}
+	if scope, scopeArgs := q.scopeClause(); scope != "" {
+		where += " AND " + scope
+		args = append(args, scopeArgs...)
+	}
 
-// searchFTS performs FTS5 full-text search.
-func (d *DB) searchFTS(versionCode, query string, limit int, seen map[int64]bool) ([]SearchResult, error) {
+	// Candidates come in rank order. Every one is read, to drop those
+	// failing the exact check (see queryNode) and to count the rest.
 	rows, err := d.conn.Query(
-		`SELECT v.id, v.book_id, v.chapter, v.verse_num, v.text,
-		        COALESCE(v.section_title, ''), v.has_footnote,
-		        b.name_ko, b.code,
-		        snippet(verses_fts, 0, '**', '**', '...', 32) as snippet
-		 FROM verses_fts
-		 JOIN verses v ON v.id = verses_fts.rowid
+		`SELECT `+searchColumns+`
+		 FROM `+table+` fts
+		 JOIN verses v ON v.id = fts.rowid
 		 JOIN books b ON b.id = v.book_id
 		 JOIN versions ver ON ver.id = b.version_id
-		 WHERE ver.code = ? AND verses_fts MATCH ?
-		 ORDER BY rank
-		 LIMIT ?`,
-		versionCode, query, limit,
+		 WHERE `+where+`
+		 ORDER BY rank, b.sort_order, v.chapter, v.verse_num`,
+		args...,
 	)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("search verses: %w", err)
 	}
 	defer rows.Close()
 
-	results := []SearchResult{}
+	var hits []searchHit
 	for rows.Next() {
-		var v Verse
-		var snippet string
-		if err := rows.Scan(
-			&v.ID, &v.BookID, &v.Chapter, &v.VerseNum, &v.Text,
-			&v.SectionTitle, &v.HasFootnote,
-			&v.BookName, &v.BookCode,
-			&snippet,
-		); err != nil {
-			return nil, fmt.Errorf("scan fts result: %w", err)
+		h, err := scanSearchHit(rows)
+		if err != nil {
+			return nil, err
 		}
-
-		if seen[v.ID] {
+		if !expr.match(newVerseWords(key(h.verse.Text))) {
 			continue
 		}
-		seen[v.ID] = true
-
-		matchCount := countMatches(v.Text, query)
-		results = append(results, SearchResult{
-			Verse:      v,
-			Snippet:    snippet,
-			MatchCount: matchCount,
-		})
+		hits = append(hits, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("search verses: %w", err)
 	}
 
-	return results, rows.Err()
+	terms := queryTerms(expr)
+	return pageOf(hits, opts, func(v Verse) SearchResult {
+		return newSearchResult(v, terms, key)
+	}), nil
 }
 
-// searchLIKE performs LIKE-based search as fallback.
-func (d *DB) searchLIKE(versionCode, query string, limit int, seen map[int64]bool) ([]SearchResult, error) {
-	rows, err := d.conn.Query(
-		`SELECT v.id, v.book_id, v.chapter, v.verse_num, v.text,
+// searchColumns are the columns read by scanSearchHit, from verses v
+// joined with books b.
+const searchColumns = `v.id, v.book_id, v.chapter, v.verse_num, v.text,
 		        COALESCE(v.section_title, ''), v.has_footnote,
-		        b.name_ko, b.code
-		 FROM verses v
-		 JOIN books b ON b.id = v.book_id
-		 JOIN versions ver ON ver.id = b.version_id
-		 WHERE ver.code = ? AND v.text LIKE '%' || ? || '%'
-		 ORDER BY b.sort_order, v.chapter, v.verse_num
-		 LIMIT ?`,
-		versionCode, query, limit,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("like search: %w", err)
+		        b.name_ko, b.code, b.sort_order`
+
+// searchHit is a matching verse and its book's place in the Bible.
+type searchHit struct {
+	verse     Verse
+	sortOrder int
+}
+
+func scanSearchHit(rows *sql.Rows) (searchHit, error) {
+	var h searchHit
+	v := &h.verse
+	if err := rows.Scan(
+		&v.ID, &v.BookID, &v.Chapter, &v.VerseNum, &v.Text,
+		&v.SectionTitle, &v.HasFootnote,
+		&v.BookName, &v.BookCode, &h.sortOrder,
+	); err != nil {
+		return searchHit{}, fmt.Errorf("scan search result: %w", err)
 	}
-	defer rows.Close()
+	return h, nil
+}
 
-	results := []SearchResult{}
-	for rows.Next() {
-		var v Verse
-		if err := rows.Scan(
-			&v.ID, &v.BookID, &v.Chapter, &v.VerseNum, &v.Text,
-			&v.SectionTitle, &v.HasFootnote,
-			&v.BookName, &v.BookCode,
-		); err != nil {
-			return nil, fmt.Errorf("scan like result: %w", err)
+// pageOf orders hits, given best first, as opts asks, counts them per book
+// and returns the page opts selects, built with result.
+func pageOf(hits []searchHit, opts SearchOptions, result func(Verse) SearchResult) *SearchPage {
+	page := &SearchPage{Results: []SearchResult{}, Total: len(hits)}
+
+	counts := make(map[string]*BookCount)
+	for _, h := range hits {
+		v := h.verse
+		if c := counts[v.BookCode]; c != nil {
+			c.Count++
+		} else {
+			counts[v.BookCode] = &BookCount{BookCode: v.BookCode, BookName: v.BookName, Count: 1}
 		}
+	}
 
-		if seen[v.ID] {
-			continue
+	canonical := func(a, b searchHit) bool {
+		if a.sortOrder != b.sortOrder {
+			return a.sortOrder < b.sortOrder
 		}
-		seen[v.ID] = true
-
-		snippet := generateSnippet(v.Text, query, 30)
-		matchCount := countMatches(v.Text, query)
-		results = append(results, SearchResult{
-			Verse:      v,
-			Snippet:    snippet,
-			MatchCount: matchCount,
+		if a.verse.Chapter != b.verse.Chapter {
+			return a.verse.Chapter < b.verse.Chapter
+		}
+		return a.verse.VerseNum < b.verse.VerseNum
+	}
+	switch opts.Sort {
+	case SortCanonical:
+		sort.SliceStable(hits, func(i, j int) bool { return canonical(hits[i], hits[j]) })
+	case SortByBook:
+		sort.SliceStable(hits, func(i, j int) bool {
+			ci, cj := counts[hits[i].verse.BookCode].Count, counts[hits[j].verse.BookCode].Count
+			if ci != cj {
+				return ci > cj
+			}
+			return canonical(hits[i], hits[j])
 		})
 	}
 
-	return results, rows.Err()
+	bookOrder := make(map[string]int)
+	for _, h := range hits {
+		bookOrder[h.verse.BookCode] = h.sortOrder
+	}
+	for _, c := range counts {
+		page.BookCounts = append(page.BookCounts, *c)
+	}
+	sort.Slice(page.BookCounts, func(i, j int) bool {
+		return bookOrder[page.BookCounts[i].BookCode] < bookOrder[page.BookCounts[j].BookCode]
+	})
+
+	start := min(max(opts.Offset, 0), len(hits))
+	end := len(hits)
+	if opts.Limit > 0 {
+		end = min(start+opts.Limit, len(hits))
+	}
+	for _, h := range hits[start:end] {
+		page.Results = append(page.Results, result(h.verse))
+	}
+	return page
+}
+
+// newSearchResult finds where terms occur in v for the snippet, match
+// count and highlights.
+func newSearchResult(v Verse, terms []termNode, key func(string) string) SearchResult {
+	var spans, highlights []string
+	seen := make(map[string]bool)
+	for _, t := range terms {
+		for _, span := range matchSpans(v.Text, t.text(), key) {
+			spans = append(spans, span)
+			if !seen[span] {
+				seen[span] = true
+				highlights = append(highlights, span)
+			}
+		}
+	}
+	snippetTerm := ""
+	if len(spans) > 0 {
+		snippetTerm = spans[0]
+	}
+	return SearchResult{
+		Verse:      v,
+		Snippet:    generateSnippet(v.Text, snippetTerm, 30),
+		MatchCount: len(spans),
+		Highlights: highlights,
+	}
+}
+
+// CheckExpr reports a syntax error in the search expression of q, which
+// Search would otherwise only find when run.
+func (q SearchQuery) CheckExpr() error {
+	expr, err := parseSearchExpr(q.Text)
+	if err != nil || expr == nil {
+		return err
+	}
+	_, err = expr.fts()
+	return err
 }
 
-// generateSnippet creates a text snippet showing context around the search term.
+// IsChosung reports whether q is searched by initial consonants.
+func (q SearchQuery) IsChosung() bool {
+	expr, err := parseSearchExpr(q.Text)
+	return err == nil && expr != nil && isChosungExpr(expr)
+}
+
+// isChosungExpr reports whether every word of the expression is made of
+// bare consonants.
+func isChosungExpr(expr queryNode) bool {
+	for _, t := range allTerms(expr) {
+		if !IsChosungQuery(t.text()) {
+			return false
+		}
+	}
+	return true
+}
+
+// generateSnippet creates a text snippet showing context around the search
+// term. contextChars counts characters, not bytes, so Hangul is never cut
+// mid-character.
 func generateSnippet(text, query string, contextChars int) string {
+	runes := []rune(text)
 	lowerText := strings.ToLower(text)
 	lowerQuery := strings.ToLower(query)
 
 	pos := strings.Index(lowerText, lowerQuery)
-	if pos == -1 {
-		// No match found, return beginning of text
-		if len(text) > contextChars*2 {
-			return text[:contextChars*2] + "..."
+	if pos == -1 || len(lowerText) != len(text) {
+		// No match found (or lower-casing changed byte offsets), return
+		// beginning of text
+		if len(runes) > contextChars*2 {
+			return string(runes[:contextChars*2]) + "..."
 		}
 		return text
 	}
+	pos = utf8.RuneCountInString(text[:pos])
 
 	// Calculate start and end positions
 	start := pos - contextChars
@@ -165,19 +309,19 @@ func generateSnippet(text, query string, contextChars int) string {
 		start = 0
 	}
 
-	end := pos + len(query) + contextChars
-	if end > len(text) {
-		end = len(text)
+	end := pos + utf8.RuneCountInString(query) + contextChars
+	if end > len(runes) {
+		end = len(runes)
 	}
 
 	// Extract snippet
-	snippet := text[start:end]
+	snippet := string(runes[start:end])
 
 	// Add ellipsis if truncated
 	if start > 0 {
 		snippet = "..." + snippet
 	}
-	if end < len(text) {
+	if end < len(runes) {
 		snippet = snippet + "..."
 	}
 
@@ -186,18 +330,28 @@ func generateSnippet(text, query string, contextChars int) string {
 
 // countMatches counts how many times query appears in text (case-insensitive).
 func countMatches(text, query string) int {
-	lowerText := strings.ToLower(text)
-	lowerQuery := strings.ToLower(query)
+	return len(matchSpans(text, query, strings.ToLower))
+}
+
+// matchSpans returns the non-overlapping occurrences of word in text, as
+// written in text. key maps text rune by rune to the form word is spelled
+// in: strings.ToLower for ordinary search, chosung for initial consonants.
+func matchSpans(text, word string, key func(string) string) []string {
+	runes := []rune(text)
+	keyed := []rune(key(text))
+	w := []rune(key(word))
+	if len(w) == 0 || len(keyed) != len(runes) {
+		return nil
+	}
 
-	count := 0
-	pos := 0
-	for {
-		idx := strings.Index(lowerText[pos:], lowerQuery)
-		if idx == -1 {
-			break
+	var spans []string
+	for i := 0; i+len(w) <= len(keyed); {
+		if string(keyed[i:i+len(w)]) == string(w) {
+			spans = append(spans, string(runes[i:i+len(w)]))
+			i += len(w)
+			continue
 		}
-		count++
-		pos += idx + len(lowerQuery)
+		i++
 	}
-	return count
+	return spans
 }
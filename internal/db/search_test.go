@@ -1,8 +1,10 @@
 package db
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func setupSearchDB(t *testing.T) *DB {
@@ -41,6 +43,8 @@ func setupSearchDB(t *testing.T) *DB {
 		{genID, 1, 3, "하나님이 이르시되 빛이 있으라 하시니 빛이 있었고", ""},
 		{genID, 1, 4, "하나님이 빛을 보시니 좋았더라 하나님이 빛과 어둠을 나누사", ""},
 		{jhnID, 3, 16, "하나님이 세상을 이처럼 사랑하사 독생자를 주셨으니 이는 그를 믿는 자마다 멸망하지 않고 영생을 얻게 하려 하심이라", ""},
+		{jhnID, 13, 34, "새 계명을 너희에게 주노니 서로 사랑하라 내가 너희를 사랑한 것 같이 너희도 서로 사랑하라", ""},
+		{jhnID, 15, 9, "아버지께서 나를 사랑하신 것 같이 나도 너희를 사랑하였으니 나의 사랑 안에 거하라", ""},
 	}
 
 	for _, v := range verses {
@@ -84,7 +88,7 @@ func TestSearchVerses_FTS(t *testing.T) {
 	}
 }
 
-func TestSearchVerses_LIKE(t *testing.T) {
+func TestSearchVerses_SingleCharacter(t *testing.T) {
 	db := setupSearchDB(t)
 	defer db.Close()
 
@@ -230,6 +234,226 @@ func TestSearchVerses_Deduplication(t *testing.T) {
 	}
 }
 
+func TestSearchVerses_KoreanSuffixes(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	results, err := db.SearchVerses("GAE", "사랑", 10)
+	if err != nil {
+		t.Fatalf("SearchVerses: %v", err)
+	}
+	// 사랑하사, 사랑하라/사랑한, 사랑하신/사랑하였으니/사랑
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	// Verses with more occurrences rank first.
+	if results[0].MatchCount < results[len(results)-1].MatchCount {
+		t.Errorf("expected best match first, got counts %d..%d",
+			results[0].MatchCount, results[len(results)-1].MatchCount)
+	}
+	if results[len(results)-1].Verse.VerseNum != 16 {
+		t.Errorf("expected 요 3:16 (one match) last, got %d:%d",
+			results[len(results)-1].Verse.Chapter, results[len(results)-1].Verse.VerseNum)
+	}
+}
+
+func TestSearchVerses_InsideWord(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	// "생자" is in the middle of 독생자; "이처" is the start of 이처럼.
+	for _, query := range []string{"생자", "이처", "사랑하사"} {
+		results, err := db.SearchVerses("GAE", query, 10)
+		if err != nil {
+			t.Fatalf("SearchVerses(%q): %v", query, err)
+		}
+		if len(results) != 1 || results[0].Verse.VerseNum != 16 {
+			t.Errorf("SearchVerses(%q): expected 요 3:16 only, got %d results", query, len(results))
+		}
+	}
+}
+
+func TestSearchVerses_NoMatchAcrossWords(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	// "하나님이 세상을" contains "이세" only across a word boundary.
+	results, err := db.SearchVerses("GAE", "이세", 10)
+	if err != nil {
+		t.Fatalf("SearchVerses: %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("expected no results, got %d", len(results))
+	}
+}
+
+func TestSearchVerses_AllWords(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	results, err := db.SearchVerses("GAE", "하나님 빛", 10)
+	if err != nil {
+		t.Fatalf("SearchVerses: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Verse.Chapter != 1 || (r.Verse.VerseNum != 3 && r.Verse.VerseNum != 4) {
+			t.Errorf("unexpected result %d:%d", r.Verse.Chapter, r.Verse.VerseNum)
+		}
+	}
+}
+
+func TestSearchVerses_Chosung(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	// ㅎㄴㄴㅇ spells both 하나님의 and 하나님이.
+	results, err := db.SearchVerses("GAE", "ㅎㄴㄴㅇ", 10)
+	if err != nil {
+		t.Fatalf("SearchVerses: %v", err)
+	}
+	if len(results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Verse.Chapter != 1 || r.Verse.VerseNum != 2 {
+			continue
+		}
+		if len(r.Highlights) != 1 || r.Highlights[0] != "하나님의" {
+			t.Errorf("expected highlight 하나님의 on 창 1:2, got %v", r.Highlights)
+		}
+		if !strings.Contains(r.Snippet, "하나님의") {
+			t.Errorf("expected snippet around the match, got %q", r.Snippet)
+		}
+	}
+}
+
+func TestSearchVerses_ChosungWords(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	// ㅅㄹ: 사랑하사 in 요 3:16; ㄷㅅㅈ: 독생자.
+	results, err := db.SearchVerses("GAE", "ㄷㅅㅈ ㅅㄹ", 10)
+	if err != nil {
+		t.Fatalf("SearchVerses: %v", err)
+	}
+	if len(results) != 1 || results[0].Verse.VerseNum != 16 {
+		t.Fatalf("expected 요 3:16 only, got %d results", len(results))
+	}
+	if got := results[0].Highlights; len(got) != 2 || got[0] != "독생자" || got[1] != "사랑" {
+		t.Errorf("unexpected highlights %v", got)
+	}
+}
+
+func TestSearchVerses_Highlights(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	results, err := db.SearchVerses("GAE", "사랑", 10)
+	if err != nil {
+		t.Fatalf("SearchVerses: %v", err)
+	}
+	for _, r := range results {
+		if len(r.Highlights) != 1 || r.Highlights[0] != "사랑" {
+			t.Errorf("verse %d: expected highlight 사랑 once, got %v", r.Verse.ID, r.Highlights)
+		}
+	}
+}
+
+func TestSearch_Paging(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	q := SearchQuery{Text: "하나님"}
+	all, err := db.Search("GAE", q, SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if all.Total != 5 || len(all.Results) != 5 {
+		t.Fatalf("expected 5 of 5, got %d of %d", len(all.Results), all.Total)
+	}
+
+	var paged []int64
+	for offset := 0; offset < all.Total; offset += 2 {
+		page, err := db.Search("GAE", q, SearchOptions{Offset: offset, Limit: 2})
+		if err != nil {
+			t.Fatalf("Search offset %d: %v", offset, err)
+		}
+		if page.Total != 5 {
+			t.Errorf("offset %d: expected total 5, got %d", offset, page.Total)
+		}
+		for _, r := range page.Results {
+			paged = append(paged, r.Verse.ID)
+		}
+	}
+	if len(paged) != 5 {
+		t.Fatalf("expected 5 results over all pages, got %d", len(paged))
+	}
+	for i, r := range all.Results {
+		if paged[i] != r.Verse.ID {
+			t.Errorf("result %d: paged %d, unpaged %d", i, paged[i], r.Verse.ID)
+		}
+	}
+
+	past, err := db.Search("GAE", q, SearchOptions{Offset: 10, Limit: 2})
+	if err != nil || len(past.Results) != 0 || past.Total != 5 {
+		t.Errorf("expected empty page past the end, got %v (%+v)", err, past)
+	}
+}
+
+func TestSearch_Sort(t *testing.T) {
+	db := setupSearchDB(t)
+	defer db.Close()
+
+	refs := func(page *SearchPage) string {
+		var parts []string
+		for _, r := range page.Results {
+			parts = append(parts, fmt.Sprintf("%s%d:%d", r.Verse.BookCode, r.Verse.Chapter, r.Verse.VerseNum))
+		}
+		return strings.Join(parts, " ")
+	}
+	q := SearchQuery{Text: "사랑 OR 빛"}
+
+	page, err := db.Search("GAE", q, SearchOptions{Sort: SortCanonical})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if got, want := refs(page), "gen1:3 gen1:4 jhn3:16 jhn13:34 jhn15:9"; got != want {
+		t.Errorf("canonical: got %s, want %s", got, want)
+	}
+
+	page, err = db.Search("GAE", q, SearchOptions{Sort: SortByBook})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if got, want := refs(page), "jhn3:16 jhn13:34 jhn15:9 gen1:3 gen1:4"; got != want {
+		t.Errorf("by book: got %s, want %s", got, want)
+	}
+
+	want := []BookCount{{"gen", "창세기", 2}, {"jhn", "요한복음", 3}}
+	if len(page.BookCounts) != 2 || page.BookCounts[0] != want[0] || page.BookCounts[1] != want[1] {
+		t.Errorf("expected book counts %v, got %v", want, page.BookCounts)
+	}
+}
+
+func TestParseSearchSort(t *testing.T) {
+	for value, want := range map[string]SearchSort{
+		"relevance": SortRelevance,
+		"canonical": SortCanonical,
+		"book":      SortByBook,
+	} {
+		if got, err := ParseSearchSort(value); err != nil || got != want {
+			t.Errorf("ParseSearchSort(%q) = %v, %v", value, got, err)
+		}
+	}
+	if _, err := ParseSearchSort("date"); err == nil {
+		t.Error("expected error for unknown sort")
+	}
+}
+
 func TestGenerateSnippet(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -288,6 +512,18 @@ func TestGenerateSnippet(t *testing.T) {
 	}
 }
 
+func TestGenerateSnippet_KeepsWholeCharacters(t *testing.T) {
+	text := "하나님이 세상을 이처럼 사랑하사 독생자를 주셨으니 이는 그를 믿는 자마다 멸망하지 않고"
+	snippet := generateSnippet(text, "독생자", 5)
+
+	if !utf8.ValidString(snippet) {
+		t.Fatalf("snippet is not valid UTF-8: %q", snippet)
+	}
+	if snippet != "...사랑하사 독생자를 주셨으..." {
+		t.Errorf("unexpected snippet %q", snippet)
+	}
+}
+
 func TestGenerateSnippet_Truncation(t *testing.T) {
 	text := "이것은 매우 긴 텍스트입니다. 하나님이 세상을 이처럼 사랑하사 독생자를 주셨으니 이는 그를 믿는 자마다 멸망하지 않고 영생을 얻게 하려 하심이라"
 	snippet := generateSnippet(text, "하나님", 10)
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// TagCount is a bookmark tag and how many bookmarks carry it.
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// NormalizeTag trims whitespace and a leading '#' from a tag name, so
+// "#암송" and "암송 " name the same tag. Control characters are dropped,
+// which keeps tagSeparator out of tag names.
+func NormalizeTag(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+}
+
+// TagBookmark adds tags to a bookmark, creating tags that do not exist
+// yet. Tags the bookmark already has and empty names are ignored.
+func (d *DB) TagBookmark(bookmarkID int64, tags ...string) error {
+	tx, err := d.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRow("SELECT EXISTS(SELECT 1 FROM bookmarks WHERE id = ?)", bookmarkID).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("tag bookmark: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("tag bookmark: bookmark %d not found", bookmarkID)
+	}
+
+	if err := tagBookmark(tx, bookmarkID, tags); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// tagBookmark adds tags to an existing bookmark within tx.
+func tagBookmark(tx *sql.Tx, bookmarkID int64, tags []string) error {
+	for _, tag := range tags {
+		tag = NormalizeTag(tag)
+		if tag == "" {
+			continue
+		}
+		if _, err := tx.Exec("INSERT OR IGNORE INTO tags (name) VALUES (?)", tag); err != nil {
+			return fmt.Errorf("tag bookmark: %w", err)
+		}
+		_, err := tx.Exec(
+			`INSERT OR IGNORE INTO bookmark_tags (bookmark_id, tag_id)
+			 SELECT ?, id FROM tags WHERE name = ?`,
+			bookmarkID, tag,
+		)
+		if err != nil {
+			return fmt.Errorf("tag bookmark: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListTags returns the tags in use with their bookmark counts, by name.
+func (d *DB) ListTags() ([]TagCount, error) {
+	rows, err := d.conn.Query(
+		`SELECT t.name, COUNT(*)
+		 FROM tags t
+		 JOIN bookmark_tags bt ON bt.tag_id = t.id
+		 GROUP BY t.id
+		 ORDER BY t.name`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []TagCount
+	for rows.Next() {
+		var tc TagCount
+		if err := rows.Scan(&tc.Name, &tc.Count); err != nil {
+			return nil, fmt.Errorf("scan tag: %w", err)
+		}
+		tags = append(tags, tc)
+	}
+	return tags, rows.Err()
+}
+
+func deleteUnusedTags(tx *sql.Tx) error {
+	_, err := tx.Exec("DELETE FROM tags WHERE id NOT IN (SELECT tag_id FROM bookmark_tags)")
+	return err
+}
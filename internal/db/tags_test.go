@@ -0,0 +1,123 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagBookmarks(t *testing.T) {
+	db, verseID := setupBookmarkDB(t)
+
+	first, err := db.AddBookmark(verseID, "")
+	if err != nil {
+		t.Fatalf("AddBookmark: %v", err)
+	}
+	second, err := db.AddBookmark(verseID, "둘째")
+	if err != nil {
+		t.Fatalf("AddBookmark: %v", err)
+	}
+	if err := db.TagBookmark(first, "암송", "#복음", " ", "암송"); err != nil {
+		t.Fatalf("TagBookmark: %v", err)
+	}
+	if err := db.TagBookmark(second, "암송 "); err != nil {
+		t.Fatalf("TagBookmark: %v", err)
+	}
+	if err := db.TagBookmark(999, "설교"); err == nil {
+		t.Error("expected error tagging a missing bookmark")
+	}
+
+	bm, err := db.GetBookmark(first)
+	if err != nil {
+		t.Fatalf("GetBookmark: %v", err)
+	}
+	if want := []string{"복음", "암송"}; !reflect.DeepEqual(bm.Tags, want) {
+		t.Errorf("expected tags %v, got %v", want, bm.Tags)
+	}
+
+	tags, err := db.ListTags()
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if want := []TagCount{{"복음", 1}, {"암송", 2}}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("expected tags %v, got %v", want, tags)
+	}
+
+	bookmarks, err := db.ListBookmarksByTag("#복음", 10, 0)
+	if err != nil {
+		t.Fatalf("ListBookmarksByTag: %v", err)
+	}
+	if len(bookmarks) != 1 || bookmarks[0].ID != first {
+		t.Errorf("expected only bookmark %d for 복음, got %+v", first, bookmarks)
+	}
+	bookmarks, err = db.ListBookmarksByTag("암송", 10, 0)
+	if err != nil {
+		t.Fatalf("ListBookmarksByTag: %v", err)
+	}
+	if len(bookmarks) != 2 || bookmarks[0].ID != second {
+		t.Errorf("expected both bookmarks newest first for 암송, got %+v", bookmarks)
+	}
+	if bookmarks, _ := db.ListBookmarksByTag("설교", 10, 0); len(bookmarks) != 0 {
+		t.Errorf("expected no bookmarks for unknown tag, got %+v", bookmarks)
+	}
+
+	// Removing a bookmark drops its links and the tags left unused.
+	if err := db.RemoveBookmark(first); err != nil {
+		t.Fatalf("RemoveBookmark: %v", err)
+	}
+	tags, err = db.ListTags()
+	if err != nil {
+		t.Fatalf("ListTags: %v", err)
+	}
+	if want := []TagCount{{"암송", 1}}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("expected tags %v after remove, got %v", want, tags)
+	}
+	var unused int
+	db.conn.QueryRow("SELECT COUNT(*) FROM tags WHERE name = '복음'").Scan(&unused)
+	if unused != 0 {
+		t.Error("expected unused tag to be deleted")
+	}
+}
+
+func TestBookmarkWithoutTags(t *testing.T) {
+	db, verseID := setupBookmarkDB(t)
+
+	id, err := db.AddBookmark(verseID, "")
+	if err != nil {
+		t.Fatalf("AddBookmark: %v", err)
+	}
+	bm, err := db.GetBookmark(id)
+	if err != nil {
+		t.Fatalf("GetBookmark: %v", err)
+	}
+	if bm.Tags == nil || len(bm.Tags) != 0 {
+		t.Errorf("expected empty non-nil tags, got %#v", bm.Tags)
+	}
+}
+
+func TestNormalizeTag(t *testing.T) {
+	tests := map[string]string{
+		"#암송":      "암송",
+		" 암송 ":     "암송",
+		"암\x1f송":   "암송",
+		"\t#복음\n":  "복음",
+		"\x00\x1f": "",
+	}
+	for in, want := range tests {
+		if got := NormalizeTag(in); got != want {
+			t.Errorf("NormalizeTag(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	// A separator in a tag name does not split it into two tags.
+	db, verseID := setupBookmarkDB(t)
+	id, err := db.AddBookmark(verseID, "")
+	if err != nil {
+		t.Fatalf("AddBookmark: %v", err)
+	}
+	if err := db.TagBookmark(id, "암"+tagSeparator+"송"); err != nil {
+		t.Fatalf("TagBookmark: %v", err)
+	}
+	if bm, _ := db.GetBookmark(id); !reflect.DeepEqual(bm.Tags, []string{"암송"}) {
+		t.Errorf("expected one tag 암송, got %q", bm.Tags)
+	}
+}
@@ -0,0 +1,549 @@
+// Package server serves the Bible database as a JSON HTTP API, for local
+// tools such as church display and chat programs.
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
+	"github.com/yangsijun/bible-tui/internal/db"
+)
+
+// Server routes the /v1 API to a database. Requests without a version
+// parameter use the version the server was created with.
+type Server struct {
+	db      *db.DB
+	version string
+	mux     *http.ServeMux
+}
+
+// New returns a server for database whose default version is versionCode.
+func New(database *db.DB, versionCode string) *Server {
+	s := &Server{db: database, version: versionCode, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /v1/versions", s.handleVersions)
+	s.mux.HandleFunc("GET /v1/books", s.handleBooks)
+	s.mux.HandleFunc("GET /v1/passage", s.handlePassage)
+	s.mux.HandleFunc("GET /v1/search", s.handleSearch)
+	s.mux.HandleFunc("GET /v1/random", s.handleRandom)
+	s.mux.HandleFunc("GET /v1/bookmarks", s.handleListBookmarks)
+	s.mux.HandleFunc("POST /v1/bookmarks", s.handleAddBookmarks)
+	s.mux.HandleFunc("GET /v1/bookmarks/{id}", s.handleGetBookmark)
+	s.mux.HandleFunc("PATCH /v1/bookmarks/{id}", s.handleUpdateBookmark)
+	s.mux.HandleFunc("DELETE /v1/bookmarks/{id}", s.handleDeleteBookmark)
+	s.mux.HandleFunc("GET /v1/plans", s.handleListPlans)
+	s.mux.HandleFunc("GET /v1/plans/{id}", s.handleGetPlan)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// maxListLimit caps the limit parameter of list and search endpoints.
+const maxListLimit = 500
+
+// PassageResponse is the body of /v1/passage.
+type PassageResponse struct {
+	Reference string        `json:"reference"`
+	Version   string        `json:"version"`
+	Verses    []db.Verse    `json:"verses"`
+	Footnotes []db.Footnote `json:"footnotes"`
+}
+
+// SearchResponse is the body of /v1/search, the same shape as
+// `bible search --format json`.
+type SearchResponse struct {
+	Query  string `json:"query"`
+	Regex  bool   `json:"regex"`
+	Offset int    `json:"offset"`
+	*db.SearchPage
+}
+
+// PlanProgress is a reading plan with how much of it is read. Today lists
+// today's entries and is only filled in by /v1/plans/{id}.
+type PlanProgress struct {
+	db.ReadingPlan
+	Completed int            `json:"completed"`
+	Total     int            `json:"total"`
+	Today     []db.PlanEntry `json:"today,omitempty"`
+}
+
+// ErrorResponse is the body of error responses from the endpoints.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) handleVersions(w http.ResponseWriter, r *http.Request) {
+	versions, err := s.db.ListVersions()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, nonNil(versions))
+}
+
+func (s *Server) handleBooks(w http.ResponseWriter, r *http.Request) {
+	version, ok := s.versionParam(w, r)
+	if !ok {
+		return
+	}
+	books, err := s.db.ListBooks(version)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, nonNil(books))
+}
+
+// handlePassage serves the verses of a reference such as 요3:16-18. The
+// text of a version rarely changes, so responses carry an ETag and
+// If-None-Match is answered with 304 Not Modified.
+func (s *Server) handlePassage(w http.ResponseWriter, r *http.Request) {
+	ref := strings.TrimSpace(r.URL.Query().Get("ref"))
+	if ref == "" {
+		writeError(w, http.StatusBadRequest, "missing ref parameter")
+		return
+	}
+	ranges, err := bible.ParseReferences(ref)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "parse reference: %v", err)
+		return
+	}
+	version, ok := s.versionParam(w, r)
+	if !ok {
+		return
+	}
+
+	verses, err := s.db.GetPassage(version, ranges)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if len(verses) == 0 {
+		writeError(w, http.StatusNotFound, "no verses found for %s", bible.FormatReferences(ranges))
+		return
+	}
+
+	resp := PassageResponse{
+		Reference: bible.FormatReferences(ranges),
+		Version:   version,
+		Verses:    verses,
+		Footnotes: []db.Footnote{},
+	}
+	byVerse := make(map[int64][]db.Footnote)
+	for _, ch := range chaptersOf(verses) {
+		list, err := s.db.GetFootnotes(version, ch.BookCode, ch.Chapter)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		for _, fn := range list {
+			byVerse[fn.VerseID] = append(byVerse[fn.VerseID], fn)
+		}
+	}
+	for _, v := range verses {
+		resp.Footnotes = append(resp.Footnotes, byVerse[v.ID]...)
+	}
+
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(resp); err != nil {
+		writeError(w, http.StatusInternalServerError, "encode passage: %v", err)
+		return
+	}
+	sum := sha256.Sum256(body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body.Bytes())
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	text := strings.TrimSpace(params.Get("q"))
+	if text == "" {
+		writeError(w, http.StatusBadRequest, "missing q parameter")
+		return
+	}
+	regex := params.Get("regex") == "true" || params.Get("regex") == "1"
+	q, err := db.ParseSearchQuery(text)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "parse search query: %v", err)
+		return
+	}
+	if regex {
+		if _, err := regexp.Compile(q.Text); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid regex: %v", err)
+			return
+		}
+	} else if err := q.CheckExpr(); err != nil {
+		writeError(w, http.StatusBadRequest, "search query: %v", err)
+		return
+	}
+	order := db.SortRelevance
+	if v := params.Get("sort"); v != "" {
+		if order, err = db.ParseSearchSort(v); err != nil {
+			writeError(w, http.StatusBadRequest, "sort: %v", err)
+			return
+		}
+	}
+	limit, offset, ok := pageParams(w, r, 20)
+	if !ok {
+		return
+	}
+	version, ok := s.versionParam(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := s.db.Search(version, q, db.SearchOptions{Sort: order, Offset: offset, Limit: limit, Regex: regex})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	page.Results = nonNil(page.Results)
+	page.BookCounts = nonNil(page.BookCounts)
+	writeJSON(w, http.StatusOK, SearchResponse{Query: text, Regex: regex, Offset: offset, SearchPage: page})
+}
+
+func (s *Server) handleRandom(w http.ResponseWriter, r *http.Request) {
+	version, ok := s.versionParam(w, r)
+	if !ok {
+		return
+	}
+	verse, err := s.db.GetRandomVerse(version)
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(w, http.StatusNotFound, "no verses in version %s", version)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, verse)
+}
+
+func (s *Server) handleListBookmarks(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := pageParams(w, r, 50)
+	if !ok {
+		return
+	}
+	var bookmarks []db.BookmarkWithVerse
+	var err error
+	if tag := r.URL.Query().Get("tag"); tag != "" {
+		bookmarks, err = s.db.ListBookmarksByTag(tag, limit, offset)
+	} else {
+		bookmarks, err = s.db.ListBookmarks(limit, offset)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, nonNil(bookmarks))
+}
+
+// bookmarkRequest is the body of POST /v1/bookmarks and PATCH
+// /v1/bookmarks/{id}. POST adds one bookmark per range of Ref; PATCH
+// only changes the note.
+type bookmarkRequest struct {
+	Ref     string   `json:"ref"`
+	Version string   `json:"version"`
+	Note    *string  `json:"note"`
+	Tags    []string `json:"tags"`
+}
+
+func (s *Server) handleAddBookmarks(w http.ResponseWriter, r *http.Request) {
+	var req bookmarkRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	ranges, err := bible.ParseReferences(req.Ref)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "parse reference: %v", err)
+		return
+	}
+	for _, rg := range ranges {
+		if rg.Start.Verse == 0 || rg.End.Verse == 0 {
+			writeError(w, http.StatusBadRequest, "specific verse required (e.g., 창 1:1)")
+			return
+		}
+		if rg.Start.BookCode != rg.End.BookCode {
+			writeError(w, http.StatusBadRequest, "range must stay within one book: %s", rg)
+			return
+		}
+	}
+	version := s.version
+	if req.Version != "" {
+		version = strings.ToUpper(req.Version)
+	}
+	spans := make([][]db.Verse, len(ranges))
+	for i, rg := range ranges {
+		verses, err := s.db.GetPassage(version, []bible.Range{rg})
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		if len(verses) == 0 {
+			writeError(w, http.StatusNotFound, "verse not found: %s", rg)
+			return
+		}
+		spans[i] = verses
+	}
+
+	note := ""
+	if req.Note != nil {
+		note = *req.Note
+	}
+	verseIDs := make([][2]int64, len(spans))
+	for i, verses := range spans {
+		verseIDs[i] = [2]int64{verses[0].ID, verses[len(verses)-1].ID}
+	}
+	ids, err := s.db.AddBookmarkRanges(verseIDs, note, req.Tags...)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	created := []db.BookmarkWithVerse{}
+	for _, id := range ids {
+		bm, err := s.db.GetBookmark(id)
+		if err != nil || bm == nil {
+			writeError(w, http.StatusInternalServerError, "get bookmark %d: %v", id, err)
+			return
+		}
+		created = append(created, *bm)
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) handleGetBookmark(w http.ResponseWriter, r *http.Request) {
+	bm, ok := s.bookmarkParam(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, bm)
+}
+
+func (s *Server) handleUpdateBookmark(w http.ResponseWriter, r *http.Request) {
+	bm, ok := s.bookmarkParam(w, r)
+	if !ok {
+		return
+	}
+	var req bookmarkRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if req.Note == nil {
+		writeError(w, http.StatusBadRequest, "missing note")
+		return
+	}
+	if req.Tags != nil {
+		writeError(w, http.StatusBadRequest, "tags can only be set when adding a bookmark")
+		return
+	}
+	if err := s.db.UpdateBookmarkNote(bm.ID, *req.Note); err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	bm.Note = *req.Note
+	writeJSON(w, http.StatusOK, bm)
+}
+
+func (s *Server) handleDeleteBookmark(w http.ResponseWriter, r *http.Request) {
+	bm, ok := s.bookmarkParam(w, r)
+	if !ok {
+		return
+	}
+	if err := s.db.RemoveBookmark(bm.ID); err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleListPlans(w http.ResponseWriter, r *http.Request) {
+	plans, err := s.db.ListPlans()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	progress := []PlanProgress{}
+	for _, p := range plans {
+		completed, total, err := s.db.GetPlanProgress(p.ID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "%v", err)
+			return
+		}
+		progress = append(progress, PlanProgress{ReadingPlan: p, Completed: completed, Total: total})
+	}
+	writeJSON(w, http.StatusOK, progress)
+}
+
+func (s *Server) handleGetPlan(w http.ResponseWriter, r *http.Request) {
+	id, ok := idParam(w, r)
+	if !ok {
+		return
+	}
+	p, err := s.db.GetPlan(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	if p == nil {
+		writeError(w, http.StatusNotFound, "plan %d not found", id)
+		return
+	}
+	completed, total, err := s.db.GetPlanProgress(p.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	today, err := s.db.GetTodayEntries(p.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, PlanProgress{ReadingPlan: *p, Completed: completed, Total: total, Today: nonNil(today)})
+}
+
+// versionParam returns the version parameter of r, or the server's
+// default version. It writes 404 for a version that is not in the
+// database.
+func (s *Server) versionParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	version := s.version
+	if v := r.URL.Query().Get("version"); v != "" {
+		version = strings.ToUpper(v)
+	}
+	if _, err := s.db.GetVersionByCode(version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, "unknown version %s", version)
+		} else {
+			writeError(w, http.StatusInternalServerError, "%v", err)
+		}
+		return "", false
+	}
+	return version, true
+}
+
+// bookmarkParam loads the bookmark named by the {id} path parameter,
+// writing 400 or 404 when there is none.
+func (s *Server) bookmarkParam(w http.ResponseWriter, r *http.Request) (*db.BookmarkWithVerse, bool) {
+	id, ok := idParam(w, r)
+	if !ok {
+		return nil, false
+	}
+	bm, err := s.db.GetBookmark(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "%v", err)
+		return nil, false
+	}
+	if bm == nil {
+		writeError(w, http.StatusNotFound, "bookmark %d not found", id)
+		return nil, false
+	}
+	return bm, true
+}
+
+func idParam(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid id %q", r.PathValue("id"))
+		return 0, false
+	}
+	return id, true
+}
+
+// pageParams parses the limit and offset parameters.
+func pageParams(w http.ResponseWriter, r *http.Request, defaultLimit int) (limit, offset int, ok bool) {
+	limit, offset = defaultLimit, 0
+	params := r.URL.Query()
+	if v := params.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxListLimit {
+			writeError(w, http.StatusBadRequest, "limit must be between 1 and %d", maxListLimit)
+			return 0, 0, false
+		}
+		limit = n
+	}
+	if v := params.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "offset must not be negative")
+			return 0, 0, false
+		}
+		offset = n
+	}
+	return limit, offset, true
+}
+
+// maxBodySize bounds request bodies, which are small JSON objects.
+const maxBodySize = 64 << 10
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %v", err)
+		return false
+	}
+	return true
+}
+
+// chaptersOf lists the distinct chapters of verses, in order.
+func chaptersOf(verses []db.Verse) []bible.Location {
+	var chapters []bible.Location
+	for _, v := range verses {
+		loc := bible.Location{BookCode: v.BookCode, Chapter: v.Chapter}
+		if len(chapters) == 0 || chapters[len(chapters)-1] != loc {
+			chapters = append(chapters, loc)
+		}
+	}
+	return chapters
+}
+
+// etagMatches reports whether an If-None-Match header lists etag.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// nonNil makes empty lists encode as [] rather than null.
+func nonNil[T any](list []T) []T {
+	if list == nil {
+		return []T{}
+	}
+	return list
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeJSON(w, status, ErrorResponse{Error: fmt.Sprintf(format, args...)})
+}
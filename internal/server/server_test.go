@@ -0,0 +1,358 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/yangsijun/bible-tui/internal/db"
+)
+
+func setupServer(t *testing.T) (*httptest.Server, *db.DB) {
+	t.Helper()
+	database, err := db.OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	if err := database.Migrate(); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	vID, err := database.InsertVersion("GAE", "개역개정", "ko")
+	if err != nil {
+		t.Fatalf("InsertVersion: %v", err)
+	}
+	genID, err := database.InsertBook(vID, "gen", "창세기", "창", "old", 50, 1)
+	if err != nil {
+		t.Fatalf("InsertBook: %v", err)
+	}
+	jhnID, err := database.InsertBook(vID, "jhn", "요한복음", "요", "new", 21, 43)
+	if err != nil {
+		t.Fatalf("InsertBook: %v", err)
+	}
+	if _, err := database.InsertVerse(genID, 1, 1, "태초에 하나님이 천지를 창조하시니라", "천지 창조", false); err != nil {
+		t.Fatalf("InsertVerse: %v", err)
+	}
+	verses := []string{
+		"하나님이 세상을 이처럼 사랑하사 독생자를 주셨으니 이는 그를 믿는 자마다 멸망하지 않고 영생을 얻게 하려 하심이라",
+		"하나님이 그 아들을 세상에 보내신 것은 세상을 심판하려 하심이 아니요",
+		"그를 믿는 자는 심판을 받지 아니하는 것이요",
+	}
+	for i, text := range verses {
+		id, err := database.InsertVerse(jhnID, 3, 16+i, text, "", i == 0)
+		if err != nil {
+			t.Fatalf("InsertVerse: %v", err)
+		}
+		if i == 0 {
+			if err := database.InsertFootnote(id, "1)", "또는 독특한"); err != nil {
+				t.Fatalf("InsertFootnote: %v", err)
+			}
+		}
+	}
+
+	ts := httptest.NewServer(New(database, "GAE"))
+	t.Cleanup(ts.Close)
+	return ts, database
+}
+
+// do sends a request and decodes a JSON response body into out.
+func do(t *testing.T, method, url, body string, out any) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("%s %s: decode: %v", method, url, err)
+		}
+	}
+	return resp
+}
+
+func TestVersionsAndBooks(t *testing.T) {
+	ts, _ := setupServer(t)
+
+	var versions []db.Version
+	if resp := do(t, "GET", ts.URL+"/v1/versions", "", &versions); resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+	if len(versions) != 1 || versions[0].Code != "GAE" || versions[0].Name != "개역개정" {
+		t.Errorf("unexpected versions: %+v", versions)
+	}
+
+	var books []db.Book
+	do(t, "GET", ts.URL+"/v1/books", "", &books)
+	if len(books) != 2 || books[0].Code != "gen" || books[1].NameKo != "요한복음" || books[1].ChapterCount != 21 {
+		t.Errorf("unexpected books: %+v", books)
+	}
+
+	var e ErrorResponse
+	if resp := do(t, "GET", ts.URL+"/v1/books?version=kjv", "", &e); resp.StatusCode != http.StatusNotFound || !strings.Contains(e.Error, "KJV") {
+		t.Errorf("expected 404 for an unknown version, got %d %q", resp.StatusCode, e.Error)
+	}
+}
+
+func TestPassage(t *testing.T) {
+	ts, _ := setupServer(t)
+
+	var p PassageResponse
+	resp := do(t, "GET", ts.URL+"/v1/passage?ref="+url.QueryEscape("요3:16-17"), "", &p)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+	if p.Reference != "요한복음 3:16-17" || p.Version != "GAE" || len(p.Verses) != 2 || p.Verses[1].VerseNum != 17 {
+		t.Errorf("unexpected passage: %+v", p)
+	}
+	if len(p.Footnotes) != 1 || p.Footnotes[0].VerseID != p.Verses[0].ID {
+		t.Errorf("unexpected footnotes: %+v", p.Footnotes)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	tests := []struct {
+		ref    string
+		status int
+	}{
+		{"", http.StatusBadRequest},
+		{"없는책 1:1", http.StatusBadRequest},
+		{"창 2:1", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		var e ErrorResponse
+		resp := do(t, "GET", ts.URL+"/v1/passage?ref="+url.QueryEscape(tt.ref), "", &e)
+		if resp.StatusCode != tt.status || e.Error == "" {
+			t.Errorf("ref %q: got %d %q, want %d", tt.ref, resp.StatusCode, e.Error, tt.status)
+		}
+	}
+}
+
+func TestPassageETag(t *testing.T) {
+	ts, database := setupServer(t)
+	passage := ts.URL + "/v1/passage?ref=" + url.QueryEscape("요 3:16")
+
+	resp := do(t, "GET", passage, "", nil)
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag")
+	}
+	if again := do(t, "GET", passage, "", nil); again.Header.Get("ETag") != etag {
+		t.Errorf("ETag changed between identical responses: %q, %q", etag, again.Header.Get("ETag"))
+	}
+
+	req, _ := http.NewRequest("GET", passage, nil)
+	req.Header.Set("If-None-Match", etag)
+	cached, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached.Body.Close()
+	if cached.StatusCode != http.StatusNotModified {
+		t.Errorf("expected 304 for a matching If-None-Match, got %d", cached.StatusCode)
+	}
+
+	// Another passage, or the same one after the text changes, is a new tag.
+	if other := do(t, "GET", ts.URL+"/v1/passage?ref="+url.QueryEscape("요 3:17"), "", nil); other.Header.Get("ETag") == etag {
+		t.Error("expected a different ETag for a different passage")
+	}
+	if _, err := database.ResetCrawlData("GAE", "jhn"); err != nil {
+		t.Fatal(err)
+	}
+	books, err := database.ListBooks("GAE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.InsertVerse(books[1].ID, 3, 16, "고친 본문", "", false); err != nil {
+		t.Fatal(err)
+	}
+	req, _ = http.NewRequest("GET", passage, nil)
+	req.Header.Set("If-None-Match", etag)
+	changed, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed.Body.Close()
+	if changed.StatusCode != http.StatusOK || changed.Header.Get("ETag") == etag {
+		t.Errorf("expected 200 with a new ETag after the text changed, got %d %q", changed.StatusCode, changed.Header.Get("ETag"))
+	}
+}
+
+func TestSearch(t *testing.T) {
+	ts, _ := setupServer(t)
+
+	var s SearchResponse
+	resp := do(t, "GET", ts.URL+"/v1/search?q="+url.QueryEscape("심판")+"&sort=canonical&limit=1", "", &s)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+	if s.Query != "심판" || s.Total != 2 || len(s.Results) != 1 || s.Results[0].Verse.VerseNum != 17 {
+		t.Errorf("unexpected search response: %+v", s)
+	}
+
+	s = SearchResponse{}
+	do(t, "GET", ts.URL+"/v1/search?regex=1&q="+url.QueryEscape("^그를"), "", &s)
+	if !s.Regex || s.Total != 1 || s.Results[0].Verse.VerseNum != 18 {
+		t.Errorf("unexpected regex search: %+v", s)
+	}
+
+	for _, query := range []string{"", "q=" + url.QueryEscape("하나님("), "q=a&regex=1&limit=x", "q=a&sort=alpha", "regex=1&q=" + url.QueryEscape("하나님(")} {
+		var e ErrorResponse
+		resp := do(t, "GET", ts.URL+"/v1/search?"+query, "", &e)
+		if resp.StatusCode != http.StatusBadRequest || e.Error == "" {
+			t.Errorf("%q: expected 400, got %d %q", query, resp.StatusCode, e.Error)
+		}
+	}
+}
+
+func TestRandom(t *testing.T) {
+	ts, _ := setupServer(t)
+
+	var v db.Verse
+	if resp := do(t, "GET", ts.URL+"/v1/random", "", &v); resp.StatusCode != http.StatusOK || v.Text == "" {
+		t.Errorf("expected a verse, got %d %+v", resp.StatusCode, v)
+	}
+}
+
+func TestBookmarksCRUD(t *testing.T) {
+	ts, _ := setupServer(t)
+
+	var list []db.BookmarkWithVerse
+	do(t, "GET", ts.URL+"/v1/bookmarks", "", &list)
+	if list == nil || len(list) != 0 {
+		t.Fatalf("expected an empty list, got %+v", list)
+	}
+
+	var created []db.BookmarkWithVerse
+	resp := do(t, "POST", ts.URL+"/v1/bookmarks", `{"ref": "요 3:16-17, 18", "note": "복음"}`, &created)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("POST status %d", resp.StatusCode)
+	}
+	// One bookmark per range: 16-17 and 18.
+	if len(created) != 2 || created[0].Note != "복음" || created[0].VerseNum != 16 || created[0].EndVerse != 17 || created[1].VerseNum != 18 || created[0].BookCode != "jhn" || created[0].VersionCode != "GAE" {
+		t.Fatalf("unexpected created bookmarks: %+v", created)
+	}
+	id := created[0].ID
+	item := ts.URL + "/v1/bookmarks/" + itoa(id)
+
+	var bm db.BookmarkWithVerse
+	if resp := do(t, "GET", item, "", &bm); resp.StatusCode != http.StatusOK || bm.ID != id || bm.VerseText == "" {
+		t.Errorf("GET: %d %+v", resp.StatusCode, bm)
+	}
+
+	bm = db.BookmarkWithVerse{}
+	if resp := do(t, "PATCH", item, `{"note": "고친 메모"}`, &bm); resp.StatusCode != http.StatusOK || bm.Note != "고친 메모" {
+		t.Errorf("PATCH: %d %+v", resp.StatusCode, bm)
+	}
+	bm = db.BookmarkWithVerse{}
+	do(t, "GET", item, "", &bm)
+	if bm.Note != "고친 메모" {
+		t.Errorf("expected the note to be saved, got %q", bm.Note)
+	}
+
+	if resp := do(t, "DELETE", item, "", nil); resp.StatusCode != http.StatusNoContent {
+		t.Errorf("DELETE status %d", resp.StatusCode)
+	}
+	if resp := do(t, "GET", item, "", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 after delete, got %d", resp.StatusCode)
+	}
+	if resp := do(t, "DELETE", item, "", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 deleting twice, got %d", resp.StatusCode)
+	}
+
+	list = nil
+	do(t, "GET", ts.URL+"/v1/bookmarks?limit=10", "", &list)
+	if len(list) != 1 || list[0].VerseNum != 18 {
+		t.Errorf("unexpected bookmarks after delete: %+v", list)
+	}
+
+	created = nil
+	do(t, "POST", ts.URL+"/v1/bookmarks", `{"ref": "요 3:18", "tags": ["암송", "#복음"]}`, &created)
+	if len(created) != 1 || strings.Join(created[0].Tags, ",") != "복음,암송" {
+		t.Fatalf("unexpected tagged bookmark: %+v", created)
+	}
+	list = nil
+	do(t, "GET", ts.URL+"/v1/bookmarks?tag=암송", "", &list)
+	if len(list) != 1 || list[0].ID != created[0].ID {
+		t.Errorf("unexpected bookmarks for tag: %+v", list)
+	}
+
+	tests := []struct {
+		method, path, body string
+		status             int
+	}{
+		{"POST", "/v1/bookmarks", `{"ref": "요 3"}`, http.StatusBadRequest},
+		{"POST", "/v1/bookmarks", `{"ref": "창 9:9"}`, http.StatusNotFound},
+		{"POST", "/v1/bookmarks", `{"verse": 1}`, http.StatusBadRequest},
+		{"POST", "/v1/bookmarks", `not json`, http.StatusBadRequest},
+		{"PATCH", "/v1/bookmarks/" + itoa(list[0].ID), `{}`, http.StatusBadRequest},
+		{"PATCH", "/v1/bookmarks/" + itoa(list[0].ID), `{"note": "", "tags": []}`, http.StatusBadRequest},
+		{"GET", "/v1/bookmarks/abc", "", http.StatusBadRequest},
+		{"PUT", "/v1/bookmarks/" + itoa(list[0].ID), `{"note": ""}`, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		if resp := do(t, tt.method, ts.URL+tt.path, tt.body, nil); resp.StatusCode != tt.status {
+			t.Errorf("%s %s %s: got %d, want %d", tt.method, tt.path, tt.body, resp.StatusCode, tt.status)
+		}
+	}
+}
+
+func TestPlans(t *testing.T) {
+	ts, database := setupServer(t)
+
+	var plans []PlanProgress
+	do(t, "GET", ts.URL+"/v1/plans", "", &plans)
+	if plans == nil || len(plans) != 0 {
+		t.Fatalf("expected an empty list, got %+v", plans)
+	}
+
+	version, err := database.GetVersionByCode("GAE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	planID, err := database.CreateCustomPlan(version.ID, "요한복음 읽기", []db.PlanEntry{
+		{DayNumber: 1, BookCode: "jhn", ChapterStart: 1, ChapterEnd: 3},
+		{DayNumber: 2, BookCode: "jhn", ChapterStart: 4, ChapterEnd: 6},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	today, err := database.GetTodayEntries(planID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.MarkEntryCompleted(today[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	do(t, "GET", ts.URL+"/v1/plans", "", &plans)
+	if len(plans) != 1 || plans[0].Name != "요한복음 읽기" || plans[0].Completed != 1 || plans[0].Total != 2 || plans[0].Today != nil {
+		t.Errorf("unexpected plans: %+v", plans)
+	}
+
+	var p PlanProgress
+	if resp := do(t, "GET", ts.URL+"/v1/plans/"+itoa(planID), "", &p); resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+	if p.ID != planID || p.TotalDays != 2 || len(p.Today) != 1 || !p.Today[0].Completed {
+		t.Errorf("unexpected plan: %+v", p)
+	}
+
+	if resp := do(t, "GET", ts.URL+"/v1/plans/999", "", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for a missing plan, got %d", resp.StatusCode)
+	}
+}
+
+func itoa(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/yangsijun/bible-tui/internal/bible"
+	"github.com/yangsijun/bible-tui/internal/config"
 	"github.com/yangsijun/bible-tui/internal/db"
 	"github.com/yangsijun/bible-tui/internal/tui/styles"
 )
@@ -22,6 +23,7 @@ const (
 	StateSettings
 	StatePlans
 	StateHelp
+	StateConcordance
 )
 
 type AppModel struct {
@@ -33,6 +35,7 @@ type AppModel struct {
 	height      int
 	ready       bool
 	statusMsg   string
+	versionCode string
 	bookList    BookListModel
 	chapterList ChapterListModel
 	reading     ReadingModel
@@ -41,14 +44,22 @@ type AppModel struct {
 	help        HelpModel
 	settings    SettingsModel
 	plans       PlanModel
+	concordance ConcordanceModel
 }
 
 func New(database *db.DB) AppModel {
+	versionCode := "GAE"
+	if database != nil {
+		if cfg, err := config.LoadConfig(database); err == nil {
+			versionCode = cfg.VersionCode
+		}
+	}
 	return AppModel{
-		state:    StateBookList,
-		db:       database,
-		theme:    styles.DefaultDarkTheme(),
-		bookList: NewBookList(80, 24),
+		state:       StateBookList,
+		db:          database,
+		theme:       styles.DefaultDarkTheme(),
+		versionCode: versionCode,
+		bookList:    NewBookList(80, 24),
 	}
 }
 
@@ -57,6 +68,20 @@ func (m AppModel) Init() tea.Cmd {
 }
 
 func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	prev := m.state
+	updated, cmd := m.update(msg)
+	m = updated.(AppModel)
+	// A reading session only runs while its chapter is on screen.
+	switch {
+	case prev == StateReading && m.state != StateReading:
+		m.reading.EndSession()
+	case prev != StateReading && m.state == StateReading:
+		cmd = tea.Batch(cmd, m.reading.ResumeSession())
+	}
+	return m, cmd
+}
+
+func (m AppModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -83,29 +108,55 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if contentHeight < 1 {
 			contentHeight = 1
 		}
-		m.reading = NewReading(msg.Book, msg.Chapter, m.db, m.theme, m.width, contentHeight)
+		compareCode := m.reading.compareCode
+		m.reading.EndSession()
+		m.reading = NewReading(msg.Book, msg.Chapter, m.versionCode, m.db, m.theme, m.width, contentHeight)
+		m.reading.compareCode = compareCode
 		m.state = StateReading
-		if m.db != nil {
-			return m, LoadVerses(m.db, msg.Book.Code, msg.Chapter)
-		}
-		return m, nil
+		return m, m.loadReading(msg.Book.Code, msg.Chapter)
 
 	case VersesLoadedMsg:
 		var cmd tea.Cmd
 		m.reading, cmd = m.reading.Update(msg)
 		return m, cmd
 
+	case CompareLoadedMsg:
+		var cmd tea.Cmd
+		m.reading, cmd = m.reading.Update(msg)
+		return m, cmd
+
+	case AnnotationsLoadedMsg:
+		var cmd tea.Cmd
+		m.reading, cmd = m.reading.Update(msg)
+		return m, cmd
+
+	case ReadingTimeMsg:
+		var cmd tea.Cmd
+		m.reading, cmd = m.reading.Update(msg)
+		return m, cmd
+
 	case SearchResultsMsg:
 		var cmd tea.Cmd
 		m.search, cmd = m.search.Update(msg)
 		return m, cmd
 
+	case SearchHistoryMsg:
+		var cmd tea.Cmd
+		m.search, cmd = m.search.Update(msg)
+		return m, cmd
+
 	case BookmarksLoadedMsg:
 		var cmd tea.Cmd
 		m.bookmarks, cmd = m.bookmarks.Update(msg)
 		return m, cmd
 
 	case BookmarkDeletedMsg:
+		var cmd tea.Cmd
+		m.bookmarks, cmd = m.bookmarks.Update(msg)
+		// The chapter left open behind the list may show what was deleted.
+		return m, tea.Batch(cmd, m.reading.ReloadAnnotations())
+
+	case BookmarkNoteSavedMsg:
 		var cmd tea.Cmd
 		m.bookmarks, cmd = m.bookmarks.Update(msg)
 		return m, cmd
@@ -125,6 +176,17 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.theme = msg.Theme
 		return m, nil
 
+	case VersionChangedMsg:
+		if msg.Err == nil {
+			m.versionCode = msg.Code
+		}
+		if m.reading.book.Code == "" {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.reading, cmd = m.reading.Update(msg)
+		return m, cmd
+
 	case PlansLoadedMsg:
 		var cmd tea.Cmd
 		m.plans, cmd = m.plans.Update(msg)
@@ -145,6 +207,31 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.plans, cmd = m.plans.Update(msg)
 		return m, cmd
 
+	case PlanRescheduledMsg:
+		var cmd tea.Cmd
+		m.plans, cmd = m.plans.Update(msg)
+		return m, cmd
+
+	case PlanFilesLoadedMsg:
+		var cmd tea.Cmd
+		m.plans, cmd = m.plans.Update(msg)
+		return m, cmd
+
+	case OpenConcordanceMsg:
+		contentHeight := m.height - 3
+		if contentHeight < 1 {
+			contentHeight = 1
+		}
+		m.prevState = m.state
+		m.state = StateConcordance
+		m.concordance = NewConcordance(m.db, m.versionCode, msg.Word, m.theme, m.width, contentHeight)
+		return m, LoadConcordance(m.db, m.versionCode, msg.Word)
+
+	case ConcordanceLoadedMsg:
+		var cmd tea.Cmd
+		m.concordance, cmd = m.concordance.Update(msg)
+		return m, cmd
+
 	case GoToVerseMsg:
 		book := findBookByCode(msg.BookCode)
 		if book != nil {
@@ -152,11 +239,13 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if contentHeight < 1 {
 				contentHeight = 1
 			}
-			m.reading = NewReading(*book, msg.Chapter, m.db, m.theme, m.width, contentHeight)
+			compareCode := m.reading.compareCode
+			m.reading.EndSession()
+			m.reading = NewReading(*book, msg.Chapter, m.versionCode, m.db, m.theme, m.width, contentHeight)
+			m.reading.targetVerse = msg.Verse
+			m.reading.compareCode = compareCode
 			m.state = StateReading
-			if m.db != nil {
-				return m, LoadVerses(m.db, msg.BookCode, msg.Chapter)
-			}
+			return m, m.loadReading(msg.BookCode, msg.Chapter)
 		}
 		return m, nil
 
@@ -167,10 +256,40 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+		if m.state == StateReading && m.reading.EditingNote() && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m.reading, cmd = m.reading.Update(msg)
+			return m, cmd
+		}
+
+		if m.state == StateReading && m.reading.Selecting() && msg.String() == "esc" {
+			var cmd tea.Cmd
+			m.reading, cmd = m.reading.Update(msg)
+			return m, cmd
+		}
+
+		if m.state == StateBookmarks && m.bookmarks.EditingNote() && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m.bookmarks, cmd = m.bookmarks.Update(msg)
+			return m, cmd
+		}
+
+		if m.state == StateSearch && m.search.Naming() && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			return m, cmd
+		}
+
+		if m.state == StatePlans && m.plans.InSubView() && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m.plans, cmd = m.plans.Update(msg)
+			return m, cmd
+		}
+
 		if m.state == StateSearch && m.search.input.Focused() {
 			switch msg.String() {
 			case "ctrl+c":
-				return m, tea.Quit
+				return m.quit()
 			case "esc":
 				m.state = m.prevState
 				return m, nil
@@ -183,10 +302,10 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch msg.String() {
 		case "ctrl+c":
-			return m, tea.Quit
+			return m.quit()
 		case "q":
 			if m.state != StateSearch {
-				return m, tea.Quit
+				return m.quit()
 			}
 		case "?":
 			if m.state != StateHelp {
@@ -201,7 +320,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case "esc":
 			switch m.state {
-			case StateHelp, StateSearch, StateBookmarks, StateSettings:
+			case StateHelp, StateSearch, StateBookmarks, StateSettings, StateConcordance:
 				m.state = m.prevState
 			case StatePlans:
 				m.state = m.prevState
@@ -224,8 +343,8 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if contentHeight < 1 {
 					contentHeight = 1
 				}
-				m.search = NewSearch(m.db, m.theme, m.width, contentHeight)
-				return m, m.search.input.Focus()
+				m.search = NewSearch(m.db, m.versionCode, m.theme, m.width, contentHeight)
+				return m, tea.Batch(m.search.input.Focus(), LoadSearchHistory(m.db))
 			}
 			return m, nil
 		case "m":
@@ -260,7 +379,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if contentHeight < 1 {
 					contentHeight = 1
 				}
-				m.plans = NewPlans(m.db, m.theme, m.width, contentHeight)
+				m.plans = NewPlans(m.db, m.versionCode, m.theme, m.width, contentHeight)
 				return m, LoadPlans(m.db)
 			}
 			return m, nil
@@ -299,6 +418,10 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			var cmd tea.Cmd
 			m.plans, cmd = m.plans.Update(msg)
 			return m, cmd
+		case StateConcordance:
+			var cmd tea.Cmd
+			m.concordance, cmd = m.concordance.Update(msg)
+			return m, cmd
 		}
 	}
 	return m, nil
@@ -327,6 +450,8 @@ func (m AppModel) View() string {
 		content = m.settings.View()
 	case StatePlans:
 		content = m.plans.View()
+	case StateConcordance:
+		content = m.concordance.View()
 	default:
 		content = m.bookList.View()
 	}
@@ -389,7 +514,28 @@ func (m AppModel) stateLabel() string {
 		return "읽기 계획"
 	case StateHelp:
 		return "도움말"
+	case StateConcordance:
+		return "용어 색인"
 	default:
 		return ""
 	}
 }
+
+// loadReading loads a chapter into the reading view, along with the
+// comparison version when the parallel view is open.
+func (m AppModel) loadReading(bookCode string, chapter int) tea.Cmd {
+	if m.db == nil {
+		return nil
+	}
+	cmd := LoadVerses(m.db, m.versionCode, bookCode, chapter)
+	if m.reading.compareCode != "" {
+		return tea.Batch(cmd, LoadCompareVerses(m.db, m.reading.compareCode, bookCode, chapter))
+	}
+	return cmd
+}
+
+// quit leaves the app, logging the time spent on the open chapter first.
+func (m AppModel) quit() (tea.Model, tea.Cmd) {
+	m.reading.EndSession()
+	return m, tea.Quit
+}
@@ -1,9 +1,13 @@
 package tui
 
 import (
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
+	"github.com/yangsijun/bible-tui/internal/db"
 )
 
 func TestAppInit(t *testing.T) {
@@ -126,3 +130,165 @@ func TestAppViewReady(t *testing.T) {
 		t.Error("expected non-empty view")
 	}
 }
+
+func TestAppVersionChanged(t *testing.T) {
+	m := New(nil)
+	if m.versionCode != "GAE" {
+		t.Errorf("expected default versionCode GAE, got %q", m.versionCode)
+	}
+	updated, _ := m.Update(VersionChangedMsg{Code: "HAN"})
+	model := updated.(AppModel)
+	if model.versionCode != "HAN" {
+		t.Errorf("expected versionCode HAN, got %q", model.versionCode)
+	}
+}
+
+func TestAppKeepsCompareAcrossChapters(t *testing.T) {
+	m := New(nil)
+	m.reading.compareCode = "HAN"
+	book := *findBookByCode("gen")
+	updated, _ := m.Update(ChapterSelectedMsg{Book: book, Chapter: 2})
+	model := updated.(AppModel)
+	if model.reading.compareCode != "HAN" {
+		t.Errorf("expected compareCode HAN to persist, got %q", model.reading.compareCode)
+	}
+}
+
+func TestAppOpenConcordance(t *testing.T) {
+	m := New(nil)
+	m.width, m.height, m.ready = 80, 24, true
+	m.state = StateReading
+
+	updated, cmd := m.Update(OpenConcordanceMsg{Word: "빛"})
+	app := updated.(AppModel)
+	if app.state != StateConcordance || cmd == nil {
+		t.Fatalf("expected concordance state with a load command, got state %d", app.state)
+	}
+	if !strings.Contains(app.View(), "용어 색인") {
+		t.Error("expected concordance view")
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	if updated.(AppModel).state != StateReading {
+		t.Error("expected Esc to return to the reading view")
+	}
+}
+
+func TestAppSearchNamingKeepsEsc(t *testing.T) {
+	m := New(nil)
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	app := updated.(AppModel)
+	app.search.query = "빛"
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	app = updated.(AppModel)
+	if !app.search.Naming() {
+		t.Fatal("expected Ctrl+S to ask for a name")
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	app = updated.(AppModel)
+	if app.state != StateSearch || app.search.Naming() {
+		t.Errorf("expected Esc to cancel naming and stay in search, got state %d", app.state)
+	}
+}
+
+func TestAppBookmarkNoteKeepsKeys(t *testing.T) {
+	m := New(nil)
+	m.state = StateReading
+	m.reading = NewReading(bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}, 1, "GAE", nil, m.theme, 80, 24)
+	m.reading, _ = m.reading.Update(VersesLoadedMsg{Verses: []db.Verse{{ID: 1, Chapter: 1, VerseNum: 1, Text: "태초에"}}})
+	m.reading.note = NewNoteEditor("책갈피 메모", "태초에", "", m.theme, 80)
+	m.reading.editingNote = true
+
+	// q, / and m are typed into the note instead of quitting or switching views.
+	var updated tea.Model = m
+	for _, r := range "q/m" {
+		updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	app := updated.(AppModel)
+	if app.state != StateReading || app.reading.note.Value() != "q/m" {
+		t.Fatalf("expected keys typed into the note, got state %d note %q", app.state, app.reading.note.Value())
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	app = updated.(AppModel)
+	if app.state != StateReading || app.reading.EditingNote() {
+		t.Errorf("expected Esc to close the editor and stay in reading, got state %d", app.state)
+	}
+}
+
+func TestAppEscClosesSelectionFirst(t *testing.T) {
+	m := New(nil)
+	m.state = StateReading
+	m.reading = NewReading(bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}, 1, "GAE", nil, m.theme, 80, 24)
+	m.reading, _ = m.reading.Update(VersesLoadedMsg{Verses: []db.Verse{{ID: 1, Chapter: 1, VerseNum: 1, Text: "태초에"}}})
+
+	var updated tea.Model = m
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	app := updated.(AppModel)
+	if app.state != StateReading || app.reading.Selecting() {
+		t.Fatalf("expected Esc to close the selection and stay in reading, got state %d", app.state)
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	if app := updated.(AppModel); app.state != StateChapterList {
+		t.Errorf("expected a second Esc to leave the chapter, got state %d", app.state)
+	}
+}
+
+func TestAppPlanTodayKeepsS(t *testing.T) {
+	m := New(nil)
+	m.state = StatePlans
+	m.plans = NewPlans(nil, "GAE", m.theme, 80, 24)
+	m.plans.viewState = PlanViewToday
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	app := updated.(AppModel)
+	if app.state != StatePlans || app.plans.statusMsg != "밀린 날이 없습니다" {
+		t.Errorf("expected s to reach the plan view, got state %d status %q", app.state, app.plans.statusMsg)
+	}
+}
+
+func TestAppPlanSubViewEscBacksOut(t *testing.T) {
+	for _, view := range []PlanViewState{PlanViewToday, PlanViewCreate} {
+		m := New(nil)
+		m.prevState = StateBookList
+		m.state = StatePlans
+		m.plans = NewPlans(nil, "GAE", m.theme, 80, 24)
+		m.plans.viewState = view
+
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+		app := updated.(AppModel)
+		if app.state != StatePlans || app.plans.viewState != PlanViewList {
+			t.Errorf("view %d: expected Esc to go back to the plan list, got state %d view %d", view, app.state, app.plans.viewState)
+		}
+
+		// From the list, Esc leaves the plans.
+		updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEscape})
+		if app := updated.(AppModel); app.state != StateBookList {
+			t.Errorf("view %d: expected Esc on the list to leave the plans, got state %d", view, app.state)
+		}
+	}
+}
+
+func TestAppPlanFormKeepsKeys(t *testing.T) {
+	m := New(nil)
+	m.state = StatePlans
+	m.plans = NewPlans(nil, "GAE", m.theme, 80, 24)
+	m.plans.viewState = PlanViewCreate
+	m.plans.createIdx = m.plans.customIdx()
+	m.plans, _ = m.plans.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// q, s and p are typed into the form instead of quitting or switching views.
+	var updated tea.Model = m
+	for _, r := range "qsp" {
+		updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	app := updated.(AppModel)
+	if app.state != StatePlans || app.plans.booksInput.Value() != "qsp" {
+		t.Fatalf("expected keys typed into the form, got state %d value %q", app.state, app.plans.booksInput.Value())
+	}
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	app = updated.(AppModel)
+	if app.state != StatePlans || app.plans.Editing() {
+		t.Errorf("expected Esc to close the form and stay in plans, got state %d", app.state)
+	}
+}
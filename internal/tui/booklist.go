@@ -19,7 +19,7 @@ type bookItem struct {
 
 func (i bookItem) Title() string       { return i.info.NameKo }
 func (i bookItem) Description() string { return fmt.Sprintf("%s • %d장", i.info.AbbrevKo, i.info.ChapterCount) }
-func (i bookItem) FilterValue() string { return i.info.NameKo + " " + i.info.AbbrevKo }
+func (i bookItem) FilterValue() string { return i.info.NameKo + " " + i.info.AbbrevKo + " " + i.info.NameEn }
 
 type BookListModel struct {
 	list list.Model
@@ -21,6 +21,8 @@ const (
 type BookmarksLoadedMsg struct {
 	Bookmarks  []db.BookmarkWithVerse
 	Highlights []db.HighlightWithVerse
+	Tags       []db.TagCount
+	Tag        string // tag filter the bookmarks were loaded with
 	Err        error
 }
 
@@ -29,6 +31,12 @@ type BookmarkDeletedMsg struct {
 	Err error
 }
 
+// BookmarkNoteSavedMsg reports the note of a bookmark edited with e.
+type BookmarkNoteSavedMsg struct {
+	ID  int64
+	Err error
+}
+
 type BookmarkModel struct {
 	tab        BookmarkTab
 	bookmarks  []db.BookmarkWithVerse
@@ -39,6 +47,12 @@ type BookmarkModel struct {
 	loaded     bool
 	width      int
 	height     int
+	statusMsg  string
+	tags       []db.TagCount // tag sidebar of the bookmarks tab
+	tag        string        // bookmarks tab shows only this tag; "" for all
+
+	note        NoteEditor // note of the selected bookmark, edited with e
+	editingNote bool
 }
 
 func NewBookmarks(database *db.DB, theme *styles.Theme, width, height int) BookmarkModel {
@@ -52,11 +66,23 @@ func NewBookmarks(database *db.DB, theme *styles.Theme, width, height int) Bookm
 }
 
 func LoadBookmarks(database *db.DB) tea.Cmd {
+	return loadBookmarks(database, "")
+}
+
+// loadBookmarks loads the bookmarks carrying tag, or all bookmarks when
+// tag is empty, together with the highlights and the tag sidebar.
+func loadBookmarks(database *db.DB, tag string) tea.Cmd {
 	return func() tea.Msg {
 		if database == nil {
 			return BookmarksLoadedMsg{Err: fmt.Errorf("no database")}
 		}
-		bookmarks, err := database.ListBookmarks(50, 0)
+		var bookmarks []db.BookmarkWithVerse
+		var err error
+		if tag != "" {
+			bookmarks, err = database.ListBookmarksByTag(tag, 50, 0)
+		} else {
+			bookmarks, err = database.ListBookmarks(50, 0)
+		}
 		if err != nil {
 			return BookmarksLoadedMsg{Err: err}
 		}
@@ -64,10 +90,18 @@ func LoadBookmarks(database *db.DB) tea.Cmd {
 		if err != nil {
 			return BookmarksLoadedMsg{Err: err}
 		}
-		return BookmarksLoadedMsg{Bookmarks: bookmarks, Highlights: highlights}
+		tags, err := database.ListTags()
+		if err != nil {
+			return BookmarksLoadedMsg{Err: err}
+		}
+		return BookmarksLoadedMsg{Bookmarks: bookmarks, Highlights: highlights, Tags: tags, Tag: tag}
 	}
 }
 
+func (m BookmarkModel) reload() tea.Cmd {
+	return loadBookmarks(m.database, m.tag)
+}
+
 func (m BookmarkModel) Update(msg tea.Msg) (BookmarkModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case BookmarksLoadedMsg:
@@ -75,15 +109,34 @@ func (m BookmarkModel) Update(msg tea.Msg) (BookmarkModel, tea.Cmd) {
 		if msg.Err == nil {
 			m.bookmarks = msg.Bookmarks
 			m.highlights = msg.Highlights
+			m.tags = msg.Tags
+			m.tag = msg.Tag
+			// The last bookmark of the filtered tag is gone.
+			if m.tag != "" && m.tagIndex() == 0 {
+				m.tag = ""
+				return m, m.reload()
+			}
 		}
-		m.selected = 0
+		// Reloads after an edit or delete keep the selection in place.
+		m.selected = min(m.selected, max(m.currentListLen()-1, 0))
 		return m, nil
 	case BookmarkDeletedMsg:
 		if msg.Err == nil {
-			return m, LoadBookmarks(m.database)
+			return m, m.reload()
 		}
 		return m, nil
+	case BookmarkNoteSavedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("오류: %v", msg.Err)
+			return m, nil
+		}
+		m.statusMsg = "메모 저장"
+		return m, m.reload()
 	case tea.KeyMsg:
+		m.statusMsg = ""
+		if m.editingNote {
+			return m.updateNote(msg)
+		}
 		switch msg.String() {
 		case "tab":
 			if m.tab == TabBookmarks {
@@ -104,8 +157,32 @@ func (m BookmarkModel) Update(msg tea.Msg) (BookmarkModel, tea.Cmd) {
 				m.selected--
 			}
 			return m, nil
+		case "t", "T":
+			if m.tab != TabBookmarks || len(m.tags) == 0 || m.database == nil {
+				return m, nil
+			}
+			// Index 0 of the cycle is "all bookmarks".
+			step := 1
+			if msg.String() == "T" {
+				step = len(m.tags)
+			}
+			next := (m.tagIndex() + step) % (len(m.tags) + 1)
+			tag := ""
+			if next > 0 {
+				tag = m.tags[next-1].Name
+			}
+			m.selected = 0
+			return m, loadBookmarks(m.database, tag)
 		case "d":
 			return m, m.deleteSelected()
+		case "e":
+			if m.tab == TabBookmarks && m.selected < len(m.bookmarks) {
+				bm := m.bookmarks[m.selected]
+				title := "책갈피 메모: " + bm.Range().String()
+				m.note = NewNoteEditor(title, bm.VerseText, bm.Note, m.theme, m.width)
+				m.editingNote = true
+			}
+			return m, nil
 		case "enter":
 			return m, m.goToSelected()
 		}
@@ -113,6 +190,44 @@ func (m BookmarkModel) Update(msg tea.Msg) (BookmarkModel, tea.Cmd) {
 	return m, nil
 }
 
+// updateNote passes a key to the note editor and saves the note with
+// Ctrl+S.
+func (m BookmarkModel) updateNote(msg tea.KeyMsg) (BookmarkModel, tea.Cmd) {
+	var cmd tea.Cmd
+	m.note, cmd = m.note.Update(msg)
+	switch {
+	case m.note.Saved():
+		m.editingNote = false
+		if m.database == nil || m.selected >= len(m.bookmarks) {
+			return m, nil
+		}
+		id, note := m.bookmarks[m.selected].ID, m.note.Value()
+		return m, func() tea.Msg {
+			return BookmarkNoteSavedMsg{ID: id, Err: m.database.UpdateBookmarkNote(id, note)}
+		}
+	case m.note.Canceled():
+		m.editingNote = false
+	}
+	return m, cmd
+}
+
+// EditingNote reports whether a note is being edited, so the app passes
+// every key to the bookmarks view.
+func (m BookmarkModel) EditingNote() bool {
+	return m.editingNote
+}
+
+// tagIndex is the position of the tag filter in the sidebar, where 0 is
+// "all bookmarks" and i is m.tags[i-1].
+func (m BookmarkModel) tagIndex() int {
+	for i, tc := range m.tags {
+		if tc.Name == m.tag {
+			return i + 1
+		}
+	}
+	return 0
+}
+
 func (m BookmarkModel) currentListLen() int {
 	if m.tab == TabBookmarks {
 		return len(m.bookmarks)
@@ -132,10 +247,10 @@ func (m BookmarkModel) deleteSelected() tea.Cmd {
 		}
 	}
 	if m.tab == TabHighlights && m.selected < len(m.highlights) {
-		verseID := m.highlights[m.selected].VerseID
+		id := m.highlights[m.selected].ID
 		return func() tea.Msg {
-			err := m.database.RemoveHighlight(verseID)
-			return BookmarkDeletedMsg{ID: verseID, Err: err}
+			err := m.database.RemoveHighlightByID(id)
+			return BookmarkDeletedMsg{ID: id, Err: err}
 		}
 	}
 	return nil
@@ -170,7 +285,14 @@ func (m BookmarkModel) View() string {
 	} else {
 		highlightLabel = activeTab.Render("하이라이트")
 	}
-	b.WriteString(fmt.Sprintf("  %s  │  %s    (Tab:전환  d:삭제  Enter:이동)\n\n", bookmarkLabel, highlightLabel))
+	if m.editingNote {
+		return m.note.View()
+	}
+	b.WriteString(fmt.Sprintf("  %s  │  %s    (Tab:전환  t:태그  e:메모  d:삭제  Enter:이동)", bookmarkLabel, highlightLabel))
+	if m.statusMsg != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.Secondary).Bold(true).Render("  " + m.statusMsg))
+	}
+	b.WriteString("\n\n")
 
 	if !m.loaded {
 		b.WriteString("  로딩 중...")
@@ -178,24 +300,7 @@ func (m BookmarkModel) View() string {
 	}
 
 	if m.tab == TabBookmarks {
-		if len(m.bookmarks) == 0 {
-			b.WriteString("  책갈피가 없습니다.")
-			return b.String()
-		}
-		for i, bm := range m.bookmarks {
-			cursor := "  "
-			if i == m.selected {
-				cursor = "▸ "
-			}
-			ref := fmt.Sprintf("%s %d:%d", bm.BookName, bm.Chapter, bm.VerseNum)
-			refStyle := lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true)
-			text := truncateRunes(bm.VerseText, m.width-15)
-			line := fmt.Sprintf("%s%s — %s", cursor, refStyle.Render(ref), text)
-			if bm.Note != "" {
-				line += "\n    📝 " + bm.Note
-			}
-			b.WriteString(line + "\n")
-		}
+		b.WriteString(m.bookmarksView())
 	} else {
 		if len(m.highlights) == 0 {
 			b.WriteString("  하이라이트가 없습니다.")
@@ -206,7 +311,7 @@ func (m BookmarkModel) View() string {
 			if i == m.selected {
 				cursor = "▸ "
 			}
-			ref := fmt.Sprintf("%s %d:%d", hl.BookName, hl.Chapter, hl.VerseNum)
+			ref := hl.Range().String()
 			refStyle := lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true)
 			colorTag := lipgloss.NewStyle().Foreground(lipgloss.Color(highlightColor(hl.Color))).Render("[" + hl.Color + "]")
 			text := truncateRunes(hl.VerseText, m.width-20)
@@ -216,6 +321,75 @@ func (m BookmarkModel) View() string {
 	return b.String()
 }
 
+// tagSidebarWidth is the width of the tag sidebar left of the bookmarks.
+const tagSidebarWidth = 18
+
+// bookmarksView renders the bookmarks tab: the tag sidebar, when any
+// bookmark is tagged, next to the bookmark list.
+func (m BookmarkModel) bookmarksView() string {
+	listWidth := m.width
+	sidebar := ""
+	if len(m.tags) > 0 {
+		sidebar = m.tagSidebarView()
+		listWidth -= tagSidebarWidth + 1
+	}
+
+	var list strings.Builder
+	if len(m.bookmarks) == 0 {
+		list.WriteString("  책갈피가 없습니다.")
+	}
+	refStyle := lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true)
+	tagStyle := lipgloss.NewStyle().Foreground(m.theme.Secondary)
+	for i, bm := range m.bookmarks {
+		cursor := "  "
+		if i == m.selected {
+			cursor = "▸ "
+		}
+		ref := bm.Range().String()
+		text := truncateRunes(bm.VerseText, listWidth-15)
+		line := fmt.Sprintf("%s%s — %s", cursor, refStyle.Render(ref), text)
+		if len(bm.Tags) > 0 {
+			line += "\n    " + tagStyle.Render("#"+strings.Join(bm.Tags, " #"))
+		}
+		if bm.Note != "" {
+			line += "\n    📝 " + strings.ReplaceAll(bm.Note, "\n", "\n       ")
+		}
+		list.WriteString(line + "\n")
+	}
+
+	if sidebar == "" {
+		return list.String()
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, sidebar, list.String())
+}
+
+func (m BookmarkModel) tagSidebarView() string {
+	activeStyle := lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
+
+	current := m.tagIndex()
+	var b strings.Builder
+	b.WriteString("  " + mutedStyle.Render("태그 (t/T)") + "\n")
+	for i := 0; i <= len(m.tags); i++ {
+		label := "전체"
+		if i > 0 {
+			tc := m.tags[i-1]
+			label = fmt.Sprintf("#%s %d", truncateRunes(tc.Name, tagSidebarWidth-10), tc.Count)
+		}
+		if i == current {
+			b.WriteString("  " + activeStyle.Render("● "+label) + "\n")
+		} else {
+			b.WriteString("    " + label + "\n")
+		}
+	}
+	return lipgloss.NewStyle().
+		Width(tagSidebarWidth).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderRight(true).
+		BorderForeground(m.theme.Muted).
+		Render(strings.TrimSuffix(b.String(), "\n"))
+}
+
 func truncateRunes(s string, maxLen int) string {
 	if maxLen <= 0 {
 		return s
@@ -227,6 +401,9 @@ func truncateRunes(s string, maxLen int) string {
 	return s
 }
 
+// highlightTextColor is the text color on a highlightColor background.
+const highlightTextColor = "#1a1b26"
+
 func highlightColor(name string) string {
 	switch name {
 	case "yellow":
@@ -81,3 +81,121 @@ func TestBookmarkModel_ViewEmpty(t *testing.T) {
 		t.Error("expected empty bookmark message")
 	}
 }
+
+func TestBookmarkModel_EditNote(t *testing.T) {
+	database := setupVersionsDB(t)
+	verses, err := database.GetVerses("GAE", "gen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.AddBookmark(verses[0].ID, "처음"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.AddBookmark(verses[1].ID, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewBookmarks(database, styles.DefaultDarkTheme(), 80, 24)
+	m, _ = m.Update(LoadBookmarks(database)())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	selected := m.bookmarks[m.selected]
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if !m.EditingNote() {
+		t.Fatal("expected e to open the note editor")
+	}
+	if !strings.Contains(m.View(), "Ctrl+S:저장") {
+		t.Error("expected the editor in the view")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("새 메모")})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.EditingNote() || cmd == nil {
+		t.Fatal("expected ctrl+s to close the editor and save")
+	}
+	m, cmd = m.Update(cmd())
+	if m.statusMsg != "메모 저장" || cmd == nil {
+		t.Fatalf("expected a reload after saving, status %q", m.statusMsg)
+	}
+	m, _ = m.Update(cmd())
+
+	if m.selected != 1 || m.bookmarks[1].ID != selected.ID || m.bookmarks[1].Note != selected.Note+"새 메모" {
+		t.Errorf("expected the edited note on the same row, got %+v (selected %d)", m.bookmarks, m.selected)
+	}
+	if !strings.Contains(m.View(), "📝 "+selected.Note+"새 메모") {
+		t.Errorf("expected the note in the list, got: %s", m.View())
+	}
+
+	// Highlights have no notes.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if m.EditingNote() {
+		t.Error("expected e to do nothing on the highlights tab")
+	}
+}
+
+func TestBookmarkModel_TagFilter(t *testing.T) {
+	database := setupVersionsDB(t)
+	verses, err := database.GetVerses("GAE", "gen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	memorize, err := database.AddBookmark(verses[0].ID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.TagBookmark(memorize, "암송", "복음"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.AddBookmark(verses[1].ID, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewBookmarks(database, styles.DefaultDarkTheme(), 80, 24)
+	m, _ = m.Update(LoadBookmarks(database)())
+	if len(m.bookmarks) != 2 || len(m.tags) != 2 {
+		t.Fatalf("expected 2 bookmarks and 2 tags, got %d and %d", len(m.bookmarks), len(m.tags))
+	}
+	v := m.View()
+	for _, want := range []string{"● 전체", "#복음 1", "#암송 1", "#복음 #암송"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("expected view to contain %q, got: %s", want, v)
+		}
+	}
+
+	// t cycles 전체 → 복음 → 암송 → 전체; T goes back.
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m, _ = m.Update(cmd())
+	if m.tag != "복음" || len(m.bookmarks) != 1 || m.bookmarks[0].ID != memorize {
+		t.Fatalf("expected the 복음 bookmark only, got tag %q %+v", m.tag, m.bookmarks)
+	}
+	if !strings.Contains(m.View(), "● #복음 1") {
+		t.Errorf("expected 복음 selected in the sidebar, got: %s", m.View())
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m, _ = m.Update(cmd())
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m, _ = m.Update(cmd())
+	if m.tag != "" || len(m.bookmarks) != 2 {
+		t.Errorf("expected t to wrap to all bookmarks, got tag %q", m.tag)
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	m, _ = m.Update(cmd())
+	if m.tag != "암송" {
+		t.Errorf("expected T to go back to 암송, got %q", m.tag)
+	}
+
+	// Deleting the last bookmark of the filtered tag falls back to all.
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	m, cmd = m.Update(cmd())
+	m, cmd = m.Update(cmd())
+	if cmd == nil {
+		t.Fatal("expected a reload without the filter")
+	}
+	m, _ = m.Update(cmd())
+	if m.tag != "" || len(m.bookmarks) != 1 || len(m.tags) != 0 {
+		t.Errorf("expected all bookmarks and no tags, got tag %q, %d bookmarks, %d tags", m.tag, len(m.bookmarks), len(m.tags))
+	}
+	if strings.Contains(m.View(), "태그 (t/T)") {
+		t.Error("expected no sidebar without tags")
+	}
+}
@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/yangsijun/bible-tui/internal/db"
+	"github.com/yangsijun/bible-tui/internal/tui/styles"
+)
+
+// OpenConcordanceMsg asks the app to show the concordance of Word.
+type OpenConcordanceMsg struct {
+	Word string
+}
+
+type ConcordanceLoadedMsg struct {
+	Word        string
+	Concordance *db.Concordance
+	Err         error
+}
+
+// concordanceMaxForms is how many word forms the header lists.
+const concordanceMaxForms = 6
+
+// ConcordanceModel lists every occurrence of a word, grouped by book.
+type ConcordanceModel struct {
+	word        string
+	concordance *db.Concordance
+	results     []db.SearchResult // all books' results, in order
+	selected    int
+	loading     bool
+	err         error
+	database    *db.DB
+	versionCode string
+	theme       *styles.Theme
+	width       int
+	height      int
+}
+
+func NewConcordance(database *db.DB, versionCode, word string, theme *styles.Theme, width, height int) ConcordanceModel {
+	return ConcordanceModel{
+		word:        word,
+		loading:     true,
+		database:    database,
+		versionCode: versionCode,
+		theme:       theme,
+		width:       width,
+		height:      height,
+	}
+}
+
+func LoadConcordance(database *db.DB, versionCode, word string) tea.Cmd {
+	return func() tea.Msg {
+		if database == nil {
+			return ConcordanceLoadedMsg{Word: word, Err: fmt.Errorf("no database")}
+		}
+		c, err := database.Concordance(versionCode, word, db.SearchQuery{})
+		return ConcordanceLoadedMsg{Word: word, Concordance: c, Err: err}
+	}
+}
+
+func (m ConcordanceModel) Update(msg tea.Msg) (ConcordanceModel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ConcordanceLoadedMsg:
+		if msg.Word != m.word {
+			return m, nil
+		}
+		m.loading = false
+		m.err = msg.Err
+		if msg.Err != nil {
+			return m, nil
+		}
+		m.concordance = msg.Concordance
+		m.results = nil
+		for _, b := range msg.Concordance.Books {
+			m.results = append(m.results, b.Results...)
+		}
+		m.selected = 0
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			if m.selected < len(m.results)-1 {
+				m.selected++
+			}
+		case "k", "up":
+			if m.selected > 0 {
+				m.selected--
+			}
+		case "g":
+			m.selected = 0
+		case "G":
+			m.selected = max(len(m.results)-1, 0)
+		case "enter":
+			if m.selected < len(m.results) {
+				v := m.results[m.selected].Verse
+				return m, func() tea.Msg {
+					return GoToVerseMsg{BookCode: v.BookCode, Chapter: v.Chapter, Verse: v.VerseNum}
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m ConcordanceModel) View() string {
+	var b strings.Builder
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Primary).Padding(0, 1)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
+
+	b.WriteString(titleStyle.Render(fmt.Sprintf("용어 색인: %q", m.word)))
+	if m.loading {
+		b.WriteString("\n\n  찾는 중...")
+		return b.String()
+	}
+	if m.err != nil {
+		b.WriteString("\n\n" + lipgloss.NewStyle().Foreground(m.theme.Error).Render("  "+m.err.Error()))
+		return b.String()
+	}
+	c := m.concordance
+	if c.Total == 0 {
+		b.WriteString("\n\n  이 단어가 나오는 구절이 없습니다.")
+		return b.String()
+	}
+	b.WriteString(mutedStyle.Render(fmt.Sprintf("  %d회 · %d절 · %d권 · Enter:본문으로", c.Total, c.Verses, len(c.Books))))
+	b.WriteString("\n")
+	if len(c.Forms) > 0 {
+		var forms []string
+		for _, f := range c.Forms[:min(len(c.Forms), concordanceMaxForms)] {
+			forms = append(forms, fmt.Sprintf("%s %d", f.Word, f.Count))
+		}
+		line := "  " + strings.Join(forms, " · ")
+		if rest := len(c.Forms) - concordanceMaxForms; rest > 0 {
+			line += fmt.Sprintf(" 외 %d개", rest)
+		}
+		b.WriteString(mutedStyle.Render(line))
+	}
+	b.WriteString("\n\n")
+
+	bookStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Secondary)
+	refStyle := lipgloss.NewStyle().Foreground(m.theme.Primary)
+	matchStyle := lipgloss.NewStyle().Background(m.theme.HighlightBg).Bold(true)
+
+	// One line per verse plus a heading per book; keep the selection in
+	// view by starting a few lines above it.
+	var lines []string
+	selectedLine := 0
+	i := 0
+	for _, book := range c.Books {
+		lines = append(lines, bookStyle.Render(fmt.Sprintf("%s (%d회)", book.BookName, book.Count)))
+		for _, r := range book.Results {
+			cursor := "  "
+			if i == m.selected {
+				cursor = "▸ "
+				selectedLine = len(lines)
+			}
+			text := r.Snippet
+			if text == "" {
+				text = r.Verse.Text
+			}
+			if maxRunes := m.width - 14; maxRunes > 0 && len([]rune(text)) > maxRunes {
+				text = string([]rune(text)[:maxRunes]) + "..."
+			}
+			ref := fmt.Sprintf("%-7s", fmt.Sprintf("%d:%d", r.Verse.Chapter, r.Verse.VerseNum))
+			lines = append(lines, cursor+refStyle.Render(ref)+" "+highlightTerms(text, r.Highlights, matchStyle))
+			i++
+		}
+	}
+
+	visible := max(m.height-5, 1)
+	first := 0
+	if selectedLine >= visible {
+		first = selectedLine - visible + 1
+	}
+	b.WriteString(strings.Join(lines[first:min(first+visible, len(lines))], "\n"))
+	return b.String()
+}
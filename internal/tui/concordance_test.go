@@ -0,0 +1,44 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yangsijun/bible-tui/internal/tui/styles"
+)
+
+func TestConcordanceModel(t *testing.T) {
+	database := setupVersionsDB(t)
+	m := NewConcordance(database, "GAE", "개정", styles.DefaultDarkTheme(), 80, 24)
+	if !strings.Contains(m.View(), "찾는 중") {
+		t.Error("expected loading view before results arrive")
+	}
+
+	m, _ = m.Update(LoadConcordance(database, "GAE", "개정")())
+	v := m.View()
+	for _, want := range []string{"3회 · 3절 · 1권", "창세기 (3회)", "1:3"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("expected %q in view, got:\n%s", want, v)
+		}
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected Enter to jump to the verse")
+	}
+	if msg, ok := cmd().(GoToVerseMsg); !ok || msg.BookCode != "gen" || msg.Chapter != 1 || msg.Verse != 2 {
+		t.Errorf("expected jump to gen 1:2, got %#v", cmd())
+	}
+}
+
+func TestConcordanceModel_IgnoresOtherWord(t *testing.T) {
+	database := setupVersionsDB(t)
+	m := NewConcordance(database, "GAE", "개정", styles.DefaultDarkTheme(), 80, 24)
+	m, _ = m.Update(LoadConcordance(database, "GAE", "한글")())
+	if !m.loading {
+		t.Error("expected results for another word to be ignored")
+	}
+}
@@ -94,8 +94,15 @@ func renderHelpContent(theme *styles.Theme) string {
 		{"j, k", "구절 위/아래 이동"},
 		{"g", "맨 위"},
 		{"G", "맨 아래"},
-		{"B", "선택 구절 책갈피"},
-		{"H", "선택 구절 하이라이트"},
+		{"f", "각주 보기/숨기기"},
+		{"v", "역본 전환"},
+		{"c", "대역 보기 (병렬)"},
+		{"w, W", "구절 안에서 단어 고르기"},
+		{"C", "고른 단어의 용어 색인"},
+		{"V", "범위 선택 시작/취소 (j, k로 넓히기)"},
+		{"B", "선택 구절 책갈피 (메모 입력, Ctrl+S:저장)"},
+		{"H", "하이라이트 색 바꾸기 (yellow→green→blue→pink→purple→삭제)"},
+		{"u", "읽기 계획 자동 체크 취소"},
 		{"Esc", "장 선택으로"},
 	}
 	for _, kv := range keys4 {
@@ -110,6 +117,15 @@ func renderHelpContent(theme *styles.Theme) string {
 		{"j, k", "결과 탐색"},
 		{"Enter", "결과 선택"},
 		{"/", "검색창으로"},
+		{"o", "정렬 (관련도/성경순/책별)"},
+		{"Ctrl+R", "정규식 검색 켜기/끄기"},
+		{"↑, ↓", "이전 검색어 불러오기 (검색창)"},
+		{"Ctrl+S", "검색 저장 (@이름으로 다시 실행)"},
+		{"A OR B", "둘 중 하나"},
+		{"-단어", "제외 (NOT)"},
+		{`"A B"`, "이어지는 구절"},
+		{"A NEAR/5 B", "5단어 이내"},
+		{"book:롬", "범위 (range:마-요, chapter:롬8, testament:new)"},
 	}
 	for _, kv := range keys5 {
 		b.WriteString("  " + keyStyle.Render(kv[0]) + descStyle.Render(kv[1]) + "\n")
@@ -121,6 +137,8 @@ func renderHelpContent(theme *styles.Theme) string {
 	keys6 := [][2]string{
 		{"Tab", "탭 전환"},
 		{"j, k", "위/아래 이동"},
+		{"t, T", "책갈피 태그 필터 (다음/이전)"},
+		{"e", "책갈피 메모 수정 (Ctrl+S:저장, Esc:취소)"},
 		{"d", "삭제"},
 		{"Enter", "해당 구절로 이동"},
 	}
@@ -128,5 +146,22 @@ func renderHelpContent(theme *styles.Theme) string {
 		b.WriteString("  " + keyStyle.Render(kv[0]) + descStyle.Render(kv[1]) + "\n")
 	}
 
+	b.WriteString("\n")
+	b.WriteString(sectionStyle.Render("읽기 계획"))
+	b.WriteString("\n\n")
+	keys7 := [][2]string{
+		{"n", "새 계획"},
+		{"w / v", "주말 쉬기 / 분량 기준 (새 계획)"},
+		{"Enter", "오늘 읽기 (계획 목록) / 해당 장으로 이동"},
+		{"Space", "완료 체크"},
+		{"o", "밀린 읽기 함께 보기"},
+		{"s", "밀린 날만큼 일정 미루기"},
+		{"r", "남은 분량을 남은 날에 다시 나누기"},
+		{"d", "계획 삭제"},
+	}
+	for _, kv := range keys7 {
+		b.WriteString("  " + keyStyle.Render(kv[0]) + descStyle.Render(kv[1]) + "\n")
+	}
+
 	return b.String()
 }
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/yangsijun/bible-tui/internal/tui/styles"
+)
+
+type noteEditorState int
+
+const (
+	noteEditing noteEditorState = iota
+	noteSaved
+	noteCanceled
+)
+
+// noteEditorHeight is the number of text lines of the note textarea.
+const noteEditorHeight = 6
+
+// NoteEditor edits a multiline bookmark note below the verse it belongs
+// to. Ctrl+S saves and Esc cancels; the owner checks Saved and Canceled
+// after each update.
+type NoteEditor struct {
+	textarea textarea.Model
+	title    string
+	quote    string
+	state    noteEditorState
+	theme    *styles.Theme
+	width    int
+}
+
+func NewNoteEditor(title, quote, note string, theme *styles.Theme, width int) NoteEditor {
+	ta := textarea.New()
+	ta.Placeholder = "메모 (비워 두어도 됩니다)"
+	ta.ShowLineNumbers = false
+	ta.SetWidth(max(width-4, 10))
+	ta.SetHeight(noteEditorHeight)
+	ta.SetValue(note)
+	ta.Focus()
+	return NoteEditor{textarea: ta, title: title, quote: quote, theme: theme, width: width}
+}
+
+func (e NoteEditor) Update(msg tea.Msg) (NoteEditor, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+s":
+			e.state = noteSaved
+			e.textarea.Blur()
+			return e, nil
+		case "esc":
+			e.state = noteCanceled
+			e.textarea.Blur()
+			return e, nil
+		}
+	}
+	var cmd tea.Cmd
+	e.textarea, cmd = e.textarea.Update(msg)
+	return e, cmd
+}
+
+// Saved reports whether the note was saved with Ctrl+S.
+func (e NoteEditor) Saved() bool { return e.state == noteSaved }
+
+// Canceled reports whether editing was abandoned with Esc.
+func (e NoteEditor) Canceled() bool { return e.state == noteCanceled }
+
+// Value returns the note with surrounding blank lines trimmed.
+func (e NoteEditor) Value() string {
+	return strings.TrimSpace(e.textarea.Value())
+}
+
+func (e NoteEditor) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(e.theme.Primary)
+	quoteStyle := lipgloss.NewStyle().Foreground(e.theme.Muted).Italic(true)
+	hintStyle := lipgloss.NewStyle().Foreground(e.theme.Muted)
+
+	var b strings.Builder
+	b.WriteString("  " + titleStyle.Render(e.title) + "\n")
+	if e.quote != "" {
+		b.WriteString("  " + quoteStyle.Render(truncateRunes(e.quote, e.width-8)) + "\n")
+	}
+	b.WriteString("\n")
+	for _, line := range strings.Split(e.textarea.View(), "\n") {
+		b.WriteString("  " + line + "\n")
+	}
+	b.WriteString("\n  " + hintStyle.Render("Ctrl+S:저장  Esc:취소  Enter:줄바꿈"))
+	return b.String()
+}
@@ -2,11 +2,18 @@ package tui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/yangsijun/bible-tui/internal/bible"
+	"github.com/yangsijun/bible-tui/internal/config"
 	"github.com/yangsijun/bible-tui/internal/db"
 	"github.com/yangsijun/bible-tui/internal/tui/styles"
 )
@@ -17,7 +24,9 @@ type PlansLoadedMsg struct {
 }
 
 type PlanEntriesLoadedMsg struct {
+	PlanID   int64
 	Entries  []db.PlanEntry
+	Status   db.PlanStatus
 	Progress PlanProgress
 	Err      error
 }
@@ -40,6 +49,26 @@ type PlanDeletedMsg struct {
 	Err error
 }
 
+// PlanFilesLoadedMsg lists the plan files of the plan folder.
+type PlanFilesLoadedMsg struct {
+	Files []PlanFileInfo
+	Err   error
+}
+
+// PlanFileInfo is a plan file offered on the create screen. Err is set
+// for files that do not parse; they are listed so the mistake is visible.
+type PlanFileInfo struct {
+	Path string
+	Plan *db.PlanFile
+	Err  error
+}
+
+// PlanRescheduledMsg reports a shifted or redistributed schedule.
+type PlanRescheduledMsg struct {
+	Message string
+	Err     error
+}
+
 type PlanViewState int
 
 const (
@@ -56,23 +85,68 @@ type PlanModel struct {
 
 	viewState PlanViewState
 	plans     []db.ReadingPlan
+	planID    int64
 	entries   []db.PlanEntry
+	status    db.PlanStatus
 	progress  PlanProgress
 	selected  int
 	loaded    bool
 
-	createIdx int
-	versionID int64
+	// showOverdue lists the overdue readings above today's.
+	showOverdue bool
+	statusMsg   string
+	now         func() time.Time
+
+	createIdx   int
+	versionCode string
+	planDir     string
+	planFiles   []PlanFileInfo
+	presets     []bible.PlanPreset
+
+	// Options of generated plans: weekdays only, and balancing the days
+	// by chapters instead of verses.
+	weekdaysOnly    bool
+	balanceChapters bool
+
+	// customForm asks for the books and pace of a plan made from scratch.
+	customForm bool
+	booksInput textinput.Model
+	paceInput  textinput.Model
+}
+
+// builtinPlans are the plans offered on the create screen before the
+// generated ones and the plan files.
+var builtinPlans = []struct {
+	planType string
+	name     string
+	desc     string
+}{
+	{"sequential", "통독", "창세기→요한계시록, 1일 3장"},
+	{"mcheyne", "매쿠인", "1일 4구간, 1년 완독"},
 }
 
-func NewPlans(database *db.DB, theme *styles.Theme, width, height int) PlanModel {
+func NewPlans(database *db.DB, versionCode string, theme *styles.Theme, width, height int) PlanModel {
+	planDir, _ := config.PlanDir()
+	books := textinput.New()
+	books.Prompt = "책: "
+	books.Placeholder = "마-요"
+	books.CharLimit = 100
+	pace := textinput.New()
+	pace.Prompt = "분량: "
+	pace.Placeholder = "40일"
+	pace.CharLimit = 10
 	return PlanModel{
-		database:  database,
-		theme:     theme,
-		width:     width,
-		height:    height,
-		viewState: PlanViewList,
-		versionID: 1,
+		database:    database,
+		theme:       theme,
+		width:       width,
+		height:      height,
+		viewState:   PlanViewList,
+		versionCode: versionCode,
+		planDir:     planDir,
+		presets:     bible.PlanPresets(),
+		booksInput:  books,
+		paceInput:   pace,
+		now:         time.Now,
 	}
 }
 
@@ -86,12 +160,18 @@ func LoadPlans(database *db.DB) tea.Cmd {
 	}
 }
 
-func LoadPlanEntries(database *db.DB, planID int64) tea.Cmd {
+// LoadPlanEntries loads the readings of a plan due on date today, with
+// how far behind schedule it is.
+func LoadPlanEntries(database *db.DB, planID int64, today time.Time) tea.Cmd {
 	return func() tea.Msg {
 		if database == nil {
 			return PlanEntriesLoadedMsg{Err: fmt.Errorf("no database")}
 		}
-		entries, err := database.GetTodayEntries(planID)
+		entries, err := database.GetEntriesOn(planID, today)
+		if err != nil {
+			return PlanEntriesLoadedMsg{Err: err}
+		}
+		status, err := database.GetPlanStatus(planID, today)
 		if err != nil {
 			return PlanEntriesLoadedMsg{Err: err}
 		}
@@ -100,24 +180,29 @@ func LoadPlanEntries(database *db.DB, planID int64) tea.Cmd {
 			return PlanEntriesLoadedMsg{Err: err}
 		}
 		return PlanEntriesLoadedMsg{
+			PlanID:   planID,
 			Entries:  entries,
+			Status:   *status,
 			Progress: PlanProgress{Completed: completed, Total: total},
 		}
 	}
 }
 
-func createPlan(database *db.DB, planType string, versionID int64) tea.Cmd {
+func createPlan(database *db.DB, planType, versionCode string) tea.Cmd {
 	return func() tea.Msg {
 		if database == nil {
 			return PlanCreatedMsg{Err: fmt.Errorf("no database")}
 		}
+		version, err := database.GetVersionByCode(versionCode)
+		if err != nil {
+			return PlanCreatedMsg{Err: err}
+		}
 		var id int64
-		var err error
 		switch planType {
 		case "sequential":
-			id, err = database.CreateSequentialPlan(versionID, "통독 계획")
+			id, err = database.CreateSequentialPlan(version.ID, "통독 계획")
 		case "mcheyne":
-			id, err = database.CreateMcCheynePlan(versionID, "매쿠인 계획")
+			id, err = database.CreateMcCheynePlan(version.ID, "매쿠인 계획")
 		default:
 			err = fmt.Errorf("unknown plan type: %s", planType)
 		}
@@ -125,6 +210,66 @@ func createPlan(database *db.DB, planType string, versionID int64) tea.Cmd {
 	}
 }
 
+// generatePlan creates a plan from a generator spec. With byVerses the
+// days are balanced by the verse counts of the version.
+func generatePlan(database *db.DB, versionCode, name, planType string, spec bible.PlanSpec, byVerses bool) tea.Cmd {
+	return func() tea.Msg {
+		if database == nil {
+			return PlanCreatedMsg{Err: fmt.Errorf("no database")}
+		}
+		version, err := database.GetVersionByCode(versionCode)
+		if err != nil {
+			return PlanCreatedMsg{Err: err}
+		}
+		if byVerses {
+			if spec.VerseCounts, err = database.VerseCounts(version.ID); err != nil {
+				return PlanCreatedMsg{Err: err}
+			}
+		}
+		id, err := database.CreateGeneratedPlan(version.ID, name, planType, spec)
+		return PlanCreatedMsg{PlanID: id, Err: err}
+	}
+}
+
+// LoadPlanFiles reads the *.json plan files of dir, by file name. A
+// missing dir has no plan files.
+func LoadPlanFiles(dir string) tea.Cmd {
+	return func() tea.Msg {
+		if dir == "" {
+			return PlanFilesLoadedMsg{}
+		}
+		paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			return PlanFilesLoadedMsg{Err: err}
+		}
+		var files []PlanFileInfo
+		for _, path := range paths {
+			info := PlanFileInfo{Path: path}
+			data, err := os.ReadFile(path)
+			if err == nil {
+				info.Plan, err = db.ParsePlanFile(data)
+			}
+			info.Err = err
+			files = append(files, info)
+		}
+		return PlanFilesLoadedMsg{Files: files}
+	}
+}
+
+func importPlanFile(database *db.DB, plan *db.PlanFile, versionCode string) tea.Cmd {
+	return func() tea.Msg {
+		if database == nil {
+			return PlanCreatedMsg{Err: fmt.Errorf("no database")}
+		}
+		version, err := database.GetVersionByCode(versionCode)
+		if err != nil {
+			return PlanCreatedMsg{Err: err}
+		}
+		id, err := database.ImportPlan(version.ID, plan)
+		return PlanCreatedMsg{PlanID: id, Err: err}
+	}
+}
+
 func deletePlan(database *db.DB, planID int64) tea.Cmd {
 	return func() tea.Msg {
 		if database == nil {
@@ -135,6 +280,30 @@ func deletePlan(database *db.DB, planID int64) tea.Cmd {
 	}
 }
 
+func shiftPlan(database *db.DB, planID int64, days int) tea.Cmd {
+	return func() tea.Msg {
+		if database == nil {
+			return PlanRescheduledMsg{Err: fmt.Errorf("no database")}
+		}
+		if err := database.ShiftPlan(planID, days); err != nil {
+			return PlanRescheduledMsg{Err: err}
+		}
+		return PlanRescheduledMsg{Message: fmt.Sprintf("일정을 %d일 미뤘습니다", days)}
+	}
+}
+
+func redistributePlan(database *db.DB, planID int64, today time.Time) tea.Cmd {
+	return func() tea.Msg {
+		if database == nil {
+			return PlanRescheduledMsg{Err: fmt.Errorf("no database")}
+		}
+		if err := database.RedistributePlan(planID, today); err != nil {
+			return PlanRescheduledMsg{Err: err}
+		}
+		return PlanRescheduledMsg{Message: "남은 분량을 남은 날에 다시 나눴습니다"}
+	}
+}
+
 func markEntryCompleted(database *db.DB, entryID int64) tea.Cmd {
 	return func() tea.Msg {
 		if database == nil {
@@ -159,7 +328,11 @@ func (m PlanModel) Update(msg tea.Msg) (PlanModel, tea.Cmd) {
 	case PlanEntriesLoadedMsg:
 		if msg.Err == nil {
 			m.entries = msg.Entries
+			m.status = msg.Status
 			m.progress = msg.Progress
+			if len(m.status.Overdue) == 0 {
+				m.showOverdue = false
+			}
 		}
 		m.selected = 0
 		return m, nil
@@ -168,14 +341,30 @@ func (m PlanModel) Update(msg tea.Msg) (PlanModel, tea.Cmd) {
 		if msg.Err == nil {
 			return m, LoadPlans(m.database)
 		}
+		m.statusMsg = fmt.Sprintf("오류: %v", msg.Err)
+		return m, nil
+
+	case PlanFilesLoadedMsg:
+		m.planFiles = msg.Files
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("오류: %v", msg.Err)
+		}
 		return m, nil
 
 	case EntryCompletedMsg:
-		if msg.Err == nil && m.viewState == PlanViewToday && len(m.plans) > 0 {
-			planID := m.activePlanID()
-			if planID > 0 {
-				return m, LoadPlanEntries(m.database, planID)
-			}
+		if msg.Err == nil && m.viewState == PlanViewToday && m.planID > 0 {
+			return m, LoadPlanEntries(m.database, m.planID, m.now())
+		}
+		return m, nil
+
+	case PlanRescheduledMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("오류: %v", msg.Err)
+			return m, nil
+		}
+		m.statusMsg = msg.Message
+		if m.viewState == PlanViewToday && m.planID > 0 {
+			return m, LoadPlanEntries(m.database, m.planID, m.now())
 		}
 		return m, nil
 
@@ -186,12 +375,16 @@ func (m PlanModel) Update(msg tea.Msg) (PlanModel, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		m.statusMsg = ""
 		switch m.viewState {
 		case PlanViewList:
 			return m.updateList(msg)
 		case PlanViewToday:
 			return m.updateToday(msg)
 		case PlanViewCreate:
+			if m.customForm {
+				return m.updateCustomForm(msg)
+			}
 			return m.updateCreate(msg)
 		}
 	}
@@ -211,13 +404,16 @@ func (m PlanModel) updateList(msg tea.KeyMsg) (PlanModel, tea.Cmd) {
 	case "enter":
 		if m.selected < len(m.plans) && len(m.plans) > 0 {
 			m.viewState = PlanViewToday
-			planID := m.plans[m.selected].ID
+			m.planID = m.plans[m.selected].ID
 			m.selected = 0
-			return m, LoadPlanEntries(m.database, planID)
+			m.entries, m.status = nil, db.PlanStatus{}
+			m.showOverdue = false
+			return m, LoadPlanEntries(m.database, m.planID, m.now())
 		}
 	case "n":
 		m.viewState = PlanViewCreate
 		m.createIdx = 0
+		return m, LoadPlanFiles(m.planDir)
 	case "d":
 		if m.selected < len(m.plans) && len(m.plans) > 0 {
 			planID := m.plans[m.selected].ID
@@ -230,7 +426,7 @@ func (m PlanModel) updateList(msg tea.KeyMsg) (PlanModel, tea.Cmd) {
 func (m PlanModel) updateToday(msg tea.KeyMsg) (PlanModel, tea.Cmd) {
 	switch msg.String() {
 	case "down", "j":
-		if m.selected < len(m.entries)-1 {
+		if m.selected < len(m.visibleEntries())-1 {
 			m.selected++
 		}
 	case "up", "k":
@@ -241,6 +437,22 @@ func (m PlanModel) updateToday(msg tea.KeyMsg) (PlanModel, tea.Cmd) {
 		return m, m.toggleEntry()
 	case "enter":
 		return m, m.goToEntry()
+	case "o":
+		if len(m.status.Overdue) == 0 {
+			m.statusMsg = "밀린 읽기가 없습니다"
+			m.showOverdue = false
+		} else {
+			m.showOverdue = !m.showOverdue
+		}
+		m.selected = 0
+	case "s":
+		if m.status.BehindDays == 0 {
+			m.statusMsg = "밀린 날이 없습니다"
+			return m, nil
+		}
+		return m, shiftPlan(m.database, m.planID, m.status.BehindDays)
+	case "r":
+		return m, redistributePlan(m.database, m.planID, m.now())
 	case "esc":
 		m.viewState = PlanViewList
 		m.selected = 0
@@ -248,23 +460,49 @@ func (m PlanModel) updateToday(msg tea.KeyMsg) (PlanModel, tea.Cmd) {
 	return m, nil
 }
 
+// customIdx returns the index of the "직접 만들기" option on the create
+// screen, which comes after the built-in plans and the presets and before
+// the plan files.
+func (m PlanModel) customIdx() int {
+	return len(builtinPlans) + len(m.presets)
+}
+
 func (m PlanModel) updateCreate(msg tea.KeyMsg) (PlanModel, tea.Cmd) {
 	switch msg.String() {
 	case "down", "j":
-		if m.createIdx < 1 {
+		if m.createIdx < m.customIdx()+len(m.planFiles) {
 			m.createIdx++
 		}
 	case "up", "k":
 		if m.createIdx > 0 {
 			m.createIdx--
 		}
+	case "w":
+		m.weekdaysOnly = !m.weekdaysOnly
+	case "v":
+		m.balanceChapters = !m.balanceChapters
 	case "enter":
-		m.viewState = PlanViewList
-		planType := "sequential"
-		if m.createIdx == 1 {
-			planType = "mcheyne"
+		if m.createIdx < len(builtinPlans) {
+			m.viewState = PlanViewList
+			return m, createPlan(m.database, builtinPlans[m.createIdx].planType, m.versionCode)
+		}
+		if m.createIdx < m.customIdx() {
+			preset := m.presets[m.createIdx-len(builtinPlans)]
+			m.viewState = PlanViewList
+			return m, generatePlan(m.database, m.versionCode, preset.Name, preset.Key, m.generatorSpec(preset.Spec), !m.balanceChapters)
+		}
+		if m.createIdx == m.customIdx() {
+			m.customForm = true
+			m.paceInput.Blur()
+			return m, m.booksInput.Focus()
+		}
+		file := m.planFiles[m.createIdx-m.customIdx()-1]
+		if file.Err != nil {
+			m.statusMsg = fmt.Sprintf("%s: %v", filepath.Base(file.Path), file.Err)
+			return m, nil
 		}
-		return m, createPlan(m.database, planType, m.versionID)
+		m.viewState = PlanViewList
+		return m, importPlanFile(m.database, file.Plan, m.versionCode)
 	case "esc":
 		m.viewState = PlanViewList
 		m.selected = 0
@@ -272,16 +510,99 @@ func (m PlanModel) updateCreate(msg tea.KeyMsg) (PlanModel, tea.Cmd) {
 	return m, nil
 }
 
-func (m PlanModel) activePlanID() int64 {
-	if len(m.entries) > 0 {
-		return m.entries[0].PlanID
+// updateCustomForm handles keys while asking for the books and pace of a
+// plan made from scratch.
+func (m PlanModel) updateCustomForm(msg tea.KeyMsg) (PlanModel, tea.Cmd) {
+	switch msg.String() {
+	case "tab", "shift+tab", "up", "down":
+		if m.booksInput.Focused() {
+			m.booksInput.Blur()
+			return m, m.paceInput.Focus()
+		}
+		m.paceInput.Blur()
+		return m, m.booksInput.Focus()
+	case "enter":
+		books, err := bible.ParseBookList(m.booksInput.Value())
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("책: %v", err)
+			return m, nil
+		}
+		var spec bible.PlanSpec
+		spec.Tracks = [][]string{books}
+		if spec.Days, spec.ChaptersPerDay, err = parsePace(m.paceInput.Value()); err != nil {
+			m.statusMsg = err.Error()
+			return m, nil
+		}
+		name := bible.BookListLabel(books) + " " + spec.PaceLabel()
+		m.customForm = false
+		m.booksInput.Blur()
+		m.paceInput.Blur()
+		m.viewState = PlanViewList
+		return m, generatePlan(m.database, m.versionCode, name, "custom", m.generatorSpec(spec), !m.balanceChapters)
+	case "esc":
+		m.customForm = false
+		m.booksInput.Blur()
+		m.paceInput.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	if m.booksInput.Focused() {
+		m.booksInput, cmd = m.booksInput.Update(msg)
+	} else {
+		m.paceInput, cmd = m.paceInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// generatorSpec applies the create screen's options to spec, starting
+// the plan today.
+func (m PlanModel) generatorSpec(spec bible.PlanSpec) bible.PlanSpec {
+	spec.WeekdaysOnly = m.weekdaysOnly
+	spec.Start = m.now()
+	return spec
+}
+
+// parsePace reads the pace of a custom plan: "40" or "40일" for the
+// number of days, "3장" for chapters per day.
+func parsePace(value string) (days, perDay int, err error) {
+	value = strings.TrimSpace(value)
+	number, isChapters := strings.CutSuffix(value, "장")
+	number = strings.TrimSpace(strings.TrimSuffix(number, "일"))
+	n, err := strconv.Atoi(number)
+	if err != nil || n <= 0 {
+		return 0, 0, fmt.Errorf("분량은 40일 또는 하루 3장처럼 적어 주세요: %q", value)
 	}
-	return 0
+	if isChapters {
+		return 0, n, nil
+	}
+	return n, 0, nil
+}
+
+// Editing reports whether the custom plan form is open.
+func (m PlanModel) Editing() bool {
+	return m.viewState == PlanViewCreate && m.customForm
+}
+
+// InSubView reports whether the today or create view is open, so the app
+// passes it every key: their Esc goes back to the plan list, and keys such
+// as s act on the plan instead of opening another screen.
+func (m PlanModel) InSubView() bool {
+	return m.viewState == PlanViewToday || m.viewState == PlanViewCreate
+}
+
+// visibleEntries returns the readings listed in the today view: today's,
+// after the overdue ones when they are shown.
+func (m PlanModel) visibleEntries() []db.PlanEntry {
+	if !m.showOverdue {
+		return m.entries
+	}
+	return append(append([]db.PlanEntry{}, m.status.Overdue...), m.entries...)
 }
 
 func (m PlanModel) toggleEntry() tea.Cmd {
-	if m.selected < len(m.entries) {
-		e := m.entries[m.selected]
+	entries := m.visibleEntries()
+	if m.selected < len(entries) {
+		e := entries[m.selected]
 		if !e.Completed {
 			return markEntryCompleted(m.database, e.ID)
 		}
@@ -290,8 +611,9 @@ func (m PlanModel) toggleEntry() tea.Cmd {
 }
 
 func (m PlanModel) goToEntry() tea.Cmd {
-	if m.selected < len(m.entries) {
-		e := m.entries[m.selected]
+	entries := m.visibleEntries()
+	if m.selected < len(entries) {
+		e := entries[m.selected]
 		return func() tea.Msg {
 			return GoToVerseMsg{BookCode: e.BookCode, Chapter: e.ChapterStart, Verse: 1}
 		}
@@ -342,6 +664,9 @@ func (m PlanModel) viewList() string {
 	b.WriteString("\n")
 	helpStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
 	b.WriteString("  " + helpStyle.Render("n:새 계획  Enter:오늘 읽기  d:삭제"))
+	if m.statusMsg != "" {
+		b.WriteString("\n  " + lipgloss.NewStyle().Foreground(m.theme.Secondary).Bold(true).Render(m.statusMsg))
+	}
 
 	return b.String()
 }
@@ -352,14 +677,16 @@ func (m PlanModel) viewToday() string {
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Primary)
 
 	planName := "읽기 계획"
-	dayNumber := 0
-	if len(m.entries) > 0 {
-		for _, p := range m.plans {
-			if p.ID == m.entries[0].PlanID {
-				planName = p.Name
-				break
-			}
+	var plan *db.ReadingPlan
+	for i, p := range m.plans {
+		if p.ID == m.planID {
+			plan = &m.plans[i]
+			planName = p.Name
+			break
 		}
+	}
+	dayNumber := m.status.Day
+	if len(m.entries) > 0 {
 		dayNumber = m.entries[0].DayNumber
 	}
 
@@ -369,7 +696,15 @@ func (m PlanModel) viewToday() string {
 	}
 
 	header := fmt.Sprintf("%s — %d일차 (%d%%)", planName, dayNumber, pct)
-	b.WriteString("  " + titleStyle.Render(header) + "\n")
+	if dayNumber < 1 && plan != nil {
+		header = fmt.Sprintf("%s — %s 시작 (%d일 남음)", planName, plan.StartDate.Format("2006-01-02"), 1-dayNumber)
+	}
+	b.WriteString("  " + titleStyle.Render(header))
+	if m.status.BehindDays > 0 {
+		behindStyle := lipgloss.NewStyle().Foreground(m.theme.Secondary).Bold(true)
+		b.WriteString("  " + behindStyle.Render(fmt.Sprintf("%d일 밀림 · 밀린 읽기 %d개", m.status.BehindDays, len(m.status.Overdue))))
+	}
+	b.WriteString("\n")
 
 	barWidth := 20
 	if m.width > 30 {
@@ -382,11 +717,13 @@ func (m PlanModel) viewToday() string {
 	}
 	b.WriteString("\n")
 
-	if len(m.entries) == 0 {
+	entries := m.visibleEntries()
+	if len(entries) == 0 {
 		mutedStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
 		b.WriteString("  " + mutedStyle.Render("오늘의 읽기가 없습니다.") + "\n")
 	} else {
-		for i, e := range m.entries {
+		dayStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
+		for i, e := range entries {
 			cursor := "  "
 			if i == m.selected {
 				cursor = "▸ "
@@ -406,13 +743,20 @@ func (m PlanModel) viewToday() string {
 				refStyle = refStyle.Bold(true)
 			}
 
-			b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, checkStyle.Render(check), refStyle.Render(ref)))
+			day := ""
+			if m.showOverdue {
+				day = dayStyle.Render(fmt.Sprintf("%d일차 ", e.DayNumber))
+			}
+			b.WriteString(fmt.Sprintf("%s%s %s%s\n", cursor, checkStyle.Render(check), day, refStyle.Render(ref)))
 		}
 	}
 
 	b.WriteString("\n")
 	helpStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
-	b.WriteString("  " + helpStyle.Render("Space:완료  Enter:읽기  Esc:목록"))
+	b.WriteString("  " + helpStyle.Render("Space:완료  Enter:읽기  o:밀린 읽기  s:일정 미루기  r:다시 나누기  Esc:목록"))
+	if m.statusMsg != "" {
+		b.WriteString("\n  " + lipgloss.NewStyle().Foreground(m.theme.Secondary).Bold(true).Render(m.statusMsg))
+	}
 
 	return b.String()
 }
@@ -423,27 +767,62 @@ func (m PlanModel) viewCreate() string {
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Primary)
 	b.WriteString("  " + titleStyle.Render("새 읽기 계획 만들기") + "\n\n")
 
-	options := []struct {
-		name string
-		desc string
-	}{
-		{"통독", "창세기→요한계시록, 1일 3장"},
-		{"매쿠인", "1일 4구간, 1년 완독"},
-	}
-
-	for i, opt := range options {
+	descStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
+	option := func(i int, name, desc string) {
 		cursor := "  "
 		if i == m.createIdx {
 			cursor = "▸ "
 		}
 		nameStyle := lipgloss.NewStyle().Foreground(m.theme.Foreground).Bold(i == m.createIdx)
-		descStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
-		b.WriteString(fmt.Sprintf("%s%s (%s)\n", cursor, nameStyle.Render(opt.name), descStyle.Render(opt.desc)))
+		b.WriteString(fmt.Sprintf("%s%s (%s)\n", cursor, nameStyle.Render(name), descStyle.Render(desc)))
+	}
+	for i, opt := range builtinPlans {
+		option(i, opt.name, opt.desc)
+	}
+	for i, p := range m.presets {
+		option(len(builtinPlans)+i, p.Name, p.Desc)
+	}
+	option(m.customIdx(), "직접 만들기", "책과 분량을 골라 만들기")
+
+	if m.customForm {
+		inputStyle := lipgloss.NewStyle().Padding(0, 4)
+		b.WriteString("\n" + inputStyle.Render(m.booksInput.View()) + "\n")
+		b.WriteString(inputStyle.Render(m.paceInput.View()) + "\n")
+		b.WriteString(inputStyle.Render(descStyle.Render("책: mat-jhn, 마-요, 롬,갈,엡  분량: 40일 또는 3장(하루)")) + "\n")
+	}
+
+	if len(m.planFiles) > 0 {
+		sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Secondary)
+		b.WriteString("\n  " + sectionStyle.Render("가져온 계획") + "\n")
 	}
+	for i, file := range m.planFiles {
+		name := filepath.Base(file.Path)
+		if file.Err != nil {
+			option(m.customIdx()+1+i, name, truncateRunes("오류: "+file.Err.Error(), max(m.width-len(name)-10, 10)))
+			continue
+		}
+		option(m.customIdx()+1+i, file.Plan.Name, fmt.Sprintf("%d일, %s", len(file.Plan.Days), name))
+	}
+
+	weekdays, balance := "끔", "절 수"
+	if m.weekdaysOnly {
+		weekdays = "켬"
+	}
+	if m.balanceChapters {
+		balance = "장 수"
+	}
+	b.WriteString("\n  " + descStyle.Render(fmt.Sprintf("주말 쉬기: %s · 분량 기준: %s (프리셋과 직접 만들기)", weekdays, balance)) + "\n")
 
 	b.WriteString("\n")
 	helpStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
-	b.WriteString("  " + helpStyle.Render("Enter:생성  Esc:취소"))
+	if m.customForm {
+		b.WriteString("  " + helpStyle.Render("Tab:다음 칸  Enter:생성  Esc:돌아가기"))
+	} else {
+		b.WriteString("  " + helpStyle.Render("Enter:생성  w:주말 쉬기  v:분량 기준  Esc:취소"))
+	}
+	if m.statusMsg != "" {
+		b.WriteString("\n  " + lipgloss.NewStyle().Foreground(m.theme.Secondary).Bold(true).Render(m.statusMsg))
+	}
 
 	return b.String()
 }
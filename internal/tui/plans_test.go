@@ -2,8 +2,11 @@ package tui
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -12,7 +15,7 @@ import (
 )
 
 func TestPlanModel_Init(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	if m.viewState != PlanViewList {
 		t.Errorf("expected PlanViewList, got %d", m.viewState)
 	}
@@ -25,7 +28,7 @@ func TestPlanModel_Init(t *testing.T) {
 }
 
 func TestPlanModel_PlansLoaded(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m, _ = m.Update(PlansLoadedMsg{
 		Plans: []db.ReadingPlan{
 			{ID: 1, Name: "통독 계획", PlanType: "sequential", TotalDays: 397},
@@ -44,7 +47,7 @@ func TestPlanModel_PlansLoaded(t *testing.T) {
 }
 
 func TestPlanModel_PlansLoadedError(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m, _ = m.Update(PlansLoadedMsg{Err: fmt.Errorf("db error")})
 	if !m.loaded {
 		t.Error("expected loaded even on error")
@@ -55,7 +58,7 @@ func TestPlanModel_PlansLoadedError(t *testing.T) {
 }
 
 func TestPlanModel_Navigation(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m, _ = m.Update(PlansLoadedMsg{
 		Plans: []db.ReadingPlan{
 			{ID: 1, Name: "plan1", TotalDays: 10},
@@ -96,7 +99,7 @@ func TestPlanModel_Navigation(t *testing.T) {
 }
 
 func TestPlanModel_SwitchToCreate(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.loaded = true
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
 	if m.viewState != PlanViewCreate {
@@ -108,7 +111,7 @@ func TestPlanModel_SwitchToCreate(t *testing.T) {
 }
 
 func TestPlanModel_CreateNavigation(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.viewState = PlanViewCreate
 	m.createIdx = 0
 
@@ -117,9 +120,15 @@ func TestPlanModel_CreateNavigation(t *testing.T) {
 		t.Errorf("after j: expected createIdx=1, got %d", m.createIdx)
 	}
 
-	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
-	if m.createIdx != 1 {
-		t.Errorf("after j at bottom: expected createIdx=1, got %d", m.createIdx)
+	// Two built-in plans, three presets and the custom form.
+	for range 5 {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	}
+	if m.createIdx != 5 {
+		t.Errorf("after j at bottom: expected createIdx=5, got %d", m.createIdx)
+	}
+	for range 4 {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
 	}
 
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
@@ -129,7 +138,7 @@ func TestPlanModel_CreateNavigation(t *testing.T) {
 }
 
 func TestPlanModel_BackFromCreate(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.viewState = PlanViewCreate
 
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
@@ -139,7 +148,7 @@ func TestPlanModel_BackFromCreate(t *testing.T) {
 }
 
 func TestPlanModel_ViewList(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m, _ = m.Update(PlansLoadedMsg{
 		Plans: []db.ReadingPlan{
 			{ID: 1, Name: "통독 계획", TotalDays: 397},
@@ -158,7 +167,7 @@ func TestPlanModel_ViewList(t *testing.T) {
 }
 
 func TestPlanModel_ViewListEmpty(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m, _ = m.Update(PlansLoadedMsg{Plans: nil})
 	v := m.View()
 	if !strings.Contains(v, "읽기 계획이 없습니다") {
@@ -167,7 +176,7 @@ func TestPlanModel_ViewListEmpty(t *testing.T) {
 }
 
 func TestPlanModel_ViewToday(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m, _ = m.Update(PlansLoadedMsg{
 		Plans: []db.ReadingPlan{
 			{ID: 1, Name: "통독 계획", TotalDays: 397},
@@ -196,7 +205,7 @@ func TestPlanModel_ViewToday(t *testing.T) {
 }
 
 func TestPlanModel_ViewCreate(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.viewState = PlanViewCreate
 	v := m.View()
 	if !strings.Contains(v, "새 읽기 계획 만들기") {
@@ -211,7 +220,7 @@ func TestPlanModel_ViewCreate(t *testing.T) {
 }
 
 func TestPlanModel_SpaceToggle(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.viewState = PlanViewToday
 	m.entries = []db.PlanEntry{
 		{ID: 10, PlanID: 1, DayNumber: 1, BookCode: "gen", ChapterStart: 1, ChapterEnd: 3, Completed: false},
@@ -226,7 +235,7 @@ func TestPlanModel_SpaceToggle(t *testing.T) {
 }
 
 func TestPlanModel_SpaceToggleCompleted(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.viewState = PlanViewToday
 	m.entries = []db.PlanEntry{
 		{ID: 10, PlanID: 1, DayNumber: 1, BookCode: "gen", ChapterStart: 1, ChapterEnd: 3, Completed: true},
@@ -240,7 +249,7 @@ func TestPlanModel_SpaceToggleCompleted(t *testing.T) {
 }
 
 func TestPlanModel_EnterGoToVerse(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.viewState = PlanViewToday
 	m.entries = []db.PlanEntry{
 		{ID: 10, PlanID: 1, DayNumber: 1, BookCode: "gen", ChapterStart: 5, ChapterEnd: 7},
@@ -265,7 +274,7 @@ func TestPlanModel_EnterGoToVerse(t *testing.T) {
 }
 
 func TestPlanModel_EscFromToday(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.viewState = PlanViewToday
 	m.entries = []db.PlanEntry{
 		{ID: 1, PlanID: 1, DayNumber: 1, BookCode: "gen", ChapterStart: 1, ChapterEnd: 3},
@@ -279,7 +288,7 @@ func TestPlanModel_EscFromToday(t *testing.T) {
 }
 
 func TestPlanModel_TodayNavigation(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.viewState = PlanViewToday
 	m.entries = []db.PlanEntry{
 		{ID: 1, PlanID: 1, DayNumber: 1, BookCode: "gen", ChapterStart: 1, ChapterEnd: 3},
@@ -345,7 +354,7 @@ func TestPlanModel_FormatEntryRef(t *testing.T) {
 }
 
 func TestPlanModel_EntriesLoaded(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.viewState = PlanViewToday
 	m, _ = m.Update(PlanEntriesLoadedMsg{
 		Entries: []db.PlanEntry{
@@ -365,9 +374,265 @@ func TestPlanModel_EntriesLoaded(t *testing.T) {
 }
 
 func TestPlanModel_ViewNotLoaded(t *testing.T) {
-	m := NewPlans(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewPlans(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	v := m.View()
 	if !strings.Contains(v, "로딩 중") {
 		t.Error("expected loading message")
 	}
 }
+
+// setupCatchUpPlan opens the today view of a five-day plan with two
+// chapters a day, started on 2025-03-01 and untouched since, on 2025-03-04.
+func setupCatchUpPlan(t *testing.T) PlanModel {
+	t.Helper()
+	database := setupVersionsDB(t)
+	version, err := database.GetVersionByCode("GAE")
+	if err != nil {
+		t.Fatalf("GetVersionByCode: %v", err)
+	}
+	var entries []db.PlanEntry
+	for day := 1; day <= 5; day++ {
+		entries = append(entries, db.PlanEntry{DayNumber: day, BookCode: "gen", ChapterStart: day*2 - 1, ChapterEnd: day * 2})
+	}
+	planID, err := database.CreateCustomPlan(version.ID, "창세기 읽기", entries)
+	if err != nil {
+		t.Fatalf("CreateCustomPlan: %v", err)
+	}
+	if err := database.SetPlanStartDate(planID, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.Local)); err != nil {
+		t.Fatalf("SetPlanStartDate: %v", err)
+	}
+
+	m := NewPlans(database, "GAE", styles.DefaultDarkTheme(), 80, 24)
+	m.now = func() time.Time { return time.Date(2025, time.March, 4, 9, 30, 0, 0, time.Local) }
+	m, _ = m.Update(LoadPlans(database)())
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return runPlanCmd(t, m, cmd)
+}
+
+// runPlanCmd feeds the messages of cmd, and of the commands they return,
+// back into m.
+func runPlanCmd(t *testing.T, m PlanModel, cmd tea.Cmd) PlanModel {
+	t.Helper()
+	for cmd != nil {
+		m, cmd = m.Update(cmd())
+	}
+	return m
+}
+
+func TestPlanModel_Behind(t *testing.T) {
+	m := setupCatchUpPlan(t)
+
+	if m.status.Day != 4 || m.status.BehindDays != 3 || len(m.status.Overdue) != 3 {
+		t.Fatalf("expected day 4 and 3 days behind, got %+v", m.status)
+	}
+	if len(m.entries) != 1 || m.entries[0].ChapterStart != 7 {
+		t.Errorf("expected today's reading gen 7-8, got %+v", m.entries)
+	}
+	if v := m.View(); !strings.Contains(v, "4일차") || !strings.Contains(v, "3일 밀림") {
+		t.Errorf("expected day and lag in header, got:\n%s", v)
+	}
+
+	// o lists the overdue readings, oldest first, above today's.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if got := len(m.visibleEntries()); got != 4 {
+		t.Fatalf("expected 4 readings with the overdue ones, got %d", got)
+	}
+	if v := m.View(); !strings.Contains(v, "1일차 gen 1-2장") {
+		t.Errorf("expected overdue day label, got:\n%s", v)
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(GoToVerseMsg); !ok || msg.Chapter != 1 {
+		t.Errorf("expected Enter to open the oldest overdue chapter, got %+v", msg)
+	}
+
+	// Completing an overdue reading moves the lag up to the next one.
+	m = runPlanCmd(t, m, m.toggleEntry())
+	if m.status.BehindDays != 2 || !m.showOverdue {
+		t.Errorf("expected 2 days behind with the list still open, got %+v", m.status)
+	}
+}
+
+func TestPlanModel_ShiftSchedule(t *testing.T) {
+	m := setupCatchUpPlan(t)
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = runPlanCmd(t, m, cmd)
+	if m.status.Day != 1 || m.status.BehindDays != 0 {
+		t.Errorf("expected day 1 with no lag after shifting, got %+v", m.status)
+	}
+	if len(m.entries) != 1 || m.entries[0].ChapterStart != 1 {
+		t.Errorf("expected day 1 reading today, got %+v", m.entries)
+	}
+	if !strings.Contains(m.View(), "일정을 3일 미뤘습니다") {
+		t.Errorf("expected shift message, got:\n%s", m.View())
+	}
+
+	// Nothing to shift once caught up.
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	if cmd != nil || m.statusMsg != "밀린 날이 없습니다" {
+		t.Errorf("expected no shift when on schedule, got %q", m.statusMsg)
+	}
+}
+
+func TestPlanModel_Redistribute(t *testing.T) {
+	m := setupCatchUpPlan(t)
+
+	// The ten unread chapters go to the two days left.
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = runPlanCmd(t, m, cmd)
+	if m.status.Day != 4 || m.status.BehindDays != 0 {
+		t.Errorf("expected day 4 with no lag after redistributing, got %+v", m.status)
+	}
+	if len(m.entries) != 1 || m.entries[0].ChapterStart != 1 || m.entries[0].ChapterEnd != 5 {
+		t.Errorf("expected gen 1-5 today, got %+v", m.entries)
+	}
+	if m.statusMsg == "" {
+		t.Error("expected a status message")
+	}
+}
+
+func TestPlanModel_NotStarted(t *testing.T) {
+	m := setupCatchUpPlan(t)
+	m.now = func() time.Time { return time.Date(2025, time.February, 27, 0, 0, 0, 0, time.Local) }
+	m = runPlanCmd(t, m, LoadPlanEntries(m.database, m.planID, m.now()))
+
+	if len(m.entries) != 0 {
+		t.Errorf("expected no readings before the start, got %+v", m.entries)
+	}
+	if v := m.View(); !strings.Contains(v, "2025-03-01 시작 (2일 남음)") {
+		t.Errorf("expected start date in header, got:\n%s", v)
+	}
+}
+
+func TestPlanModel_ImportPicker(t *testing.T) {
+	database := setupVersionsDB(t)
+	dir := t.TempDir()
+	files := map[string]string{
+		"bad.json":  "{\n  \"name\": \"오타\",\n  \"days\": [\"창셰기 1\"]\n}",
+		"good.json": `{"name": "창세기 맛보기", "days": ["창 1", "창 2-3"]}`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewPlans(database, "GAE", styles.DefaultDarkTheme(), 80, 24)
+	m.planDir = dir
+	m, _ = m.Update(LoadPlans(database)())
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	m = runPlanCmd(t, m, cmd)
+
+	v := m.View()
+	for _, want := range []string{"가져온 계획", "창세기 맛보기", "2일, good.json", "bad.json", "line 3"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("expected %q in create view:\n%s", want, v)
+		}
+	}
+
+	// Files are listed by name after the built-in and generated plans; a
+	// broken one cannot be picked.
+	for range 6 {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || m.viewState != PlanViewCreate || !strings.Contains(m.statusMsg, "bad.json") {
+		t.Errorf("expected an error for the broken file, got %q", m.statusMsg)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = runPlanCmd(t, m, cmd)
+	if m.viewState != PlanViewList || len(m.plans) != 1 || m.plans[0].Name != "창세기 맛보기" {
+		t.Errorf("expected the imported plan listed, got %+v", m.plans)
+	}
+}
+
+func TestPlanModel_CreatePreset(t *testing.T) {
+	database := setupVersionsDB(t)
+	m := NewPlans(database, "GAE", styles.DefaultDarkTheme(), 80, 24)
+	m.now = func() time.Time { return time.Date(2026, time.January, 2, 9, 0, 0, 0, time.Local) }
+	m.viewState = PlanViewCreate
+
+	v := m.View()
+	for _, want := range []string{"연대순 1년 통독", "신약 90일", "시편·잠언 한 달", "직접 만들기", "주말 쉬기: 끔"} {
+		if !strings.Contains(v, want) {
+			t.Errorf("expected %q in create view:\n%s", want, v)
+		}
+	}
+
+	// 신약 90일, without weekends, from Friday 2026-01-02.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if !strings.Contains(m.View(), "주말 쉬기: 켬") {
+		t.Error("expected w to turn on weekdays only")
+	}
+	for range 3 {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = runPlanCmd(t, m, cmd)
+	if len(m.plans) != 1 {
+		t.Fatalf("expected the preset plan created, got %+v (%s)", m.plans, m.statusMsg)
+	}
+	plan := m.plans[0]
+	// 90 weekdays from Friday 2026-01-02 run to Thursday 2026-05-07.
+	if plan.Name != "신약 90일" || plan.PlanType != "nt90" || plan.TotalDays != 126 {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+}
+
+func TestPlanModel_CreateCustom(t *testing.T) {
+	database := setupVersionsDB(t)
+	m := NewPlans(database, "GAE", styles.DefaultDarkTheme(), 80, 24)
+	m.viewState = PlanViewCreate
+	m.createIdx = m.customIdx()
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.Editing() {
+		t.Fatal("expected Enter to open the custom form")
+	}
+	typeText := func(text string) {
+		for _, r := range text {
+			m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+	}
+	typeText("마-요")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	typeText("사십")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || !m.Editing() || !strings.Contains(m.statusMsg, "분량") {
+		t.Fatalf("expected an error for a bad pace, got %q", m.statusMsg)
+	}
+
+	m.paceInput.SetValue("40일")
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = runPlanCmd(t, m, cmd)
+	if m.Editing() || len(m.plans) != 1 {
+		t.Fatalf("expected the custom plan created, got %+v (%s)", m.plans, m.statusMsg)
+	}
+	if plan := m.plans[0]; plan.Name != "마태복음-요한복음 40일" || plan.PlanType != "custom" || plan.TotalDays != 40 {
+		t.Errorf("unexpected plan %+v", plan)
+	}
+}
+
+func TestParsePace(t *testing.T) {
+	tests := []struct {
+		input        string
+		days, perDay int
+	}{
+		{"40", 40, 0},
+		{"40일", 40, 0},
+		{" 3장 ", 0, 3},
+	}
+	for _, tt := range tests {
+		days, perDay, err := parsePace(tt.input)
+		if err != nil || days != tt.days || perDay != tt.perDay {
+			t.Errorf("parsePace(%q) = %d, %d, %v", tt.input, days, perDay, err)
+		}
+	}
+	for _, bad := range []string{"", "0", "사십", "-3장"} {
+		if _, _, err := parsePace(bad); err == nil {
+			t.Errorf("parsePace(%q): expected error", bad)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,14 +16,31 @@ import (
 )
 
 type VersesLoadedMsg struct {
-	Verses []db.Verse
-	Err    error
+	Verses      []db.Verse
+	Footnotes   []db.Footnote
+	Annotations map[int]db.VerseAnnotation // keyed by verse number
+	Err         error
+}
+
+// AnnotationsLoadedMsg carries the highlights and bookmarks of a chapter
+// after they changed.
+type AnnotationsLoadedMsg struct {
+	VersionCode string
+	BookCode    string
+	Chapter     int
+	Annotations map[int]db.VerseAnnotation
+	Err         error
 }
 
+// footnotePaneHeight is the number of lines reserved below the viewport
+// when the footnote pane is open (separator + footnote lines).
+const footnotePaneHeight = 4
+
 type ReadingModel struct {
 	viewport    viewport.Model
 	book        bible.BookInfo
 	chapter     int
+	versionCode string
 	verses      []db.Verse
 	loading     bool
 	theme       *styles.Theme
@@ -32,31 +51,109 @@ type ReadingModel struct {
 	statusMsg   string
 	statusTimer int
 	lineOffsets []int // line offset for each verse in rendered content
+
+	footnotes     map[int64][]db.Footnote // keyed by verse ID
+	showFootnotes bool
+
+	annotations map[int]db.VerseAnnotation // highlights and bookmarks by verse number
+
+	targetVerse int // verse number to place the cursor on after loading
+
+	compareCode   string // second version shown side by side; empty when off
+	compareVerses []db.Verse
+
+	wordIdx      int // word of the cursor verse picked with w/W
+	wordSelected bool
+
+	note        NoteEditor // note of the bookmark being added with B
+	noteSpan    [2]int     // verse indexes the bookmark being added covers
+	editingNote bool
+
+	selecting    bool // V selection: verses from selectAnchor to the cursor
+	selectAnchor int
+
+	logID         int64          // reading_log session of the chapter; 0 before it loads
+	openedAt      time.Time      // when the session started
+	atEnd         bool           // scrolled to the end of the chapter
+	away          bool           // another screen is shown, so no session runs
+	autoCompleted []db.PlanEntry // plan readings the chapter checked off, undone with u
+	now           func() time.Time
+}
+
+// CompareLoadedMsg carries the chapter text of the version shown in the
+// right-hand column of the parallel view.
+type CompareLoadedMsg struct {
+	Code   string
+	Verses []db.Verse
+	Err    error
 }
 
-func NewReading(book bible.BookInfo, chapter int, database *db.DB, theme *styles.Theme, width, height int) ReadingModel {
+func NewReading(book bible.BookInfo, chapter int, versionCode string, database *db.DB, theme *styles.Theme, width, height int) ReadingModel {
 	vp := viewport.New(width, height-4)
 	vp.SetContent("로딩 중...")
 	return ReadingModel{
-		viewport: vp,
-		book:     book,
-		chapter:  chapter,
-		loading:  true,
-		theme:    theme,
-		width:    width,
-		height:   height,
-		database: database,
+		viewport:    vp,
+		book:        book,
+		chapter:     chapter,
+		versionCode: versionCode,
+		loading:     true,
+		theme:       theme,
+		width:       width,
+		height:      height,
+		database:    database,
+		now:         time.Now,
+	}
+}
+
+func LoadVerses(database *db.DB, versionCode, bookCode string, chapter int) tea.Cmd {
+	return func() tea.Msg {
+		verses, err := database.GetVerses(versionCode, bookCode, chapter)
+		if err != nil {
+			return VersesLoadedMsg{Err: err}
+		}
+		footnotes, err := database.GetFootnotes(versionCode, bookCode, chapter)
+		if err != nil {
+			return VersesLoadedMsg{Err: err}
+		}
+		annotations, err := database.ChapterAnnotations(versionCode, bookCode, chapter)
+		return VersesLoadedMsg{Verses: verses, Footnotes: footnotes, Annotations: annotations, Err: err}
 	}
 }
 
-func LoadVerses(database *db.DB, bookCode string, chapter int) tea.Cmd {
+// LoadAnnotations reloads the highlights and bookmarks of a chapter.
+func LoadAnnotations(database *db.DB, versionCode, bookCode string, chapter int) tea.Cmd {
 	return func() tea.Msg {
-		verses, err := database.GetVerses("GAE", bookCode, chapter)
-		return VersesLoadedMsg{Verses: verses, Err: err}
+		annotations, err := database.ChapterAnnotations(versionCode, bookCode, chapter)
+		return AnnotationsLoadedMsg{
+			VersionCode: versionCode, BookCode: bookCode, Chapter: chapter,
+			Annotations: annotations, Err: err,
+		}
+	}
+}
+
+// ReloadAnnotations reloads the annotations of the chapter being read, or
+// returns nil when none is.
+func (m ReadingModel) ReloadAnnotations() tea.Cmd {
+	if m.database == nil || len(m.verses) == 0 {
+		return nil
+	}
+	return LoadAnnotations(m.database, m.versionCode, m.book.Code, m.chapter)
+}
+
+// LoadCompareVerses loads a chapter of the comparison version.
+func LoadCompareVerses(database *db.DB, versionCode, bookCode string, chapter int) tea.Cmd {
+	return func() tea.Msg {
+		verses, err := database.GetVerses(versionCode, bookCode, chapter)
+		return CompareLoadedMsg{Code: versionCode, Verses: verses, Err: err}
 	}
 }
 
 func (m ReadingModel) Update(msg tea.Msg) (ReadingModel, tea.Cmd) {
+	m, cmd := m.update(msg)
+	return m, tea.Batch(cmd, m.reachEnd())
+}
+
+func (m ReadingModel) update(msg tea.Msg) (ReadingModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case VersesLoadedMsg:
 		m.loading = false
@@ -65,19 +162,91 @@ func (m ReadingModel) Update(msg tea.Msg) (ReadingModel, tea.Cmd) {
 			return m, nil
 		}
 		m.verses = msg.Verses
+		m.annotations = msg.Annotations
+		m.footnotes = make(map[int64][]db.Footnote)
+		for _, fn := range msg.Footnotes {
+			m.footnotes[fn.VerseID] = append(m.footnotes[fn.VerseID], fn)
+		}
 		m.cursorIdx = 0
+		for i, v := range m.verses {
+			if v.VerseNum == m.targetVerse {
+				m.cursorIdx = i
+				break
+			}
+		}
+		m.targetVerse = 0
+		m.wordSelected = false
+		m.selecting = false
 		m.viewport.SetContent(m.renderVerses())
 		m.viewport.GotoTop()
+		m.ensureCursorVisible()
+		m.startSession()
 		return m, nil
+	case ReadingTimeMsg:
+		if msg.SessionID != 0 && msg.SessionID == m.logID {
+			m.logRead()
+		}
+		return m, nil
+	case AnnotationsLoadedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("오류: %v", msg.Err)
+			return m, nil
+		}
+		// Drop a reply for a chapter or version left in the meantime.
+		if msg.VersionCode != m.versionCode || msg.BookCode != m.book.Code || msg.Chapter != m.chapter {
+			return m, nil
+		}
+		m.annotations = msg.Annotations
+		m.viewport.SetContent(m.renderVerses())
+		return m, nil
+	case CompareLoadedMsg:
+		if msg.Err != nil {
+			m.statusMsg = fmt.Sprintf("오류: %v", msg.Err)
+			return m, nil
+		}
+		m.compareCode = msg.Code
+		m.compareVerses = msg.Verses
+		m.viewport.SetContent(m.renderVerses())
+		m.ensureCursorVisible()
+		return m, nil
+	case VersionChangedMsg:
+		if msg.Err != nil {
+			m.statusMsg = msg.Err.Error()
+			return m, nil
+		}
+		if msg.Code == m.versionCode {
+			return m, nil
+		}
+		prev := m.versionCode
+		m.versionCode = msg.Code
+		if m.database == nil {
+			return m, nil
+		}
+		if m.cursorIdx < len(m.verses) {
+			m.targetVerse = m.verses[m.cursorIdx].VerseNum
+		}
+		m.loading = true
+		m.statusMsg = "역본 변경: " + msg.Code
+		cmds := []tea.Cmd{LoadVerses(m.database, m.versionCode, m.book.Code, m.chapter)}
+		if m.compareCode == m.versionCode {
+			// The two columns would be identical; swap them instead.
+			m.compareCode = prev
+			cmds = append(cmds, LoadCompareVerses(m.database, prev, m.book.Code, m.chapter))
+		}
+		return m, tea.Batch(cmds...)
 	case tea.KeyMsg:
 		if m.statusMsg != "" {
 			m.statusMsg = ""
 			m.statusTimer = 0
 		}
+		if m.editingNote {
+			return m.updateNote(msg)
+		}
 		switch msg.String() {
 		case "j", "down":
 			if len(m.verses) > 0 && m.cursorIdx < len(m.verses)-1 {
 				m.cursorIdx++
+				m.wordSelected = false
 				m.viewport.SetContent(m.renderVerses())
 				m.ensureCursorVisible()
 			}
@@ -85,6 +254,7 @@ func (m ReadingModel) Update(msg tea.Msg) (ReadingModel, tea.Cmd) {
 		case "k", "up":
 			if len(m.verses) > 0 && m.cursorIdx > 0 {
 				m.cursorIdx--
+				m.wordSelected = false
 				m.viewport.SetContent(m.renderVerses())
 				m.ensureCursorVisible()
 			}
@@ -105,38 +275,110 @@ func (m ReadingModel) Update(msg tea.Msg) (ReadingModel, tea.Cmd) {
 			return m, nil
 		case "g":
 			m.cursorIdx = 0
+			m.wordSelected = false
 			m.viewport.SetContent(m.renderVerses())
 			m.viewport.GotoTop()
 			return m, nil
 		case "G":
 			if len(m.verses) > 0 {
 				m.cursorIdx = len(m.verses) - 1
+				m.wordSelected = false
 				m.viewport.SetContent(m.renderVerses())
 				m.viewport.GotoBottom()
 			}
 			return m, nil
+		case "f":
+			m.showFootnotes = !m.showFootnotes
+			if m.showFootnotes {
+				m.viewport.Height = m.height - 4 - footnotePaneHeight
+			} else {
+				m.viewport.Height = m.height - 4
+			}
+			if m.viewport.Height < 1 {
+				m.viewport.Height = 1
+			}
+			m.ensureCursorVisible()
+			return m, nil
+		case "v":
+			if m.database != nil {
+				return m, nextVersion(m.database, m.versionCode)
+			}
+			return m, nil
+		case "c":
+			if m.compareCode != "" {
+				m.compareCode = ""
+				m.compareVerses = nil
+				m.viewport.SetContent(m.renderVerses())
+				m.ensureCursorVisible()
+				return m, nil
+			}
+			if m.database != nil {
+				return m, startCompare(m.database, m.versionCode, m.book.Code, m.chapter)
+			}
+			return m, nil
+		case "w", "W":
+			if len(m.verses) == 0 {
+				return m, nil
+			}
+			n := len(verseWordSpans(m.verses[m.cursorIdx].Text))
+			if n == 0 {
+				return m, nil
+			}
+			switch {
+			case !m.wordSelected && msg.String() == "w":
+				m.wordIdx = 0
+			case !m.wordSelected:
+				m.wordIdx = n - 1
+			case msg.String() == "w":
+				m.wordIdx = (m.wordIdx + 1) % n
+			default:
+				m.wordIdx = (m.wordIdx + n - 1) % n
+			}
+			m.wordSelected = true
+			m.viewport.SetContent(m.renderVerses())
+			return m, nil
+		case "C":
+			word := m.selectedWord()
+			if word == "" {
+				m.statusMsg = "w로 단어를 먼저 고르세요"
+				return m, nil
+			}
+			return m, func() tea.Msg { return OpenConcordanceMsg{Word: word} }
+		case "V":
+			if len(m.verses) == 0 {
+				return m, nil
+			}
+			m.selecting = !m.selecting
+			m.selectAnchor = m.cursorIdx
+			m.viewport.SetContent(m.renderVerses())
+			return m, nil
+		case "esc":
+			// The app only passes Esc on while a selection is open.
+			m.selecting = false
+			m.viewport.SetContent(m.renderVerses())
+			return m, nil
 		case "B":
 			if len(m.verses) > 0 && m.database != nil {
-				v := m.verses[m.cursorIdx]
-				_, err := m.database.AddBookmark(v.ID, "")
-				if err == nil {
-					m.statusMsg = fmt.Sprintf("책갈피 추가: %s %d:%d", m.book.NameKo, v.Chapter, v.VerseNum)
-				} else {
-					m.statusMsg = fmt.Sprintf("오류: %v", err)
+				lo, hi := m.selection()
+				var quote []string
+				for _, v := range m.verses[lo : hi+1] {
+					quote = append(quote, v.Text)
 				}
+				title := "책갈피 메모: " + m.spanLabel(lo, hi)
+				m.note = NewNoteEditor(title, strings.Join(quote, " "), "", m.theme, m.width)
+				m.noteSpan = [2]int{lo, hi}
+				m.editingNote = true
 			}
 			return m, nil
 		case "H":
 			if len(m.verses) > 0 && m.database != nil {
-				v := m.verses[m.cursorIdx]
-				err := m.database.AddHighlight(v.ID, "yellow")
-				if err == nil {
-					m.statusMsg = fmt.Sprintf("하이라이트 추가: %s %d:%d", m.book.NameKo, v.Chapter, v.VerseNum)
-				} else {
-					m.statusMsg = fmt.Sprintf("오류: %v", err)
-				}
+				m.cycleHighlight()
+				return m, m.ReloadAnnotations()
 			}
 			return m, nil
+		case "u":
+			m.undoAutoComplete()
+			return m, nil
 		}
 	}
 	var cmd tea.Cmd
@@ -144,6 +386,201 @@ func (m ReadingModel) Update(msg tea.Msg) (ReadingModel, tea.Cmd) {
 	return m, cmd
 }
 
+// updateNote passes a key to the note editor opened with B and adds the
+// bookmark once the note is saved.
+func (m ReadingModel) updateNote(msg tea.KeyMsg) (ReadingModel, tea.Cmd) {
+	var cmd tea.Cmd
+	m.note, cmd = m.note.Update(msg)
+	switch {
+	case m.note.Saved():
+		m.editingNote = false
+		lo, hi := m.noteSpan[0], m.noteSpan[1]
+		if _, err := m.database.AddBookmarkRange(m.verses[lo].ID, m.verses[hi].ID, m.note.Value()); err != nil {
+			m.statusMsg = fmt.Sprintf("오류: %v", err)
+		} else {
+			m.statusMsg = "책갈피 추가: " + m.spanLabel(lo, hi)
+		}
+		m.selecting = false
+		m.viewport.SetContent(m.renderVerses())
+		return m, tea.Batch(cmd, m.ReloadAnnotations())
+	case m.note.Canceled():
+		m.editingNote = false
+		m.statusMsg = "책갈피 취소"
+	}
+	return m, cmd
+}
+
+// EditingNote reports whether the bookmark note editor is open, so the
+// app passes every key to it.
+func (m ReadingModel) EditingNote() bool {
+	return m.editingNote
+}
+
+// Selecting reports whether a V selection is open, so the app passes Esc
+// to it instead of leaving the chapter.
+func (m ReadingModel) Selecting() bool {
+	return m.selecting
+}
+
+// highlightColors is the order H cycles through; after the last color
+// the highlight is removed.
+var highlightColors = []string{"yellow", "green", "blue", "pink", "purple"}
+
+// nextHighlightColor returns the color after color in highlightColors,
+// "yellow" for no color, and "" after the last one.
+func nextHighlightColor(color string) string {
+	for i, c := range highlightColors {
+		if c == color {
+			if i+1 < len(highlightColors) {
+				return highlightColors[i+1]
+			}
+			return ""
+		}
+	}
+	return highlightColors[0]
+}
+
+// cycleHighlight moves the highlight under the cursor, or of the V
+// selection, to its next color, removing it after the last one. Without
+// a selection the whole highlight covering the cursor verse changes, even
+// when it is a range.
+func (m *ReadingModel) cycleHighlight() {
+	lo, hi := m.selection()
+	label := m.spanLabel(lo, hi)
+	current := m.annotations[m.verses[lo].VerseNum]
+	color := nextHighlightColor(current.HighlightColor)
+
+	var err error
+	switch {
+	case !m.selecting && current.HighlightID != 0 && color == "":
+		err = m.database.RemoveHighlightByID(current.HighlightID)
+	case !m.selecting && current.HighlightID != 0:
+		err = m.database.UpdateHighlightColor(current.HighlightID, color)
+	case color == "":
+		for _, v := range m.verses[lo : hi+1] {
+			if err = m.database.RemoveHighlight(v.ID); err != nil {
+				break
+			}
+		}
+	default:
+		err = m.database.AddHighlightRange(m.verses[lo].ID, m.verses[hi].ID, color)
+	}
+
+	switch {
+	case err != nil:
+		m.statusMsg = fmt.Sprintf("오류: %v", err)
+	case color == "":
+		m.statusMsg = "하이라이트 삭제: " + label
+	default:
+		m.statusMsg = fmt.Sprintf("하이라이트 %s: %s", color, label)
+	}
+	m.selecting = false
+	m.viewport.SetContent(m.renderVerses())
+}
+
+// selection returns the first and last verse index B and H act on: the V
+// selection, or just the cursor verse.
+func (m ReadingModel) selection() (lo, hi int) {
+	if !m.selecting {
+		return m.cursorIdx, m.cursorIdx
+	}
+	return min(m.selectAnchor, m.cursorIdx), max(m.selectAnchor, m.cursorIdx)
+}
+
+// spanLabel formats the verses from index lo to hi, e.g. "고린도전서 13:4-7".
+func (m ReadingModel) spanLabel(lo, hi int) string {
+	first, last := m.verses[lo], m.verses[hi]
+	return bible.Range{
+		Start: bible.Location{BookCode: m.book.Code, Chapter: first.Chapter, Verse: first.VerseNum},
+		End:   bible.Location{BookCode: m.book.Code, Chapter: last.Chapter, Verse: last.VerseNum},
+	}.String()
+}
+
+// verseMarker is the gutter left of the verse at index i: the cursor, a
+// bar for the rest of the V selection, or blank.
+func (m ReadingModel) verseMarker(i int) string {
+	switch lo, hi := m.selection(); {
+	case i == m.cursorIdx:
+		return lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true).Render("▸ ")
+	case m.selecting && i >= lo && i <= hi:
+		return lipgloss.NewStyle().Foreground(m.theme.Primary).Render("┃ ")
+	}
+	return "  "
+}
+
+// verseWordSpans returns the byte spans of the words of text: runs of
+// letters and digits, without punctuation.
+func verseWordSpans(text string) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsNumber(r)
+		switch {
+		case isWord && start < 0:
+			start = i
+		case !isWord && start >= 0:
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+	return spans
+}
+
+// selectedWord returns the word picked with w/W, or "" when none is.
+func (m ReadingModel) selectedWord() string {
+	if !m.wordSelected || m.cursorIdx >= len(m.verses) {
+		return ""
+	}
+	text := m.verses[m.cursorIdx].Text
+	spans := verseWordSpans(text)
+	if m.wordIdx >= len(spans) {
+		return ""
+	}
+	return text[spans[m.wordIdx][0]:spans[m.wordIdx][1]]
+}
+
+// nextVersionCode returns the code of the version after current, wrapping
+// around. It fails when there is no other version to switch to.
+func nextVersionCode(database *db.DB, current string) (string, error) {
+	versions, err := database.ListVersions()
+	if err != nil {
+		return "", err
+	}
+	if len(versions) < 2 {
+		return "", fmt.Errorf("다른 역본이 없습니다")
+	}
+	next := versions[0].Code
+	for i, v := range versions {
+		if v.Code == current {
+			next = versions[(i+1)%len(versions)].Code
+			break
+		}
+	}
+	return next, nil
+}
+
+// nextVersion picks the version after current among those in the database.
+func nextVersion(database *db.DB, current string) tea.Cmd {
+	return func() tea.Msg {
+		next, err := nextVersionCode(database, current)
+		return VersionChangedMsg{Code: next, Err: err}
+	}
+}
+
+// startCompare opens the parallel view with the version after current.
+func startCompare(database *db.DB, current, bookCode string, chapter int) tea.Cmd {
+	return func() tea.Msg {
+		code, err := nextVersionCode(database, current)
+		if err != nil {
+			return CompareLoadedMsg{Err: err}
+		}
+		return LoadCompareVerses(database, code, bookCode, chapter)()
+	}
+}
+
 func (m *ReadingModel) ensureCursorVisible() {
 	if m.cursorIdx < 0 || m.cursorIdx >= len(m.lineOffsets) {
 		return
@@ -162,23 +599,65 @@ func (m *ReadingModel) ensureCursorVisible() {
 func (m ReadingModel) View() string {
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Primary).Padding(0, 1)
 	title := titleStyle.Render(fmt.Sprintf("%s %d장", m.book.NameKo, m.chapter))
+	if m.versionCode != "" {
+		label := m.versionCode
+		if m.compareCode != "" {
+			label += " | " + m.compareCode
+		}
+		title += lipgloss.NewStyle().Foreground(m.theme.Secondary).Render("[" + label + "]")
+	}
 
-	navHint := lipgloss.NewStyle().Foreground(m.theme.Muted).Render("  ←/h:이전장  →/l:다음장  j/k:구절이동  B:책갈피  H:하이라이트  Esc:돌아가기")
+	navHint := lipgloss.NewStyle().Foreground(m.theme.Muted).Render("  ←/h:이전장  →/l:다음장  j/k:구절이동  f:각주  v:역본  c:대역  w:단어  C:용어색인  V:범위선택  B:책갈피  H:하이라이트(색 바꾸기)  Esc:돌아가기")
 
 	header := title + navHint
+	if m.selecting {
+		lo, hi := m.selection()
+		selStyle := lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true).PaddingLeft(2)
+		header += selStyle.Render("  선택: " + m.spanLabel(lo, hi) + " (B:책갈피 H:하이라이트 V/Esc:취소)")
+	}
 	if m.statusMsg != "" {
 		statusStyle := lipgloss.NewStyle().Foreground(m.theme.Secondary).Bold(true).PaddingLeft(2)
 		header += statusStyle.Render("  " + m.statusMsg)
 	}
 
-	return header + "\n" + m.viewport.View()
+	if m.editingNote {
+		return header + "\n\n" + m.note.View()
+	}
+	view := header + "\n" + m.viewport.View()
+	if m.showFootnotes {
+		view += "\n" + m.renderFootnotePane()
+	}
+	return view
+}
+
+// renderFootnotePane renders the footnotes of the verse under the cursor.
+func (m ReadingModel) renderFootnotePane() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
+	markerStyle := lipgloss.NewStyle().Foreground(m.theme.FootnoteMarker)
+
+	lines := []string{mutedStyle.Render("── 각주 " + strings.Repeat("─", max(m.width-8, 0)))}
+	if m.cursorIdx < len(m.verses) {
+		v := m.verses[m.cursorIdx]
+		for _, fn := range m.footnotes[v.ID] {
+			lines = append(lines, fmt.Sprintf("  %s %s", markerStyle.Render(fn.Marker), fn.Content))
+		}
+	}
+	if len(lines) == 1 {
+		lines = append(lines, mutedStyle.Render("  이 구절에는 각주가 없습니다."))
+	}
+	if len(lines) > footnotePaneHeight {
+		lines = lines[:footnotePaneHeight]
+	}
+	return strings.Join(lines, "\n")
 }
 
 func (m *ReadingModel) renderVerses() string {
+	if m.compareCode != "" {
+		return m.renderParallel()
+	}
 	var b strings.Builder
 	numStyle := lipgloss.NewStyle().Foreground(m.theme.Muted).Width(4).Align(lipgloss.Right)
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Secondary)
-	cursorStyle := lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true)
 
 	m.lineOffsets = make([]int, len(m.verses))
 	lineCount := 0
@@ -194,14 +673,121 @@ func (m *ReadingModel) renderVerses() string {
 
 		m.lineOffsets[i] = lineCount
 
+		marker := m.verseMarker(i)
+		num := m.verseNumber(v, numStyle)
+		b.WriteString(fmt.Sprintf("%s%s  %s\n", marker, num, m.verseText(v)))
+		lineCount++
+	}
+	return b.String()
+}
+
+// bookmarkGlyph marks bookmarked verses in the verse number column.
+const bookmarkGlyph = "◆"
+
+// verseNumber renders the verse number in the 4-column numStyle, with
+// bookmarkGlyph at the left when a bookmark covers the verse.
+func (m ReadingModel) verseNumber(v db.Verse, numStyle lipgloss.Style) string {
+	if !m.annotations[v.VerseNum].Bookmarked {
+		return numStyle.Render(fmt.Sprintf("%d", v.VerseNum))
+	}
+	glyph := lipgloss.NewStyle().Foreground(m.theme.Secondary).Render(bookmarkGlyph)
+	return glyph + numStyle.Width(3).Render(fmt.Sprintf("%d", v.VerseNum))
+}
+
+// verseText returns the verse text followed by its footnote markers, on
+// its highlight color and with the word picked with w/W underlined.
+func (m ReadingModel) verseText(v db.Verse) string {
+	markerStyle := lipgloss.NewStyle().Foreground(m.theme.FootnoteMarker)
+	wordStyle := lipgloss.NewStyle().Underline(true).Bold(true).Foreground(m.theme.Primary)
+	paint := func(s string) string { return s }
+	if color := m.annotations[v.VerseNum].HighlightColor; color != "" {
+		hlStyle := lipgloss.NewStyle().
+			Background(lipgloss.Color(highlightColor(color))).
+			Foreground(lipgloss.Color(highlightTextColor))
+		paint = func(s string) string { return hlStyle.Render(s) }
+		wordStyle = hlStyle.Underline(true).Bold(true)
+	}
+	text := paint(v.Text)
+	if m.wordSelected && m.cursorIdx < len(m.verses) && m.verses[m.cursorIdx].ID == v.ID {
+		if spans := verseWordSpans(v.Text); m.wordIdx < len(spans) {
+			sp := spans[m.wordIdx]
+			text = paint(v.Text[:sp[0]]) + wordStyle.Render(v.Text[sp[0]:sp[1]]) + paint(v.Text[sp[1]:])
+		}
+	}
+	for _, fn := range m.footnotes[v.ID] {
+		text += " " + markerStyle.Render(fn.Marker)
+	}
+	return text
+}
+
+// renderParallel renders the primary and comparison versions in two
+// columns, one row per verse number. The cursor still moves over the
+// primary verses; rows that exist only in the comparison version are
+// shown but cannot be selected.
+func (m *ReadingModel) renderParallel() string {
+	var b strings.Builder
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
+	numStyle := mutedStyle.Width(4).Align(lipgloss.Right)
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.theme.Secondary)
+
+	// marker(2) + number(4) + gap(2) + border(1) + padding(1) + margin(1)
+	colWidth := (m.width - 11) / 2
+	if colWidth < 10 {
+		colWidth = 10
+	}
+	leftCol := lipgloss.NewStyle().Width(colWidth)
+	rightCol := lipgloss.NewStyle().Width(colWidth).PaddingLeft(1).
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(m.theme.Muted)
+
+	idxByNum := make(map[int]int, len(m.verses))
+	for i, v := range m.verses {
+		idxByNum[v.VerseNum] = i
+	}
+
+	m.lineOffsets = make([]int, len(m.verses))
+	lineCount := 0
+
+	for _, p := range db.AlignVerses(m.verses, m.compareVerses) {
+		var leftTitle, rightTitle string
+		if p.Primary != nil {
+			leftTitle = p.Primary.SectionTitle
+		}
+		if p.Secondary != nil {
+			rightTitle = p.Secondary.SectionTitle
+		}
+		if leftTitle != "" || rightTitle != "" {
+			row := lipgloss.JoinHorizontal(lipgloss.Top,
+				"        ",
+				leftCol.Render(titleStyle.Render(leftTitle)),
+				rightCol.Render(titleStyle.Render(rightTitle)))
+			b.WriteString("\n" + row + "\n\n")
+			lineCount += lipgloss.Height(row) + 2
+		}
+
 		marker := "  "
-		if i == m.cursorIdx {
-			marker = cursorStyle.Render("▸ ")
+		left := mutedStyle.Render("—")
+		if p.Primary != nil {
+			idx := idxByNum[p.VerseNum]
+			m.lineOffsets[idx] = lineCount
+			marker = m.verseMarker(idx)
+			left = m.verseText(*p.Primary)
+		}
+		right := mutedStyle.Render("—")
+		if p.Secondary != nil {
+			right = p.Secondary.Text
 		}
 
-		num := numStyle.Render(fmt.Sprintf("%d", v.VerseNum))
-		b.WriteString(fmt.Sprintf("%s%s  %s\n", marker, num, v.Text))
-		lineCount++
+		num := numStyle.Render(fmt.Sprintf("%d", p.VerseNum))
+		if p.Primary != nil {
+			num = m.verseNumber(*p.Primary, numStyle)
+		}
+		row := lipgloss.JoinHorizontal(lipgloss.Top,
+			marker+num+"  ",
+			leftCol.Render(left),
+			rightCol.Render(right))
+		b.WriteString(row + "\n")
+		lineCount += lipgloss.Height(row)
 	}
 	return b.String()
 }
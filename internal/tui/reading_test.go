@@ -2,6 +2,7 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,7 +14,7 @@ import (
 
 func TestReadingModel_VersesLoaded(t *testing.T) {
 	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
-	m := NewReading(book, 1, nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewReading(book, 1, "GAE", nil, styles.DefaultDarkTheme(), 80, 24)
 	verses := []db.Verse{
 		{VerseNum: 1, Text: "태초에 하나님이 천지를 창조하시니라", SectionTitle: "천지 창조"},
 	}
@@ -28,7 +29,7 @@ func TestReadingModel_VersesLoaded(t *testing.T) {
 
 func TestReadingModel_VersesLoadedError(t *testing.T) {
 	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
-	m := NewReading(book, 1, nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewReading(book, 1, "GAE", nil, styles.DefaultDarkTheme(), 80, 24)
 	updated, _ := m.Update(VersesLoadedMsg{Err: fmt.Errorf("db error")})
 	if updated.loading {
 		t.Error("expected loading=false after error")
@@ -37,7 +38,7 @@ func TestReadingModel_VersesLoadedError(t *testing.T) {
 
 func TestReadingModel_View(t *testing.T) {
 	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
-	m := NewReading(book, 1, nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewReading(book, 1, "GAE", nil, styles.DefaultDarkTheme(), 80, 24)
 	v := m.View()
 	if v == "" {
 		t.Error("expected non-empty view")
@@ -46,7 +47,7 @@ func TestReadingModel_View(t *testing.T) {
 
 func TestReadingModel_InitialLoading(t *testing.T) {
 	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
-	m := NewReading(book, 1, nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewReading(book, 1, "GAE", nil, styles.DefaultDarkTheme(), 80, 24)
 	if !m.loading {
 		t.Error("expected loading=true initially")
 	}
@@ -54,7 +55,7 @@ func TestReadingModel_InitialLoading(t *testing.T) {
 
 func TestReadingModel_CursorMovement(t *testing.T) {
 	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
-	m := NewReading(book, 1, nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewReading(book, 1, "GAE", nil, styles.DefaultDarkTheme(), 80, 24)
 
 	verses := []db.Verse{
 		{ID: 1, VerseNum: 1, Text: "첫째 구절", Chapter: 1},
@@ -100,7 +101,7 @@ func TestReadingModel_CursorMovement(t *testing.T) {
 
 func TestReadingModel_CursorGAndShiftG(t *testing.T) {
 	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
-	m := NewReading(book, 1, nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewReading(book, 1, "GAE", nil, styles.DefaultDarkTheme(), 80, 24)
 
 	verses := []db.Verse{
 		{ID: 1, VerseNum: 1, Text: "첫째 구절", Chapter: 1},
@@ -122,7 +123,7 @@ func TestReadingModel_CursorGAndShiftG(t *testing.T) {
 
 func TestReadingModel_BookmarkWithoutDB(t *testing.T) {
 	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
-	m := NewReading(book, 1, nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewReading(book, 1, "GAE", nil, styles.DefaultDarkTheme(), 80, 24)
 
 	verses := []db.Verse{
 		{ID: 1, VerseNum: 1, Text: "첫째 구절", Chapter: 1},
@@ -139,3 +140,402 @@ func TestReadingModel_BookmarkWithoutDB(t *testing.T) {
 		t.Errorf("expected empty statusMsg without DB, got %q", m.statusMsg)
 	}
 }
+
+func TestReadingModel_FootnotePane(t *testing.T) {
+	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
+	m := NewReading(book, 1, "GAE", nil, styles.DefaultDarkTheme(), 80, 24)
+
+	verses := []db.Verse{
+		{ID: 1, VerseNum: 1, Text: "첫째 구절", Chapter: 1},
+		{ID: 2, VerseNum: 2, Text: "둘째 구절", Chapter: 1, HasFootnote: true},
+	}
+	footnotes := []db.Footnote{
+		{ID: 10, VerseID: 2, Marker: "1)", Content: "또는 형체가 없는"},
+	}
+	m, _ = m.Update(VersesLoadedMsg{Verses: verses, Footnotes: footnotes})
+
+	if !strings.Contains(m.renderVerses(), "1)") {
+		t.Error("expected inline footnote marker in rendered verses")
+	}
+	if strings.Contains(m.View(), "또는 형체가 없는") {
+		t.Error("expected footnote pane to be hidden by default")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if !m.showFootnotes {
+		t.Fatal("expected showFootnotes=true after f")
+	}
+	if m.viewport.Height != 24-4-footnotePaneHeight {
+		t.Errorf("expected viewport height to shrink, got %d", m.viewport.Height)
+	}
+	if !strings.Contains(m.View(), "이 구절에는 각주가 없습니다") {
+		t.Error("expected empty footnote message for verse 1")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if !strings.Contains(m.View(), "또는 형체가 없는") {
+		t.Error("expected footnote content for verse 2 in pane")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}})
+	if m.showFootnotes || m.viewport.Height != 24-4 {
+		t.Errorf("expected pane closed and viewport restored, got show=%v height=%d", m.showFootnotes, m.viewport.Height)
+	}
+}
+
+func setupVersionsDB(t *testing.T) *db.DB {
+	t.Helper()
+	database, err := db.OpenMemory()
+	if err != nil {
+		t.Fatalf("OpenMemory: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	if err := database.Migrate(); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	for _, v := range []struct{ code, name, prefix string }{
+		{"GAE", "개역개정", "개정"},
+		{"HAN", "개역한글", "한글"},
+	} {
+		vID, err := database.InsertVersion(v.code, v.name, "ko")
+		if err != nil {
+			t.Fatalf("InsertVersion: %v", err)
+		}
+		bookID, err := database.InsertBook(vID, "gen", "창세기", "창", "old", 50, 0)
+		if err != nil {
+			t.Fatalf("InsertBook: %v", err)
+		}
+		for n := 1; n <= 3; n++ {
+			if _, err := database.InsertVerse(bookID, 1, n, fmt.Sprintf("%s %d절", v.prefix, n), "", false); err != nil {
+				t.Fatalf("InsertVerse: %v", err)
+			}
+		}
+	}
+	return database
+}
+
+func TestNextVersion(t *testing.T) {
+	database := setupVersionsDB(t)
+
+	msg := nextVersion(database, "GAE")().(VersionChangedMsg)
+	if msg.Err != nil || msg.Code != "HAN" {
+		t.Errorf("expected HAN after GAE, got %q (err=%v)", msg.Code, msg.Err)
+	}
+	msg = nextVersion(database, "HAN")().(VersionChangedMsg)
+	if msg.Err != nil || msg.Code != "GAE" {
+		t.Errorf("expected wrap to GAE after HAN, got %q (err=%v)", msg.Code, msg.Err)
+	}
+}
+
+func TestReadingModel_SwitchVersionKeepsVerse(t *testing.T) {
+	database := setupVersionsDB(t)
+	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
+	m := NewReading(book, 1, "GAE", database, styles.DefaultDarkTheme(), 80, 24)
+
+	m, _ = m.Update(LoadVerses(database, "GAE", "gen", 1)())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}})
+	if cmd == nil {
+		t.Fatal("expected version switch command")
+	}
+	m, cmd = m.Update(cmd())
+	if m.versionCode != "HAN" {
+		t.Fatalf("expected versionCode HAN, got %q", m.versionCode)
+	}
+	if cmd == nil {
+		t.Fatal("expected reload command after version change")
+	}
+	m, _ = m.Update(cmd())
+
+	if m.cursorIdx != 1 {
+		t.Errorf("expected cursor to stay on verse 2, got idx %d", m.cursorIdx)
+	}
+	if m.verses[0].Text != "한글 1절" {
+		t.Errorf("expected HAN text, got %q", m.verses[0].Text)
+	}
+	if !strings.Contains(m.View(), "[HAN]") {
+		t.Error("expected version code in title")
+	}
+}
+
+func TestReadingModel_BookmarkNote(t *testing.T) {
+	database := setupVersionsDB(t)
+	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
+	m := NewReading(book, 1, "GAE", database, styles.DefaultDarkTheme(), 80, 24)
+	m, _ = m.Update(LoadVerses(database, "GAE", "gen", 1)())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+	if !m.EditingNote() {
+		t.Fatal("expected B to open the note editor")
+	}
+	if v := m.View(); !strings.Contains(v, "책갈피 메모: 창세기 1:2") || !strings.Contains(v, "개정 2절") {
+		t.Errorf("expected the editor to show the verse, got: %s", v)
+	}
+
+	// Keys go to the editor, not to cursor movement.
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("첫 줄")},
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyRunes, Runes: []rune("jk")},
+		{Type: tea.KeyCtrlS},
+	} {
+		m, _ = m.Update(msg)
+	}
+	if m.EditingNote() || m.cursorIdx != 1 {
+		t.Fatalf("expected the editor closed on verse 2, got editing=%v cursor=%d", m.EditingNote(), m.cursorIdx)
+	}
+	if !strings.Contains(m.statusMsg, "책갈피 추가: 창세기 1:2") {
+		t.Errorf("unexpected status %q", m.statusMsg)
+	}
+	bookmarks, err := database.ListBookmarks(10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bookmarks) != 1 || bookmarks[0].Note != "첫 줄\njk" || bookmarks[0].VerseNum != 2 {
+		t.Fatalf("unexpected bookmarks: %+v", bookmarks)
+	}
+
+	// Esc cancels without bookmarking.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.EditingNote() {
+		t.Error("expected esc to close the editor")
+	}
+	if bookmarks, _ := database.ListBookmarks(10, 0); len(bookmarks) != 1 {
+		t.Errorf("expected no bookmark after esc, got %d", len(bookmarks))
+	}
+}
+
+func TestReadingModel_RangeSelection(t *testing.T) {
+	database := setupVersionsDB(t)
+	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
+	m := NewReading(book, 1, "GAE", database, styles.DefaultDarkTheme(), 80, 24)
+	m, _ = m.Update(LoadVerses(database, "GAE", "gen", 1)())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+
+	// V then k extends the selection upwards from verse 3.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	if !m.Selecting() {
+		t.Fatal("expected V to start a selection")
+	}
+	v := m.View()
+	if !strings.Contains(v, "선택: 창세기 1:2-3") || !strings.Contains(v, "┃") {
+		t.Errorf("expected the selection in the view, got: %s", v)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'B'}})
+	if !strings.Contains(m.View(), "책갈피 메모: 창세기 1:2-3") {
+		t.Errorf("expected the range in the editor title, got: %s", m.View())
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if m.Selecting() || m.statusMsg != "책갈피 추가: 창세기 1:2-3" {
+		t.Errorf("expected the selection closed after bookmarking, status %q", m.statusMsg)
+	}
+	bookmarks, err := database.ListBookmarks(10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bookmarks) != 1 || bookmarks[0].VerseNum != 2 || bookmarks[0].EndVerse != 3 {
+		t.Fatalf("expected one 1:2-3 bookmark, got %+v", bookmarks)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	highlights, err := database.ListHighlights(10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(highlights) != 1 || highlights[0].VerseNum != 1 || highlights[0].EndVerse != 2 {
+		t.Fatalf("expected one 1:1-2 highlight, got %+v", highlights)
+	}
+
+	// Esc closes the selection without acting on it.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'V'}})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.Selecting() {
+		t.Error("expected esc to close the selection")
+	}
+}
+
+func TestReadingModel_HighlightCycleAndBookmarkGlyph(t *testing.T) {
+	database := setupVersionsDB(t)
+	verses, err := database.GetVerses("GAE", "gen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := database.AddBookmarkRange(verses[1].ID, verses[2].ID, ""); err != nil {
+		t.Fatal(err)
+	}
+	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
+	m := NewReading(book, 1, "GAE", database, styles.DefaultDarkTheme(), 80, 24)
+	m, _ = m.Update(LoadVerses(database, "GAE", "gen", 1)())
+
+	// The bookmarked range is marked in the number column.
+	lines := strings.Split(m.viewport.View(), "\n")
+	if strings.Contains(lines[0], bookmarkGlyph) || !strings.Contains(lines[1], bookmarkGlyph+"  2") || !strings.Contains(lines[2], bookmarkGlyph+"  3") {
+		t.Errorf("expected the glyph on verses 2-3 only, got:\n%s", m.viewport.View())
+	}
+
+	// H cycles yellow → green → … → purple → removed.
+	for _, want := range append(highlightColors, "") {
+		var cmd tea.Cmd
+		m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+		if cmd == nil {
+			t.Fatal("expected H to reload the annotations")
+		}
+		m, _ = m.Update(cmd())
+		if got := m.annotations[1].HighlightColor; got != want {
+			t.Fatalf("expected %q after H, got %q (status %q)", want, got, m.statusMsg)
+		}
+	}
+	if m.statusMsg != "하이라이트 삭제: 창세기 1:1" {
+		t.Errorf("unexpected status %q", m.statusMsg)
+	}
+	if highlights, _ := database.ListHighlights(10, 0); len(highlights) != 0 {
+		t.Errorf("expected the highlight removed, got %+v", highlights)
+	}
+
+	// On a verse inside a range highlight, H recolors the whole range.
+	if err := database.AddHighlightRange(verses[0].ID, verses[2].ID, "blue"); err != nil {
+		t.Fatal(err)
+	}
+	m, _ = m.Update(m.ReloadAnnotations()())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	m, _ = m.Update(cmd())
+	for n := 1; n <= 3; n++ {
+		if got := m.annotations[n].HighlightColor; got != "pink" {
+			t.Errorf("expected verse %d pink, got %q", n, got)
+		}
+	}
+
+	// Replies for a chapter no longer shown are dropped.
+	m, _ = m.Update(AnnotationsLoadedMsg{VersionCode: "GAE", BookCode: "gen", Chapter: 2})
+	if m.annotations[1].HighlightColor != "pink" {
+		t.Error("expected a stale reply to be ignored")
+	}
+}
+
+func TestReadingModel_CompareToggle(t *testing.T) {
+	database := setupVersionsDB(t)
+	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
+	m := NewReading(book, 1, "GAE", database, styles.DefaultDarkTheme(), 100, 24)
+	m, _ = m.Update(LoadVerses(database, "GAE", "gen", 1)())
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if cmd == nil {
+		t.Fatal("expected compare load command")
+	}
+	m, _ = m.Update(cmd())
+	if m.compareCode != "HAN" {
+		t.Fatalf("expected compareCode HAN, got %q", m.compareCode)
+	}
+
+	view := m.View()
+	for _, want := range []string{"[GAE | HAN]", "개정 2절", "한글 2절"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in parallel view", want)
+		}
+	}
+	if len(m.lineOffsets) != len(m.verses) {
+		t.Errorf("expected %d line offsets, got %d", len(m.verses), len(m.lineOffsets))
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	if m.compareCode != "" {
+		t.Errorf("expected compare mode off, got %q", m.compareCode)
+	}
+	if strings.Contains(m.View(), "한글 2절") {
+		t.Error("expected comparison text to be hidden")
+	}
+}
+
+func TestReadingModel_CompareMissingVerse(t *testing.T) {
+	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
+	m := NewReading(book, 1, "GAE", nil, styles.DefaultDarkTheme(), 100, 24)
+	m, _ = m.Update(VersesLoadedMsg{Verses: []db.Verse{
+		{ID: 1, VerseNum: 1, Text: "첫째"},
+		{ID: 2, VerseNum: 2, Text: "둘째"},
+	}})
+	m, _ = m.Update(CompareLoadedMsg{Code: "HAN", Verses: []db.Verse{
+		{ID: 11, VerseNum: 1, Text: "하나"},
+	}})
+
+	view := m.View()
+	if !strings.Contains(view, "둘째") || !strings.Contains(view, "—") {
+		t.Error("expected placeholder for verse missing in comparison version")
+	}
+	if m.lineOffsets[1] <= m.lineOffsets[0] {
+		t.Errorf("expected increasing line offsets, got %v", m.lineOffsets)
+	}
+}
+
+func TestReadingModel_SwitchToCompareVersionSwaps(t *testing.T) {
+	database := setupVersionsDB(t)
+	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
+	m := NewReading(book, 1, "GAE", database, styles.DefaultDarkTheme(), 100, 24)
+	m, _ = m.Update(LoadVerses(database, "GAE", "gen", 1)())
+	m, _ = m.Update(LoadCompareVerses(database, "HAN", "gen", 1)())
+
+	m, _ = m.Update(VersionChangedMsg{Code: "HAN"})
+	if m.versionCode != "HAN" || m.compareCode != "GAE" {
+		t.Errorf("expected HAN | GAE, got %s | %s", m.versionCode, m.compareCode)
+	}
+}
+
+func TestReadingModel_WordCursor(t *testing.T) {
+	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
+	m := NewReading(book, 1, "GAE", nil, styles.DefaultDarkTheme(), 80, 24)
+	m, _ = m.Update(VersesLoadedMsg{Verses: []db.Verse{
+		{ID: 1, VerseNum: 1, Text: "태초에 하나님이, 천지를 창조하시니라"},
+		{ID: 2, VerseNum: 2, Text: "땅이 혼돈하고"},
+	}})
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if cmd != nil || m.statusMsg == "" {
+		t.Fatal("expected C without a picked word to ask for one")
+	}
+
+	w := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}}
+	m, _ = m.Update(w)
+	m, _ = m.Update(w)
+	if got := m.selectedWord(); got != "하나님이" {
+		t.Errorf("expected 하나님이 after two w, got %q", got)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	if got := m.selectedWord(); got != "태초에" {
+		t.Errorf("expected 태초에 after W, got %q", got)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'W'}})
+	if got := m.selectedWord(); got != "창조하시니라" {
+		t.Errorf("expected W to wrap to the last word, got %q", got)
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if cmd == nil {
+		t.Fatal("expected C to open the concordance")
+	}
+	if msg, ok := cmd().(OpenConcordanceMsg); !ok || msg.Word != "창조하시니라" {
+		t.Errorf("expected OpenConcordanceMsg for 창조하시니라, got %#v", cmd())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if m.selectedWord() != "" {
+		t.Error("expected moving to another verse to drop the picked word")
+	}
+}
+
+func TestVerseWordSpans(t *testing.T) {
+	text := "“빛이 있으라” 하시니, 3절"
+	var words []string
+	for _, sp := range verseWordSpans(text) {
+		words = append(words, text[sp[0]:sp[1]])
+	}
+	if got := strings.Join(words, "|"); got != "빛이|있으라|하시니|3절" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
+	"github.com/yangsijun/bible-tui/internal/config"
+	"github.com/yangsijun/bible-tui/internal/db"
+)
+
+// readingMinTime is how long a chapter has to stay open, besides being
+// scrolled to the end, to count as read. It keeps paging through chapters
+// from checking off a plan.
+const readingMinTime = 20 * time.Second
+
+// ReadingTimeMsg arrives once a chapter scrolled to the end has been open
+// for readingMinTime. SessionID tells the session it belongs to, since the
+// chapter may have been left in the meantime.
+type ReadingTimeMsg struct {
+	SessionID int64
+}
+
+// startSession logs the chapter just loaded as opened. The session is
+// started once per visit; reloading the chapter in another version keeps
+// it, and none starts while another screen is shown.
+func (m *ReadingModel) startSession() {
+	if m.logID != 0 || m.away || m.database == nil || len(m.verses) == 0 {
+		return
+	}
+	m.openedAt = m.now()
+	id, err := m.database.StartReading(m.book.Code, m.chapter, m.openedAt)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("오류: %v", err)
+		return
+	}
+	m.logID = id
+}
+
+// reachEnd notices the chapter being scrolled to the end and returns the
+// timer after which it counts as read.
+func (m *ReadingModel) reachEnd() tea.Cmd {
+	if m.atEnd || m.logID == 0 || m.loading || !m.viewport.AtBottom() {
+		return nil
+	}
+	m.atEnd = true
+	id := m.logID
+	wait := max(0, readingMinTime-m.now().Sub(m.openedAt))
+	return tea.Tick(wait, func(time.Time) tea.Msg {
+		return ReadingTimeMsg{SessionID: id}
+	})
+}
+
+// logRead records the chapter as read and, unless turned off in the
+// settings, checks off the plan readings it completes.
+func (m *ReadingModel) logRead() {
+	if err := m.database.UpdateReading(m.logID, m.secondsOpen(), true); err != nil {
+		m.statusMsg = fmt.Sprintf("오류: %v", err)
+		return
+	}
+	cfg, err := config.LoadConfig(m.database)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("오류: %v", err)
+		return
+	}
+	if !cfg.AutoCompletePlans {
+		return
+	}
+	entries, err := m.database.CompleteReadEntries(m.book.Code, m.chapter, m.now())
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("오류: %v", err)
+		return
+	}
+	if len(entries) > 0 {
+		m.autoCompleted = entries
+		m.statusMsg = "읽기 계획 체크: " + entryLabels(entries) + " (u: 취소)"
+	}
+}
+
+// undoAutoComplete unchecks the plan readings the chapter checked off.
+func (m *ReadingModel) undoAutoComplete() {
+	if len(m.autoCompleted) == 0 {
+		return
+	}
+	ids := make([]int64, len(m.autoCompleted))
+	for i, e := range m.autoCompleted {
+		ids[i] = e.ID
+	}
+	if err := m.database.UncompleteEntries(ids...); err != nil {
+		m.statusMsg = fmt.Sprintf("오류: %v", err)
+		return
+	}
+	m.statusMsg = "체크 취소: " + entryLabels(m.autoCompleted)
+	m.autoCompleted = nil
+}
+
+// EndSession records the time spent on the chapter when the app leaves it
+// for another chapter or screen, and drops the timer of the session. It is
+// best effort: the reader is going away, so an error has nowhere to show.
+func (m *ReadingModel) EndSession() {
+	m.away = true
+	if m.logID == 0 {
+		return
+	}
+	_ = m.database.UpdateReading(m.logID, m.secondsOpen(), false)
+	m.logID = 0
+	m.atEnd = false
+}
+
+// ResumeSession starts a new session when the app comes back to the
+// chapter from another screen.
+func (m *ReadingModel) ResumeSession() tea.Cmd {
+	m.away = false
+	m.startSession()
+	return m.reachEnd()
+}
+
+func (m ReadingModel) secondsOpen() int {
+	return int(m.now().Sub(m.openedAt).Seconds())
+}
+
+// entryLabels lists plan readings, e.g. "창세기 1-2; 시편 1".
+func entryLabels(entries []db.PlanEntry) string {
+	ranges := make([]bible.Range, len(entries))
+	for i, e := range entries {
+		ranges[i] = e.Range()
+	}
+	return bible.FormatReferences(ranges)
+}
@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/yangsijun/bible-tui/internal/bible"
+	"github.com/yangsijun/bible-tui/internal/config"
+	"github.com/yangsijun/bible-tui/internal/db"
+	"github.com/yangsijun/bible-tui/internal/tui/styles"
+)
+
+// openLoggedChapter opens Genesis 1, which fits on one screen, with a
+// clock that advances only when the returned function is called.
+func openLoggedChapter(t *testing.T, database *db.DB) (ReadingModel, tea.Cmd, func(time.Duration)) {
+	t.Helper()
+	clock := time.Now()
+	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
+	m := NewReading(book, 1, "GAE", database, styles.DefaultDarkTheme(), 80, 24)
+	m.now = func() time.Time { return clock }
+	m, cmd := m.Update(LoadVerses(database, "GAE", "gen", 1)())
+	return m, cmd, func(d time.Duration) { clock = clock.Add(d) }
+}
+
+func TestReadingModel_AutoCompletePlan(t *testing.T) {
+	database := setupVersionsDB(t)
+	version, err := database.GetVersionByCode("GAE")
+	if err != nil {
+		t.Fatalf("GetVersionByCode: %v", err)
+	}
+	_, err = database.CreateCustomPlan(version.ID, "창세기 읽기", []db.PlanEntry{
+		{DayNumber: 1, BookCode: "gen", ChapterStart: 1, ChapterEnd: 1},
+	})
+	if err != nil {
+		t.Fatalf("CreateCustomPlan: %v", err)
+	}
+	completed := func() bool {
+		t.Helper()
+		entry, err := database.GetPlanEntry(1)
+		if err != nil || entry == nil {
+			t.Fatalf("GetPlanEntry: %+v, %v", entry, err)
+		}
+		return entry.Completed
+	}
+
+	m, cmd, advance := openLoggedChapter(t, database)
+	if m.logID == 0 || !m.atEnd || cmd == nil {
+		t.Fatalf("expected a session at the end of the chapter with a timer, got logID=%d atEnd=%v cmd=%v", m.logID, m.atEnd, cmd != nil)
+	}
+
+	// A timer of another session does nothing.
+	advance(readingMinTime)
+	m, _ = m.Update(ReadingTimeMsg{SessionID: m.logID + 1})
+	if completed() {
+		t.Fatal("expected a stale timer to leave the plan alone")
+	}
+
+	m, _ = m.Update(ReadingTimeMsg{SessionID: m.logID})
+	if !completed() {
+		t.Fatal("expected Genesis 1 checked off after reading it")
+	}
+	if !strings.Contains(m.statusMsg, "읽기 계획 체크: 창세기 1") || !strings.Contains(m.statusMsg, "u: 취소") {
+		t.Errorf("unexpected status %q", m.statusMsg)
+	}
+	sessions, err := database.ListReadingLog(10)
+	if err != nil || len(sessions) != 1 || !sessions[0].ScrolledToEnd || sessions[0].Seconds != 20 {
+		t.Errorf("expected one session read to the end in 20s, got %+v, %v", sessions, err)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if completed() {
+		t.Error("expected u to undo the check")
+	}
+	if !strings.Contains(m.statusMsg, "체크 취소: 창세기 1") {
+		t.Errorf("unexpected status after undo %q", m.statusMsg)
+	}
+
+	advance(40 * time.Second)
+	m.EndSession()
+	sessions, _ = database.ListReadingLog(10)
+	if sessions[0].Seconds != 60 || !sessions[0].ScrolledToEnd || !sessions[0].OpenedAt.Equal(m.openedAt.Truncate(time.Second)) {
+		t.Errorf("expected 60s logged on leaving, opened at %v, got %+v", m.openedAt, sessions[0])
+	}
+}
+
+func TestReadingModel_AutoCompleteDisabled(t *testing.T) {
+	database := setupVersionsDB(t)
+	version, err := database.GetVersionByCode("GAE")
+	if err != nil {
+		t.Fatalf("GetVersionByCode: %v", err)
+	}
+	_, err = database.CreateCustomPlan(version.ID, "창세기 읽기", []db.PlanEntry{
+		{DayNumber: 1, BookCode: "gen", ChapterStart: 1, ChapterEnd: 1},
+	})
+	if err != nil {
+		t.Fatalf("CreateCustomPlan: %v", err)
+	}
+	cfg := &config.Config{ThemeName: "dark", FontSize: 2, VersionCode: "GAE", AutoCompletePlans: false}
+	if err := config.SaveConfig(database, cfg); err != nil {
+		t.Fatalf("SaveConfig: %v", err)
+	}
+
+	m, _, advance := openLoggedChapter(t, database)
+	advance(readingMinTime)
+	m, _ = m.Update(ReadingTimeMsg{SessionID: m.logID})
+
+	if entry, _ := database.GetPlanEntry(1); entry.Completed {
+		t.Error("expected the plan left alone with auto-complete off")
+	}
+	if sessions, _ := database.ListReadingLog(10); len(sessions) != 1 || !sessions[0].ScrolledToEnd {
+		t.Errorf("expected the chapter still logged as read, got %+v", sessions)
+	}
+	if m.statusMsg != "" {
+		t.Errorf("expected no status, got %q", m.statusMsg)
+	}
+}
+
+func TestReadingModel_ReachEndOnScroll(t *testing.T) {
+	database := setupVersionsDB(t)
+	book := bible.BookInfo{Code: "gen", NameKo: "창세기", ChapterCount: 50}
+	// Three verses do not fit in a viewport of two lines.
+	m := NewReading(book, 1, "GAE", database, styles.DefaultDarkTheme(), 80, 6)
+	m, cmd := m.Update(LoadVerses(database, "GAE", "gen", 1)())
+	if m.logID == 0 || m.atEnd || cmd != nil {
+		t.Fatalf("expected a session not yet at the end, got logID=%d atEnd=%v", m.logID, m.atEnd)
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if !m.atEnd || cmd == nil {
+		t.Error("expected G to reach the end and start the timer")
+	}
+}
+
+func TestApp_ReadingSessionOnlyOnScreen(t *testing.T) {
+	database := setupVersionsDB(t)
+	version, err := database.GetVersionByCode("GAE")
+	if err != nil {
+		t.Fatalf("GetVersionByCode: %v", err)
+	}
+	_, err = database.CreateCustomPlan(version.ID, "창세기 읽기", []db.PlanEntry{
+		{DayNumber: 1, BookCode: "gen", ChapterStart: 1, ChapterEnd: 1},
+	})
+	if err != nil {
+		t.Fatalf("CreateCustomPlan: %v", err)
+	}
+
+	m := New(database)
+	m.state = StateReading
+	reading, _, advance := openLoggedChapter(t, database)
+	m.reading = reading
+	first := reading.logID
+
+	// Leaving for the bookmarks ends the session and its timer.
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'m'}})
+	m = updated.(AppModel)
+	if m.state != StateBookmarks || m.reading.logID != 0 {
+		t.Fatalf("expected the session ended off screen, got state %d logID %d", m.state, m.reading.logID)
+	}
+	advance(readingMinTime)
+	updated, _ = m.Update(ReadingTimeMsg{SessionID: first})
+	m = updated.(AppModel)
+	if entry, _ := database.GetPlanEntry(1); entry.Completed {
+		t.Error("expected a timer of an ended session to leave the plan alone")
+	}
+
+	// Coming back starts a new session.
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEscape})
+	m = updated.(AppModel)
+	if m.state != StateReading || m.reading.logID == 0 || m.reading.logID == first || cmd == nil {
+		t.Errorf("expected a new session with a timer on return, got state %d logID %d", m.state, m.reading.logID)
+	}
+	if sessions, _ := database.ListReadingLog(10); len(sessions) != 2 {
+		t.Errorf("expected 2 sessions, got %+v", sessions)
+	}
+}
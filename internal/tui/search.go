@@ -13,12 +13,34 @@ import (
 	"github.com/yangsijun/bible-tui/internal/tui/styles"
 )
 
+// SearchResultsMsg carries one page of results. A page with Offset > 0
+// extends the results already shown for the same query.
 type SearchResultsMsg struct {
-	Results []db.SearchResult
-	Query   string
-	Err     error
+	Results    []db.SearchResult
+	Query      string
+	Offset     int
+	Total      int
+	BookCounts []db.BookCount
+	Err        error
 }
 
+const searchPageSize = 20
+
+// SearchHistoryMsg carries the past and saved searches. SavedName is set
+// when it follows saving a search.
+type SearchHistoryMsg struct {
+	History   []db.SearchHistoryEntry
+	Saved     []db.SavedSearch
+	SavedName string
+	Err       error
+}
+
+// searchHistoryRecall is how many past searches up/down can recall.
+const searchHistoryRecall = 50
+
+// searchSortLabels names the sort orders, indexed by db.SearchSort.
+var searchSortLabels = []string{"관련도순", "성경순", "책별"}
+
 type GoToVerseMsg struct {
 	BookCode string
 	Chapter  int
@@ -26,49 +48,168 @@ type GoToVerseMsg struct {
 }
 
 type SearchModel struct {
-	input     textinput.Model
-	results   []db.SearchResult
-	selected  int
-	database  *db.DB
-	theme     *styles.Theme
-	query     string
-	loading   bool
-	noResults bool
-	width     int
-	height    int
-}
-
-func NewSearch(database *db.DB, theme *styles.Theme, width, height int) SearchModel {
+	input       textinput.Model
+	results     []db.SearchResult
+	selected    int
+	database    *db.DB
+	versionCode string
+	theme       *styles.Theme
+	query       string
+	loading     bool
+	loadingMore bool
+	noResults   bool
+	err         error
+	total       int
+	bookCounts  []db.BookCount
+	sort        db.SearchSort
+	regex       bool // search the query as a regular expression
+	width       int
+	height      int
+
+	history    []db.SearchHistoryEntry // newest first
+	saved      []db.SavedSearch
+	historyIdx int    // entry recalled with up/down; -1 while editing
+	draft      string // input before recalling history
+	naming     bool   // asking for a name to save the search under
+	nameInput  textinput.Model
+	statusMsg  string
+}
+
+func NewSearch(database *db.DB, versionCode string, theme *styles.Theme, width, height int) SearchModel {
 	ti := textinput.New()
-	ti.Placeholder = "검색어를 입력하세요..."
+	ti.Placeholder = searchPlaceholders[0]
 	ti.Focus()
 	ti.CharLimit = 100
 	if width > 4 {
 		ti.Width = width - 4
 	}
+	ni := textinput.New()
+	ni.Prompt = "저장할 이름: "
+	ni.CharLimit = 50
 	return SearchModel{
-		input:    ti,
-		database: database,
-		theme:    theme,
-		width:    width,
-		height:   height,
+		input:       ti,
+		database:    database,
+		versionCode: versionCode,
+		theme:       theme,
+		width:       width,
+		height:      height,
+		historyIdx:  -1,
+		nameInput:   ni,
+	}
+}
+
+// LoadSearchHistory loads the past and saved searches for recall.
+func LoadSearchHistory(database *db.DB) tea.Cmd {
+	return func() tea.Msg {
+		return loadSearchHistory(database, "")
+	}
+}
+
+func loadSearchHistory(database *db.DB, savedName string) SearchHistoryMsg {
+	if database == nil {
+		return SearchHistoryMsg{Err: fmt.Errorf("no database")}
+	}
+	history, err := database.ListSearchHistory(searchHistoryRecall)
+	if err != nil {
+		return SearchHistoryMsg{Err: err}
+	}
+	saved, err := database.ListSavedSearches()
+	if err != nil {
+		return SearchHistoryMsg{Err: err}
+	}
+	return SearchHistoryMsg{History: history, Saved: saved, SavedName: savedName}
+}
+
+// recordSearch adds a search to the history and reloads it.
+func recordSearch(database *db.DB, query string, regex bool) tea.Cmd {
+	return func() tea.Msg {
+		if database == nil {
+			return SearchHistoryMsg{Err: fmt.Errorf("no database")}
+		}
+		if err := database.AddSearchHistory(query, regex); err != nil {
+			return SearchHistoryMsg{Err: err}
+		}
+		return loadSearchHistory(database, "")
+	}
+}
+
+// saveSearch saves a search under name and reloads the saved searches.
+func saveSearch(database *db.DB, name, query string, regex bool) tea.Cmd {
+	return func() tea.Msg {
+		if database == nil {
+			return SearchHistoryMsg{Err: fmt.Errorf("no database")}
+		}
+		if err := database.SaveSearch(name, query, regex); err != nil {
+			return SearchHistoryMsg{Err: err}
+		}
+		return loadSearchHistory(database, name)
 	}
 }
 
+// searchPlaceholders are the input placeholders for word and regex search.
+var searchPlaceholders = [2]string{
+	`검색어 (예: 사랑 -미움, "세상을 이처럼", book:롬)`,
+	`정규식 (예: 여호와(의|께서), ^그러므로)`,
+}
+
 func (m SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.statusMsg = ""
+		if m.naming {
+			return m.updateNaming(msg)
+		}
+		switch msg.String() {
+		case "ctrl+s":
+			if m.query != "" {
+				m.naming = true
+				m.input.Blur()
+				m.nameInput.SetValue("")
+				m.nameInput.Placeholder = m.query
+				return m, m.nameInput.Focus()
+			}
+			m.statusMsg = "저장할 검색이 없습니다"
+			return m, nil
+		case "ctrl+r":
+			m.setRegex(!m.regex)
+			return m, nil
+		}
 		if m.input.Focused() {
 			switch msg.String() {
 			case "enter":
 				query := strings.TrimSpace(m.input.Value())
-				if query != "" {
-					m.query = query
-					m.loading = true
-					return m, searchVerses(m.database, "GAE", query, 20)
+				if query == "" {
+					return m, nil
+				}
+				m.historyIdx = -1
+				if name, ok := strings.CutPrefix(query, "@"); ok {
+					s := m.findSaved(name)
+					if s == nil {
+						m.statusMsg = fmt.Sprintf("저장한 검색이 없습니다: %s", name)
+						return m, nil
+					}
+					query = s.Query
+					m.setRegex(s.Regex)
+					m.input.SetValue(query)
+				}
+				m.query = query
+				m, cmd := m.runSearch()
+				return m, tea.Batch(cmd, recordSearch(m.database, query, m.regex))
+			case "up":
+				if m.historyIdx+1 < len(m.history) {
+					if m.historyIdx < 0 {
+						m.draft = m.input.Value()
+					}
+					m.historyIdx++
+					m.recall()
 				}
 				return m, nil
 			case "down", "tab":
+				if msg.String() == "down" && m.historyIdx >= 0 {
+					m.historyIdx--
+					m.recall()
+					return m, nil
+				}
 				if len(m.results) > 0 {
 					m.input.Blur()
 				}
@@ -86,8 +227,12 @@ func (m SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
 			case "down", "j":
 				if m.selected < len(m.results)-1 {
 					m.selected++
+					return m, nil
 				}
-				return m, nil
+				return m.loadMore()
+			case "o":
+				m.sort = (m.sort + 1) % db.SearchSort(len(searchSortLabels))
+				return m.runSearch()
 			case "enter":
 				if m.selected < len(m.results) {
 					r := m.results[m.selected]
@@ -106,14 +251,40 @@ func (m SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
 			}
 		}
 
+	case SearchHistoryMsg:
+		if msg.Err != nil {
+			if msg.SavedName != "" || m.naming {
+				m.statusMsg = fmt.Sprintf("오류: %v", msg.Err)
+			}
+			return m, nil
+		}
+		m.history = msg.History
+		m.saved = msg.Saved
+		if msg.SavedName != "" {
+			m.statusMsg = fmt.Sprintf("검색 저장: @%s", msg.SavedName)
+		}
+		return m, nil
+
 	case SearchResultsMsg:
+		if msg.Offset > 0 {
+			m.loadingMore = false
+			// Drop pages left over from an earlier search.
+			if msg.Err == nil && !m.loading && msg.Query == m.query && msg.Offset == len(m.results) {
+				m.results = append(m.results, msg.Results...)
+				m.total = msg.Total
+			}
+			return m, nil
+		}
 		m.loading = false
+		m.err = msg.Err
 		if msg.Err != nil {
 			m.results = nil
 			m.noResults = true
 			return m, nil
 		}
 		m.results = msg.Results
+		m.total = msg.Total
+		m.bookCounts = msg.BookCounts
 		m.noResults = len(msg.Results) == 0
 		m.selected = 0
 		return m, nil
@@ -124,52 +295,287 @@ func (m SearchModel) Update(msg tea.Msg) (SearchModel, tea.Cmd) {
 	return m, cmd
 }
 
+// updateNaming handles keys while asking for the name to save the
+// current search under.
+func (m SearchModel) updateNaming(msg tea.KeyMsg) (SearchModel, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		name := strings.TrimSpace(m.nameInput.Value())
+		if name == "" {
+			return m, nil
+		}
+		m.naming = false
+		m.nameInput.Blur()
+		return m, saveSearch(m.database, name, m.query, m.regex)
+	case "esc":
+		m.naming = false
+		m.nameInput.Blur()
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.nameInput, cmd = m.nameInput.Update(msg)
+	return m, cmd
+}
+
+// Naming reports whether the model is asking for a name, so the app
+// passes it every key.
+func (m SearchModel) Naming() bool {
+	return m.naming
+}
+
+// recall puts the history entry at historyIdx, or the draft, in the input.
+func (m *SearchModel) recall() {
+	if m.historyIdx < 0 {
+		m.input.SetValue(m.draft)
+	} else {
+		e := m.history[m.historyIdx]
+		m.input.SetValue(e.Query)
+		m.setRegex(e.Regex)
+	}
+	m.input.CursorEnd()
+}
+
+func (m *SearchModel) setRegex(on bool) {
+	m.regex = on
+	m.input.Placeholder = searchPlaceholders[0]
+	if on {
+		m.input.Placeholder = searchPlaceholders[1]
+	}
+}
+
+func (m SearchModel) findSaved(name string) *db.SavedSearch {
+	name = strings.TrimSpace(name)
+	for i := range m.saved {
+		if m.saved[i].Name == name {
+			return &m.saved[i]
+		}
+	}
+	return nil
+}
+
+// runSearch starts m.query over from the first page.
+func (m SearchModel) runSearch() (SearchModel, tea.Cmd) {
+	m.loading = true
+	m.loadingMore = false
+	return m, searchVerses(m.database, m.versionCode, m.query, db.SearchOptions{Sort: m.sort, Limit: searchPageSize, Regex: m.regex})
+}
+
+// loadMore fetches the next page once the cursor moves past the last
+// result shown.
+func (m SearchModel) loadMore() (SearchModel, tea.Cmd) {
+	if m.loading || m.loadingMore || len(m.results) >= m.total {
+		return m, nil
+	}
+	m.loadingMore = true
+	opts := db.SearchOptions{Sort: m.sort, Offset: len(m.results), Limit: searchPageSize, Regex: m.regex}
+	return m, searchVerses(m.database, m.versionCode, m.query, opts)
+}
+
 func (m SearchModel) View() string {
 	var b strings.Builder
 
 	inputStyle := lipgloss.NewStyle().Padding(0, 1)
-	b.WriteString(inputStyle.Render(m.input.View()))
-	b.WriteString("\n\n")
+	input := m.input.View()
+	if m.regex {
+		input = lipgloss.NewStyle().Foreground(m.theme.Secondary).Bold(true).Render("[.*] ") + input
+	}
+	b.WriteString(inputStyle.Render(input))
+	b.WriteString("\n")
+	if m.naming {
+		b.WriteString(inputStyle.Render(m.nameInput.View()) + "\n")
+	}
+	if m.statusMsg != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.Secondary).Bold(true).Render("  "+m.statusMsg) + "\n")
+	}
+	b.WriteString("\n")
 
+	if m.query == "" {
+		b.WriteString(m.renderRecall())
+		return b.String()
+	}
 	if m.loading {
 		b.WriteString("  검색 중...")
 		return b.String()
 	}
+	if m.err != nil {
+		b.WriteString(lipgloss.NewStyle().Foreground(m.theme.Error).Render("  " + m.err.Error()))
+		return b.String()
+	}
 	if m.noResults && m.query != "" {
 		b.WriteString(fmt.Sprintf("  검색 결과가 없습니다: %q", m.query))
 		return b.String()
 	}
 
-	for i, r := range m.results {
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
+	status := fmt.Sprintf("  %d / %d건 · %s", len(m.results), m.total, searchSortLabels[m.sort])
+	if m.regex {
+		status += " · 정규식"
+	} else if q, err := db.ParseSearchQuery(m.query); err == nil && q.IsChosung() && len(m.results) > 0 {
+		status += " · 초성 검색"
+	}
+	b.WriteString(mutedStyle.Render(status + " · o:정렬 · Ctrl+R:정규식 · Ctrl+S:저장"))
+	b.WriteString("\n\n")
+
+	// Results take two lines each; keep the selection in view.
+	sidebarWidth := 0
+	if m.width >= 60 && len(m.bookCounts) > 0 {
+		sidebarWidth = 18
+	}
+	listWidth := m.width - sidebarWidth
+	visible := (m.height - 4) / 2
+	if visible < 1 {
+		visible = 1
+	}
+	first := 0
+	if m.selected >= visible {
+		first = m.selected - visible + 1
+	}
+	last := min(first+visible, len(m.results))
+
+	refStyle := lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true)
+	matchStyle := lipgloss.NewStyle().Background(m.theme.HighlightBg).Bold(true)
+
+	var list strings.Builder
+	for i := first; i < last; i++ {
+		r := m.results[i]
 		cursor := "  "
 		if i == m.selected && !m.input.Focused() {
 			cursor = "▸ "
 		}
 
 		ref := fmt.Sprintf("%s %d:%d", r.Verse.BookName, r.Verse.Chapter, r.Verse.VerseNum)
-		refStyle := lipgloss.NewStyle().Foreground(m.theme.Primary).Bold(true)
 
+		// Long verses show the snippet so the match stays in view.
 		text := r.Verse.Text
-		maxRunes := m.width - 10
+		maxRunes := listWidth - 10
+		if maxRunes > 0 && len([]rune(text)) > maxRunes && r.Snippet != "" {
+			text = r.Snippet
+		}
 		runes := []rune(text)
 		if maxRunes > 0 && len(runes) > maxRunes {
 			text = string(runes[:maxRunes]) + "..."
 		}
+		text = highlightTerms(text, r.Highlights, matchStyle)
+
+		list.WriteString(fmt.Sprintf("%s%s\n    %s\n", cursor, refStyle.Render(ref), text))
+	}
+	if m.loadingMore {
+		list.WriteString(mutedStyle.Render("  더 불러오는 중..."))
+	} else if last == len(m.results) && len(m.results) < m.total {
+		list.WriteString(mutedStyle.Render("  ↓ 더 보기"))
+	}
+
+	if sidebarWidth == 0 {
+		b.WriteString(list.String())
+		return b.String()
+	}
+	left := lipgloss.NewStyle().Width(listWidth).Render(list.String())
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, left, m.renderBookCounts(sidebarWidth, visible*2)))
+	return b.String()
+}
+
+// renderRecall lists recent and saved searches before the first search.
+func (m SearchModel) renderRecall() string {
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.Muted)
+	titleStyle := lipgloss.NewStyle().Bold(true)
+	label := func(query string, regex bool) string {
+		if regex {
+			return query + mutedStyle.Render(" (정규식)")
+		}
+		return query
+	}
+
+	var b strings.Builder
+	rows := max(m.height-6, 2)
+	if len(m.history) > 0 {
+		b.WriteString(titleStyle.Render("  최근 검색") + mutedStyle.Render(" · ↑/↓:불러오기") + "\n")
+		for _, e := range m.history[:min(len(m.history), rows/2)] {
+			b.WriteString("    " + label(e.Query, e.Regex) + "\n")
+		}
+	}
+	if len(m.saved) > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(titleStyle.Render("  저장한 검색") + mutedStyle.Render(" · @이름 Enter:실행") + "\n")
+		for _, s := range m.saved[:min(len(m.saved), rows/2)] {
+			b.WriteString(fmt.Sprintf("    @%s  %s\n", s.Name, mutedStyle.Render(label(s.Query, s.Regex))))
+		}
+	}
+	if b.Len() == 0 {
+		b.WriteString(mutedStyle.Render("  검색어를 입력하고 Enter를 누르세요. Ctrl+R: 정규식"))
+	}
+	return b.String()
+}
 
-		b.WriteString(fmt.Sprintf("%s%s\n    %s\n", cursor, refStyle.Render(ref), text))
+// renderBookCounts lists hits per book, as many as fit in height lines.
+func (m SearchModel) renderBookCounts(width, height int) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("책별"))
+	for i, c := range m.bookCounts {
+		if i+2 > height && i < len(m.bookCounts)-1 {
+			b.WriteString(fmt.Sprintf("\n외 %d권", len(m.bookCounts)-i))
+			break
+		}
+		b.WriteString(fmt.Sprintf("\n%s %d", bible.GetBookAbbrev(c.BookCode), c.Count))
 	}
+	return lipgloss.NewStyle().
+		Width(width).
+		PaddingLeft(1).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderLeft(true).
+		BorderForeground(m.theme.Muted).
+		Foreground(m.theme.Muted).
+		Render(b.String())
+}
 
+// highlightTerms renders every occurrence of terms in text with style.
+// Overlapping terms ("하나", "하나님") merge into one highlighted run.
+func highlightTerms(text string, terms []string, style lipgloss.Style) string {
+	marked := make([]bool, len(text))
+	for _, term := range terms {
+		if term == "" {
+			continue
+		}
+		for i := 0; ; {
+			j := strings.Index(text[i:], term)
+			if j < 0 {
+				break
+			}
+			for k := i + j; k < i+j+len(term); k++ {
+				marked[k] = true
+			}
+			i += j + len(term)
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		j := i
+		for j < len(text) && marked[j] == marked[i] {
+			j++
+		}
+		if marked[i] {
+			b.WriteString(style.Render(text[i:j]))
+		} else {
+			b.WriteString(text[i:j])
+		}
+		i = j
+	}
 	return b.String()
 }
 
+// tryParseReference turns a reference typed in the search box into a jump
+// to its first verse; for lists such as "롬 8:28; 12:1-2" that is 8:28.
 func tryParseReference(query string) *GoToVerseMsg {
-	ref, err := bible.ParseReference(query)
+	ranges, err := bible.ParseReferences(query)
 	if err == nil {
+		start := ranges[0].Start
 		verse := 1
-		if ref.VerseStart > 0 {
-			verse = ref.VerseStart
+		if start.Verse > 0 {
+			verse = start.Verse
 		}
-		return &GoToVerseMsg{BookCode: ref.BookCode, Chapter: ref.Chapter, Verse: verse}
+		return &GoToVerseMsg{BookCode: start.BookCode, Chapter: start.Chapter, Verse: verse}
 	}
 
 	trimmed := strings.TrimSpace(query)
@@ -180,30 +586,41 @@ func tryParseReference(query string) *GoToVerseMsg {
 	return nil
 }
 
+// findBookByInput matches a bare book name exactly, in any language; a
+// partial name is searched as text instead.
 func findBookByInput(name string) *bible.BookInfo {
-	if b, ok := bible.GetBookByName(name); ok {
-		return b
-	}
-	if b, ok := bible.GetBookByAbbrev(name); ok {
-		return b
-	}
-	if b, ok := bible.GetBookByCode(strings.ToLower(name)); ok {
+	if b, ok := bible.GetBookByAnyName(name); ok {
 		return b
 	}
 	return nil
 }
 
-func searchVerses(database *db.DB, versionCode, query string, limit int) tea.Cmd {
+func searchVerses(database *db.DB, versionCode, query string, opts db.SearchOptions) tea.Cmd {
 	return func() tea.Msg {
 		if database == nil {
-			return SearchResultsMsg{Err: fmt.Errorf("no database")}
+			return SearchResultsMsg{Query: query, Offset: opts.Offset, Err: fmt.Errorf("no database")}
 		}
 
-		if msg := tryParseReference(query); msg != nil {
-			return *msg
+		if opts.Offset == 0 && !opts.Regex {
+			if msg := tryParseReference(query); msg != nil {
+				return *msg
+			}
 		}
 
-		results, err := database.SearchVerses(versionCode, query, limit)
-		return SearchResultsMsg{Results: results, Query: query, Err: err}
+		q, err := db.ParseSearchQuery(query)
+		if err != nil {
+			return SearchResultsMsg{Query: query, Offset: opts.Offset, Err: err}
+		}
+		page, err := database.Search(versionCode, q, opts)
+		if err != nil {
+			return SearchResultsMsg{Query: query, Offset: opts.Offset, Err: err}
+		}
+		return SearchResultsMsg{
+			Results:    page.Results,
+			Query:      query,
+			Offset:     opts.Offset,
+			Total:      page.Total,
+			BookCounts: page.BookCounts,
+		}
 	}
 }
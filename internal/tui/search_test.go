@@ -1,16 +1,19 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/yangsijun/bible-tui/internal/db"
 	"github.com/yangsijun/bible-tui/internal/tui/styles"
 )
 
 func TestSearchModel_Init(t *testing.T) {
-	m := NewSearch(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewSearch(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	if m.input.Value() != "" {
 		t.Error("expected empty input")
 	}
@@ -20,7 +23,7 @@ func TestSearchModel_Init(t *testing.T) {
 }
 
 func TestSearchModel_Results(t *testing.T) {
-	m := NewSearch(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewSearch(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	results := []db.SearchResult{
 		{Verse: db.Verse{BookName: "창세기", Chapter: 1, VerseNum: 1, Text: "태초에 하나님이", BookCode: "gen"}},
 	}
@@ -34,7 +37,7 @@ func TestSearchModel_Results(t *testing.T) {
 }
 
 func TestSearchModel_NoResults(t *testing.T) {
-	m := NewSearch(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewSearch(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.query = "없는단어"
 	updated, _ := m.Update(SearchResultsMsg{Results: []db.SearchResult{}, Query: "없는단어"})
 	if !updated.noResults {
@@ -43,7 +46,7 @@ func TestSearchModel_NoResults(t *testing.T) {
 }
 
 func TestSearchModel_Navigation(t *testing.T) {
-	m := NewSearch(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewSearch(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.results = []db.SearchResult{
 		{Verse: db.Verse{BookName: "창세기", Chapter: 1, VerseNum: 1, Text: "t1", BookCode: "gen"}},
 		{Verse: db.Verse{BookName: "창세기", Chapter: 1, VerseNum: 2, Text: "t2", BookCode: "gen"}},
@@ -62,13 +65,110 @@ func TestSearchModel_Navigation(t *testing.T) {
 }
 
 func TestSearchModel_View(t *testing.T) {
-	m := NewSearch(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewSearch(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	v := m.View()
 	if v == "" {
 		t.Error("expected non-empty view")
 	}
 }
 
+func TestSearchVerses_Chosung(t *testing.T) {
+	database := setupVersionsDB(t)
+
+	msg := searchVerses(database, "GAE", "ㄱㅈ", db.SearchOptions{Limit: 20})().(SearchResultsMsg)
+	if msg.Err != nil {
+		t.Fatalf("searchVerses: %v", msg.Err)
+	}
+	if len(msg.Results) != 3 {
+		t.Fatalf("expected 3 results for ㄱㅈ, got %d", len(msg.Results))
+	}
+	if hl := msg.Results[0].Highlights; len(hl) != 1 || hl[0] != "개정" {
+		t.Errorf("expected 개정 highlighted, got %v", hl)
+	}
+
+	m := NewSearch(database, "GAE", styles.DefaultDarkTheme(), 80, 24)
+	m.query = "ㄱㅈ"
+	updated, _ := m.Update(msg)
+	v := updated.View()
+	if !strings.Contains(v, "초성 검색") || !strings.Contains(v, "개정") {
+		t.Errorf("expected chosung label and matched text in view, got:\n%s", v)
+	}
+}
+
+func TestSearchModel_QueryError(t *testing.T) {
+	database := setupVersionsDB(t)
+
+	msg := searchVerses(database, "GAE", "개정 OR", db.SearchOptions{Limit: 20})().(SearchResultsMsg)
+	if msg.Err == nil {
+		t.Fatal("expected a query error")
+	}
+	m := NewSearch(database, "GAE", styles.DefaultDarkTheme(), 80, 24)
+	m.query = "개정 OR"
+	updated, _ := m.Update(msg)
+	if v := updated.View(); !strings.Contains(v, "OR needs a word") {
+		t.Errorf("expected the error in the view, got:\n%s", v)
+	}
+}
+
+func TestSearchModel_LoadMoreAndSort(t *testing.T) {
+	database := setupVersionsDB(t)
+	vID, err := database.InsertVersion("NEW", "새번역", "ko")
+	if err != nil {
+		t.Fatalf("InsertVersion: %v", err)
+	}
+	bookID, err := database.InsertBook(vID, "psa", "시편", "시", "old", 150, 18)
+	if err != nil {
+		t.Fatalf("InsertBook: %v", err)
+	}
+	for n := 1; n <= 25; n++ {
+		if _, err := database.InsertVerse(bookID, 119, n, fmt.Sprintf("주의 말씀 %d", n), "", false); err != nil {
+			t.Fatalf("InsertVerse: %v", err)
+		}
+	}
+
+	m := NewSearch(database, "NEW", styles.DefaultDarkTheme(), 80, 24)
+	m.query = "말씀"
+	m, cmd := m.runSearch()
+	m, _ = m.Update(cmd())
+	if len(m.results) != searchPageSize || m.total != 25 {
+		t.Fatalf("expected first page of %d with total 25, got %d of %d", searchPageSize, len(m.results), m.total)
+	}
+	if v := m.View(); !strings.Contains(v, "20 / 25건") || !strings.Contains(v, "책별") || !strings.Contains(v, "시 25") {
+		t.Errorf("expected counts and book sidebar in view, got:\n%s", v)
+	}
+
+	m.input.Blur()
+	m.selected = len(m.results) - 1
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if cmd == nil || !m.loadingMore {
+		t.Fatal("expected moving past the last result to load more")
+	}
+	m, _ = m.Update(cmd())
+	if len(m.results) != 25 || m.loadingMore {
+		t.Fatalf("expected all 25 results after loading more, got %d", len(m.results))
+	}
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}); cmd != nil {
+		t.Error("expected no more pages after the last one")
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if m.sort != db.SortCanonical || cmd == nil {
+		t.Fatalf("expected o to switch to canonical order and search again")
+	}
+	m, _ = m.Update(cmd())
+	if m.results[0].Verse.VerseNum != 1 || m.results[1].Verse.VerseNum != 2 {
+		t.Errorf("expected Bible order, got %d, %d", m.results[0].Verse.VerseNum, m.results[1].Verse.VerseNum)
+	}
+}
+
+func TestHighlightTerms(t *testing.T) {
+	style := lipgloss.NewStyle().Transform(func(s string) string { return "[" + s + "]" })
+	got := highlightTerms("하나님이 하나님의 나라", []string{"하나", "하나님"}, style)
+	if got != "[하나님]이 [하나님]의 나라" {
+		t.Errorf("unexpected text %q", got)
+	}
+}
+
 func TestTryParseReference_FullRef(t *testing.T) {
 	msg := tryParseReference("창세기 2")
 	if msg == nil {
@@ -126,8 +226,28 @@ func TestTryParseReference_EnglishCode(t *testing.T) {
 	}
 }
 
+func TestTryParseReference_RichForms(t *testing.T) {
+	tests := []struct {
+		query          string
+		chapter, verse int
+	}{
+		{"창 1:26-2:3", 1, 26},
+		{"롬 8:28; 12:1-2", 8, 28},
+		{"창세기 3장 5절", 3, 5},
+	}
+	for _, tt := range tests {
+		msg := tryParseReference(tt.query)
+		if msg == nil {
+			t.Fatalf("expected GoToVerseMsg for %q", tt.query)
+		}
+		if msg.Chapter != tt.chapter || msg.Verse != tt.verse {
+			t.Errorf("for %q expected %d:%d, got %d:%d", tt.query, tt.chapter, tt.verse, msg.Chapter, msg.Verse)
+		}
+	}
+}
+
 func TestSearchModel_ViewWithResults(t *testing.T) {
-	m := NewSearch(nil, styles.DefaultDarkTheme(), 80, 24)
+	m := NewSearch(nil, "GAE", styles.DefaultDarkTheme(), 80, 24)
 	m.results = []db.SearchResult{
 		{Verse: db.Verse{BookName: "창세기", Chapter: 1, VerseNum: 1, Text: "태초에 하나님이", BookCode: "gen"}},
 	}
@@ -136,3 +256,122 @@ func TestSearchModel_ViewWithResults(t *testing.T) {
 		t.Error("expected non-empty view")
 	}
 }
+
+func TestSearchModel_RegexToggle(t *testing.T) {
+	database := setupVersionsDB(t)
+	m := NewSearch(database, "GAE", styles.DefaultDarkTheme(), 80, 24)
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if !m.regex || !strings.Contains(m.View(), "[.*]") {
+		t.Fatal("expected Ctrl+R to turn on regex mode")
+	}
+
+	m.query = "^개정 [12]절$"
+	m, cmd := m.runSearch()
+	m, _ = m.Update(cmd())
+	if len(m.results) != 2 || !strings.Contains(m.View(), "정규식") {
+		t.Fatalf("expected 2 regex results, got %d (err=%v)", len(m.results), m.err)
+	}
+
+	m.query = "개정("
+	m, cmd = m.runSearch()
+	m, _ = m.Update(cmd())
+	if m.err == nil || !strings.Contains(m.View(), "invalid regex") {
+		t.Errorf("expected invalid regex error in view, got %v", m.err)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if m.regex {
+		t.Error("expected Ctrl+R to turn regex mode off again")
+	}
+}
+
+func TestSearchModel_HistoryRecall(t *testing.T) {
+	database := setupVersionsDB(t)
+	for _, q := range []string{"개정", "^개정 1", "한글"} {
+		if err := database.AddSearchHistory(q, q == "^개정 1"); err != nil {
+			t.Fatalf("AddSearchHistory: %v", err)
+		}
+	}
+	m := NewSearch(database, "GAE", styles.DefaultDarkTheme(), 80, 24)
+	m, _ = m.Update(LoadSearchHistory(database)())
+	if v := m.View(); !strings.Contains(v, "최근 검색") || !strings.Contains(v, "^개정 1") {
+		t.Errorf("expected recent searches before searching, got:\n%s", v)
+	}
+
+	m.input.SetValue("초안")
+	up := tea.KeyMsg{Type: tea.KeyUp}
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	m, _ = m.Update(up)
+	if m.input.Value() != "한글" || m.regex {
+		t.Errorf("expected newest search first, got %q (regex=%v)", m.input.Value(), m.regex)
+	}
+	m, _ = m.Update(up)
+	if m.input.Value() != "^개정 1" || !m.regex {
+		t.Errorf("expected regex search recalled with regex mode, got %q (regex=%v)", m.input.Value(), m.regex)
+	}
+	m, _ = m.Update(up)
+	m, _ = m.Update(up)
+	if m.input.Value() != "개정" {
+		t.Errorf("expected to stop at the oldest search, got %q", m.input.Value())
+	}
+	for i := 0; i < 3; i++ {
+		m, _ = m.Update(down)
+	}
+	if m.input.Value() != "초안" || !m.input.Focused() {
+		t.Errorf("expected the draft back in the input, got %q", m.input.Value())
+	}
+
+	m.input.SetValue("한글")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, msg := range cmd().(tea.BatchMsg) {
+		m, _ = m.Update(msg())
+	}
+	if len(m.results) != 0 || m.history[0].Query != "한글" {
+		t.Errorf("expected the search recorded first in history, got %+v", m.history)
+	}
+}
+
+func TestSearchModel_SavedSearches(t *testing.T) {
+	database := setupVersionsDB(t)
+	m := NewSearch(database, "GAE", styles.DefaultDarkTheme(), 80, 24)
+
+	m.input.SetValue("개정")
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, msg := range cmd().(tea.BatchMsg) {
+		m, _ = m.Update(msg())
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if !m.Naming() || !strings.Contains(m.View(), "저장할 이름") {
+		t.Fatal("expected Ctrl+S to ask for a name")
+	}
+	for _, r := range "개정본" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = m.Update(cmd())
+	if m.Naming() || len(m.saved) != 1 || !strings.Contains(m.View(), "검색 저장: @개정본") {
+		t.Fatalf("expected the search saved, got %+v", m.saved)
+	}
+
+	m = NewSearch(database, "GAE", styles.DefaultDarkTheme(), 80, 24)
+	m, _ = m.Update(LoadSearchHistory(database)())
+	if !strings.Contains(m.View(), "@개정본") {
+		t.Errorf("expected saved search listed, got:\n%s", m.View())
+	}
+	m.input.SetValue("@개정본")
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	for _, msg := range cmd().(tea.BatchMsg) {
+		m, _ = m.Update(msg())
+	}
+	if m.query != "개정" || len(m.results) != 3 {
+		t.Errorf("expected @name to run the saved search, got %q with %d results", m.query, len(m.results))
+	}
+
+	m.input.SetValue("@없음")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(m.View(), "저장한 검색이 없습니다") {
+		t.Error("expected unknown saved search message")
+	}
+}
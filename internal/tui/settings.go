@@ -13,8 +13,9 @@ import (
 )
 
 type SettingsLoadedMsg struct {
-	Config *config.Config
-	Err    error
+	Config   *config.Config
+	Versions []db.Version
+	Err      error
 }
 
 type SettingsSavedMsg struct {
@@ -25,11 +26,16 @@ type ThemeChangedMsg struct {
 	Theme *styles.Theme
 }
 
+// VersionChangedMsg switches the Bible version used by reading and search.
+type VersionChangedMsg struct {
+	Code string
+	Err  error
+}
+
 var (
 	fontSizeLabels   = []string{"작게", "보통", "크게"}
-	versionLabels    = []string{"개역개정 (GAE)"}
-	versionCodes     = []string{"GAE"}
-	settingsRowCount = 3
+	defaultVersions  = []db.Version{{Code: "GAE", Name: "개역개정", Lang: "ko"}}
+	settingsRowCount = 4
 )
 
 type SettingsModel struct {
@@ -41,8 +47,11 @@ type SettingsModel struct {
 	themeIdx    int
 	fontSizeIdx int
 	versionIdx  int
-	loaded      bool
-	saved       bool
+	versions    []db.Version // versions present in the database
+	// autoComplete checks plan readings off once their chapters are read.
+	autoComplete bool
+	loaded       bool
+	saved        bool
 }
 
 func NewSettings(database *db.DB, theme *styles.Theme, width, height int) SettingsModel {
@@ -51,6 +60,8 @@ func NewSettings(database *db.DB, theme *styles.Theme, width, height int) Settin
 		theme:    theme,
 		width:    width,
 		height:   height,
+		// The config default, kept until the saved settings load.
+		autoComplete: true,
 	}
 }
 
@@ -60,7 +71,11 @@ func LoadSettings(database *db.DB) tea.Cmd {
 			return SettingsLoadedMsg{Err: fmt.Errorf("no database")}
 		}
 		cfg, err := config.LoadConfig(database)
-		return SettingsLoadedMsg{Config: cfg, Err: err}
+		if err != nil {
+			return SettingsLoadedMsg{Err: err}
+		}
+		versions, err := database.ListVersions()
+		return SettingsLoadedMsg{Config: cfg, Versions: versions, Err: err}
 	}
 }
 
@@ -80,7 +95,9 @@ func (m SettingsModel) Update(msg tea.Msg) (SettingsModel, tea.Cmd) {
 		if m.fontSizeIdx >= len(fontSizeLabels) {
 			m.fontSizeIdx = len(fontSizeLabels) - 1
 		}
-		m.versionIdx = versionCodeToIdx(msg.Config.VersionCode)
+		m.versions = msg.Versions
+		m.versionIdx = m.versionCodeToIdx(msg.Config.VersionCode)
+		m.autoComplete = msg.Config.AutoCompletePlans
 		return m, nil
 
 	case tea.KeyMsg:
@@ -103,7 +120,7 @@ func (m SettingsModel) Update(msg tea.Msg) (SettingsModel, tea.Cmd) {
 			return m, nil
 		case "enter", "s":
 			m.saved = true
-			return m, tea.Batch(m.saveConfig(), m.emitThemeChange())
+			return m, tea.Batch(m.saveConfig(), m.emitThemeChange(), m.emitVersionChange())
 		}
 	}
 	return m, nil
@@ -123,7 +140,8 @@ func (m SettingsModel) View() string {
 	}{
 		{"테마", themeNames[m.themeIdx]},
 		{"글자크기", fontSizeLabels[m.fontSizeIdx]},
-		{"기본역본", versionLabels[m.versionIdx]},
+		{"기본역본", m.versionLabel()},
+		{"자동체크", onOffLabel(m.autoComplete)},
 	}
 
 	for i, row := range rows {
@@ -159,7 +177,9 @@ func (m *SettingsModel) incrementOption() {
 	case 1:
 		m.fontSizeIdx = (m.fontSizeIdx + 1) % len(fontSizeLabels)
 	case 2:
-		m.versionIdx = (m.versionIdx + 1) % len(versionLabels)
+		m.versionIdx = (m.versionIdx + 1) % len(m.versionList())
+	case 3:
+		m.autoComplete = !m.autoComplete
 	}
 }
 
@@ -171,7 +191,10 @@ func (m *SettingsModel) decrementOption() {
 	case 1:
 		m.fontSizeIdx = (m.fontSizeIdx - 1 + len(fontSizeLabels)) % len(fontSizeLabels)
 	case 2:
-		m.versionIdx = (m.versionIdx - 1 + len(versionLabels)) % len(versionLabels)
+		n := len(m.versionList())
+		m.versionIdx = (m.versionIdx - 1 + n) % n
+	case 3:
+		m.autoComplete = !m.autoComplete
 	}
 }
 
@@ -179,9 +202,10 @@ func (m SettingsModel) saveConfig() tea.Cmd {
 	return func() tea.Msg {
 		themeNames := styles.AllThemeNames()
 		cfg := &config.Config{
-			ThemeName:   themeNames[m.themeIdx],
-			FontSize:    m.fontSizeIdx + 1,
-			VersionCode: versionCodes[m.versionIdx],
+			ThemeName:         themeNames[m.themeIdx],
+			FontSize:          m.fontSizeIdx + 1,
+			VersionCode:       m.versionList()[m.versionIdx].Code,
+			AutoCompletePlans: m.autoComplete,
 		}
 		if m.database == nil {
 			return SettingsSavedMsg{Err: fmt.Errorf("no database")}
@@ -198,6 +222,34 @@ func (m SettingsModel) emitThemeChange() tea.Cmd {
 	}
 }
 
+func (m SettingsModel) emitVersionChange() tea.Cmd {
+	code := m.versionList()[m.versionIdx].Code
+	return func() tea.Msg {
+		return VersionChangedMsg{Code: code}
+	}
+}
+
+// versionList returns the selectable versions, falling back to the default
+// version when nothing has been crawled yet.
+func (m SettingsModel) versionList() []db.Version {
+	if len(m.versions) == 0 {
+		return defaultVersions
+	}
+	return m.versions
+}
+
+func (m SettingsModel) versionLabel() string {
+	v := m.versionList()[m.versionIdx]
+	return fmt.Sprintf("%s (%s)", v.Name, v.Code)
+}
+
+func onOffLabel(on bool) string {
+	if on {
+		return "켬"
+	}
+	return "끔"
+}
+
 func themeNameToIdx(name string) int {
 	for i, n := range styles.AllThemeNames() {
 		if n == name {
@@ -207,9 +259,9 @@ func themeNameToIdx(name string) int {
 	return 0
 }
 
-func versionCodeToIdx(code string) int {
-	for i, c := range versionCodes {
-		if c == code {
+func (m SettingsModel) versionCodeToIdx(code string) int {
+	for i, v := range m.versionList() {
+		if v.Code == code {
 			return i
 		}
 	}
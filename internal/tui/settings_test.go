@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/yangsijun/bible-tui/internal/config"
+	"github.com/yangsijun/bible-tui/internal/db"
 	"github.com/yangsijun/bible-tui/internal/tui/styles"
 )
 
@@ -96,10 +97,12 @@ func TestSettingsModel_Navigation(t *testing.T) {
 	}
 
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
-	if m.focusRow != 2 {
-		t.Errorf("expected focusRow=2 (clamped), got %d", m.focusRow)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if m.focusRow != 3 {
+		t.Errorf("expected focusRow=3 (clamped), got %d", m.focusRow)
 	}
 
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
 	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("k")})
 	if m.focusRow != 1 {
 		t.Errorf("expected focusRow=1 after up, got %d", m.focusRow)
@@ -246,3 +249,49 @@ func TestSettingsModel_SaveEnter(t *testing.T) {
 		t.Error("expected saved=true after pressing enter")
 	}
 }
+
+func TestSettingsModel_VersionsFromDB(t *testing.T) {
+	m := newTestSettingsModel()
+	cfg := &config.Config{ThemeName: "dark", FontSize: 2, VersionCode: "HAN"}
+	versions := []db.Version{
+		{Code: "GAE", Name: "개역개정"},
+		{Code: "HAN", Name: "개역한글"},
+	}
+	m, _ = m.Update(SettingsLoadedMsg{Config: cfg, Versions: versions})
+
+	if m.versionIdx != 1 {
+		t.Errorf("expected versionIdx=1 for 'HAN', got %d", m.versionIdx)
+	}
+	if !strings.Contains(m.View(), "개역한글 (HAN)") {
+		t.Error("view should contain '개역한글 (HAN)'")
+	}
+
+	m.focusRow = 2
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if m.versionIdx != 0 {
+		t.Errorf("expected versionIdx=0 after wrap, got %d", m.versionIdx)
+	}
+
+	msg := m.emitVersionChange()().(VersionChangedMsg)
+	if msg.Code != "GAE" {
+		t.Errorf("expected VersionChangedMsg for GAE, got %q", msg.Code)
+	}
+}
+
+func TestSettingsModel_AutoComplete(t *testing.T) {
+	m := newTestSettingsModel()
+	cfg := &config.Config{ThemeName: "dark", FontSize: 2, VersionCode: "GAE", AutoCompletePlans: true}
+	m, _ = m.Update(SettingsLoadedMsg{Config: cfg})
+	if !strings.Contains(m.View(), "켬") {
+		t.Error("view should show auto-complete on")
+	}
+
+	m.focusRow = 3
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("l")})
+	if m.autoComplete {
+		t.Error("expected auto-complete off after toggling")
+	}
+	if !strings.Contains(m.View(), "끔") {
+		t.Error("view should show auto-complete off")
+	}
+}
@@ -0,0 +1,115 @@
+-- Database as created by releases before versioned migrations:
+-- the flat baseline schema with no schema_migrations table, plus a
+-- little user data that upgrades must preserve.
+
+CREATE TABLE versions (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	code TEXT UNIQUE NOT NULL,
+	name TEXT NOT NULL,
+	lang TEXT NOT NULL DEFAULT 'ko'
+);
+CREATE TABLE books (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	version_id INTEGER NOT NULL REFERENCES versions(id),
+	code TEXT NOT NULL,
+	name_ko TEXT NOT NULL,
+	abbrev_ko TEXT NOT NULL,
+	testament TEXT NOT NULL,
+	chapter_count INTEGER NOT NULL,
+	sort_order INTEGER NOT NULL,
+	UNIQUE(version_id, code)
+);
+CREATE TABLE verses (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	book_id INTEGER NOT NULL REFERENCES books(id),
+	chapter INTEGER NOT NULL,
+	verse_num INTEGER NOT NULL,
+	text TEXT NOT NULL,
+	section_title TEXT,
+	has_footnote BOOLEAN NOT NULL DEFAULT 0,
+	UNIQUE(book_id, chapter, verse_num)
+);
+CREATE TABLE footnotes (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	verse_id INTEGER NOT NULL REFERENCES verses(id),
+	marker TEXT,
+	content TEXT NOT NULL
+);
+CREATE VIRTUAL TABLE verses_fts USING fts5(
+	text,
+	content=verses,
+	content_rowid=id,
+	tokenize='unicode61'
+);
+CREATE TRIGGER verses_ai AFTER INSERT ON verses BEGIN
+	INSERT INTO verses_fts(rowid, text) VALUES (new.id, new.text);
+END;
+CREATE TRIGGER verses_ad AFTER DELETE ON verses BEGIN
+	INSERT INTO verses_fts(verses_fts, rowid, text) VALUES('delete', old.id, old.text);
+END;
+CREATE TRIGGER verses_au AFTER UPDATE ON verses BEGIN
+	INSERT INTO verses_fts(verses_fts, rowid, text) VALUES('delete', old.id, old.text);
+	INSERT INTO verses_fts(rowid, text) VALUES (new.id, new.text);
+END;
+CREATE TABLE bookmarks (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	verse_id INTEGER NOT NULL REFERENCES verses(id),
+	note TEXT,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE highlights (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	verse_id INTEGER NOT NULL REFERENCES verses(id),
+	color TEXT NOT NULL DEFAULT 'yellow',
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+	UNIQUE(verse_id)
+);
+CREATE TABLE reading_plans (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	plan_type TEXT NOT NULL,
+	version_id INTEGER NOT NULL REFERENCES versions(id),
+	total_days INTEGER NOT NULL,
+	created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE reading_plan_entries (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	plan_id INTEGER NOT NULL REFERENCES reading_plans(id),
+	day_number INTEGER NOT NULL,
+	book_code TEXT NOT NULL,
+	chapter_start INTEGER NOT NULL,
+	chapter_end INTEGER NOT NULL,
+	completed BOOLEAN NOT NULL DEFAULT 0,
+	completed_at DATETIME,
+	UNIQUE(plan_id, day_number, book_code, chapter_start)
+);
+CREATE TABLE settings (
+	key TEXT PRIMARY KEY,
+	value TEXT NOT NULL
+);
+CREATE TABLE crawl_status (
+	version_code TEXT NOT NULL,
+	book_code TEXT NOT NULL,
+	chapter INTEGER NOT NULL,
+	status TEXT NOT NULL DEFAULT 'pending',
+	verse_count INTEGER,
+	crawled_at DATETIME,
+	error_msg TEXT,
+	PRIMARY KEY(version_code, book_code, chapter)
+);
+
+INSERT INTO versions (code, name, lang) VALUES ('GAE', '개역개정', 'ko');
+INSERT INTO books (version_id, code, name_ko, abbrev_ko, testament, chapter_count, sort_order)
+VALUES (1, 'gen', '창세기', '창', 'old', 50, 1);
+INSERT INTO verses (book_id, chapter, verse_num, text, section_title, has_footnote) VALUES
+	(1, 1, 1, '태초에 하나님이 천지를 창조하시니라', '천지 창조', 0),
+	(1, 1, 2, '땅이 혼돈하고 공허하며 흑암이 깊음 위에 있고', NULL, 1),
+	(1, 1, 3, '하나님이 이르시되 빛이 있으라 하시니 빛이 있었고', NULL, 0);
+INSERT INTO footnotes (verse_id, marker, content) VALUES (2, '1)', '또는 형체가 없는');
+INSERT INTO bookmarks (verse_id, note) VALUES (1, '시작'), (3, NULL);
+INSERT INTO highlights (verse_id, color) VALUES (3, 'green');
+INSERT INTO reading_plans (name, plan_type, version_id, total_days) VALUES ('1년 통독', 'sequential', 1, 365);
+INSERT INTO reading_plan_entries (plan_id, day_number, book_code, chapter_start, chapter_end)
+VALUES (1, 1, 'gen', 1, 3);
+INSERT INTO settings (key, value) VALUES ('theme', 'light');
+INSERT INTO crawl_status (version_code, book_code, chapter, status, verse_count) VALUES ('GAE', 'gen', 1, 'done', 3);